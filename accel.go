@@ -0,0 +1,94 @@
+package gohash
+
+import (
+	"runtime"
+
+	"github.com/klauspost/cpuid/v2"
+	sha256simd "github.com/minio/sha256-simd"
+)
+
+const (
+	implGeneric    = "generic"
+	implStdlib     = "stdlib"
+	implSha256SHA  = "sha256-simd (sha-ni)"
+	implSha256ARM  = "sha256-simd (arm64 sha2)"
+	implBlake3AVX2 = "blake3 (avx2)"
+	implBlake3AVX5 = "blake3 (avx512)"
+)
+
+var (
+	// implementations holds the implementation chosen for algos with
+	// more than one available, populated at init
+	implementations = map[string]string{}
+
+	hasIntelSha = runtime.GOARCH == "amd64" &&
+		cpuid.CPU.Supports(cpuid.SHA, cpuid.SSSE3, cpuid.SSE4)
+	hasArmSha2 = runtime.GOARCH == "arm64" && cpuid.CPU.Supports(cpuid.SHA2)
+	hasAVX2    = cpuid.CPU.Supports(cpuid.AVX2)
+	hasAVX512  = cpuid.CPU.Supports(cpuid.AVX512F)
+	hasNEON    = runtime.GOARCH == "arm64" && cpuid.CPU.Supports(cpuid.ASIMD)
+)
+
+func init() {
+	selectImplementations()
+}
+
+// selectImplementations swaps in accelerated hash functions where the
+// cpu supports them
+func selectImplementations() {
+
+	implementations["sha256"] = implStdlib
+	if hasIntelSha || hasArmSha2 {
+		hashers["sha256"] = sha256SimdSum
+		fastSums["sha256"] = sha256SimdAppend
+		hmacHashes["sha256"] = sha256simd.New
+		if hasIntelSha {
+			implementations["sha256"] = implSha256SHA
+		} else {
+			implementations["sha256"] = implSha256ARM
+		}
+	}
+
+	// the blake3 package dispatches to its own assembly, we just record it
+	switch {
+	case hasAVX512:
+		implementations["blake3-256"] = implBlake3AVX5
+	case hasAVX2:
+		implementations["blake3-256"] = implBlake3AVX2
+	default:
+		implementations["blake3-256"] = implGeneric
+	}
+}
+
+// CPUFeatures returns the detected cpu features relevant for hashing
+func CPUFeatures() []string {
+
+	res := []string{}
+	if hasIntelSha {
+		res = append(res, "sha-ni")
+	}
+	if hasArmSha2 {
+		res = append(res, "sha2")
+	}
+	if hasAVX2 {
+		res = append(res, "avx2")
+	}
+	if hasAVX512 {
+		res = append(res, "avx512")
+	}
+	if hasNEON {
+		res = append(res, "neon")
+	}
+	return res
+}
+
+func sha256SimdSum(b *[]byte) *[]byte {
+	x := sha256simd.Sum256(*b)
+	res := x[:]
+	return &res
+}
+
+func sha256SimdAppend(dst, buf []byte) []byte {
+	sum := sha256simd.Sum256(buf)
+	return append(dst, sum[:]...)
+}
@@ -0,0 +1,98 @@
+package gohash
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NewCoderWithAlphabet creates a Coder for base-N using a custom alphabet,
+// for example one of the many base58 and base62 variants. Like base58,
+// each leading zero byte is encoded as the first symbol of the alphabet
+func NewCoderWithAlphabet(base int, alphabet string) (*Coder, error) {
+
+	symbols := []rune(alphabet)
+	if base < 2 {
+		return nil, fmt.Errorf("base must be at least 2, got %d", base)
+	}
+	if len(symbols) != base {
+		return nil, fmt.Errorf("alphabet has %d symbols, expected %d", len(symbols), base)
+	}
+
+	seen := make(map[rune]bool)
+	for _, r := range symbols {
+		if seen[r] {
+			return nil, fmt.Errorf("alphabet has duplicate symbol %q", r)
+		}
+		seen[r] = true
+	}
+
+	return &Coder{
+		encoding: fmt.Sprintf("base%d-custom", base),
+		alphabet: symbols,
+	}, nil
+}
+
+func encodeAlphabet(c *Coder, src []byte) ([]byte, error) {
+	return encodeBaseN(c.alphabet, src), nil
+}
+
+func decodeAlphabet(c *Coder, src []byte) ([]byte, error) {
+	return decodeBaseN(c.encoding, c.alphabet, src)
+}
+
+// encodeBaseN encodes src as one big number in the base of alphabet, with
+// each leading zero byte as the first symbol, so every byte string
+// round-trips
+func encodeBaseN(alphabet []rune, src []byte) []byte {
+
+	base := big.NewInt(int64(len(alphabet)))
+	n := new(big.Int).SetBytes(src)
+	mod := new(big.Int)
+
+	digits := []rune{}
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		digits = append(digits, alphabet[mod.Int64()])
+	}
+	for i := 0; i < len(src) && src[i] == 0; i++ {
+		digits = append(digits, alphabet[0])
+	}
+
+	// digits were collected least significant first
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return []byte(string(digits))
+}
+
+func decodeBaseN(encoding string, alphabet []rune, src []byte) ([]byte, error) {
+
+	base := big.NewInt(int64(len(alphabet)))
+	n := new(big.Int)
+	zeros := 0
+	leading := true
+	for i, r := range string(src) {
+		idx := indexOfRune(alphabet, r)
+		if idx == -1 {
+			return nil, newDecodeError(encoding, src, i, "invalid character")
+		}
+		if leading && idx == 0 {
+			zeros++
+			continue
+		}
+		leading = false
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+func indexOfRune(list []rune, r rune) int {
+
+	for i, v := range list {
+		if v == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoderWithAlphabet(t *testing.T) {
+
+	for _, tc := range []struct {
+		alphabet string
+		clear    string
+		coded    string
+	}{
+		{"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", "hello world", "AAwf93rvy4aWQVw"},
+		{"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz", "\x00\x00hi", "006x7"},
+		{"123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ", "hello world", "rTu1dk6cWsRYjYu"},
+		{"01", "\x05", "101"},
+		{"01", "", ""},
+	} {
+		coder, err := NewCoderWithAlphabet(len([]rune(tc.alphabet)), tc.alphabet)
+		assert.Equal(t, nil, err)
+
+		res, err := coder.Encode([]byte(tc.clear))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tc.coded, string(res))
+
+		dec, err := coder.Decode(res)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte(tc.clear), dec)
+	}
+}
+
+func TestCoderWithAlphabetMatchesBase58(t *testing.T) {
+
+	coder, err := NewCoderWithAlphabet(58, "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	assert.Equal(t, nil, err)
+
+	src := []byte("\x00The quick brown fox jumps over the lazy dog")
+	res, _ := coder.Encode(src)
+	expected, _ := NewCoder("base58").Encode(src)
+	assert.Equal(t, string(expected), string(res))
+}
+
+func TestCoderWithAlphabetInvalid(t *testing.T) {
+
+	_, err := NewCoderWithAlphabet(3, "01")
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoderWithAlphabet(2, "00")
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoderWithAlphabet(1, "0")
+	assert.NotEqual(t, nil, err)
+
+	coder, _ := NewCoderWithAlphabet(2, "01")
+	_, err = coder.Decode([]byte("012"))
+	assert.NotEqual(t, nil, err)
+}
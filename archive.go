@@ -0,0 +1,128 @@
+package gohash
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// IsArchive returns true if name has the extension of an archive read by
+// WalkArchive
+func IsArchive(name string) bool {
+	return archiveFormat(name) != ""
+}
+
+// archiveFormat returns "tar", "tar.gz" or "zip" by the extension of
+// name, or "" for other files
+func archiveFormat(name string) string {
+
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".tar"):
+		return "tar"
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	}
+	return ""
+}
+
+// WalkArchive calls fn with the name and contents of each file in the tar,
+// tar.gz or zip archive, in archive order, without extracting it.
+// Directories, links and other special members are skipped
+func WalkArchive(name string, fn func(member string, r io.Reader) error) error {
+
+	format := archiveFormat(name)
+	if format == "" {
+		return fmt.Errorf("%s is not a tar, tar.gz or zip archive", name)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if format == "zip" {
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		return walkZip(f, fi.Size(), fn)
+	}
+
+	var r io.Reader = f
+	if format == "tar.gz" {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return walkTar(r, fn)
+}
+
+func walkTar(r io.Reader, fn func(member string, r io.Reader) error) error {
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZip(r io.ReaderAt, size int64, fn func(member string, r io.Reader) error) error {
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = fn(zf.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HashArchive hashes each file in the archive with algo, see WalkArchive.
+// The results are named as the archive followed by "/" and the member
+func HashArchive(name string, algo string, encoding string) ([]Result, error) {
+
+	res := []Result{}
+	err := WalkArchive(name, func(member string, r io.Reader) error {
+		result, err := ReaderResult(algo, name+"/"+member, encoding, r)
+		if err != nil {
+			return fmt.Errorf("%s/%s: %v", name, member, err)
+		}
+		res = append(res, *result)
+		return nil
+	})
+	return res, err
+}
@@ -0,0 +1,110 @@
+package gohash
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	archiveMembers = []struct{ name, data string }{
+		{"bin/tool", "tool"},
+		{"README", fox},
+	}
+)
+
+func writeTar(t *testing.T, w io.Writer) {
+
+	tw := tar.NewWriter(w)
+	assert.Equal(t, nil, tw.WriteHeader(&tar.Header{Name: "bin/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, m := range archiveMembers {
+		assert.Equal(t, nil, tw.WriteHeader(&tar.Header{Name: m.name, Mode: 0644, Size: int64(len(m.data))}))
+		_, err := tw.Write([]byte(m.data))
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, nil, tw.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "README"}))
+	assert.Equal(t, nil, tw.Close())
+}
+
+func testArchives(t *testing.T) []string {
+
+	dir := t.TempDir()
+	res := []string{}
+
+	name := filepath.Join(dir, "a.tar")
+	f, err := os.Create(name)
+	assert.Equal(t, nil, err)
+	writeTar(t, f)
+	assert.Equal(t, nil, f.Close())
+	res = append(res, name)
+
+	name = filepath.Join(dir, "a.tar.gz")
+	f, err = os.Create(name)
+	assert.Equal(t, nil, err)
+	gz := gzip.NewWriter(f)
+	writeTar(t, gz)
+	assert.Equal(t, nil, gz.Close())
+	assert.Equal(t, nil, f.Close())
+	res = append(res, name)
+
+	name = filepath.Join(dir, "a.zip")
+	f, err = os.Create(name)
+	assert.Equal(t, nil, err)
+	zw := zip.NewWriter(f)
+	_, err = zw.Create("bin/")
+	assert.Equal(t, nil, err)
+	for _, m := range archiveMembers {
+		w, err := zw.Create(m.name)
+		assert.Equal(t, nil, err)
+		_, err = w.Write([]byte(m.data))
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, nil, zw.Close())
+	assert.Equal(t, nil, f.Close())
+	res = append(res, name)
+	return res
+}
+
+func TestIsArchive(t *testing.T) {
+
+	assert.Equal(t, true, IsArchive("layer.tar"))
+	assert.Equal(t, true, IsArchive("release.TGZ"))
+	assert.Equal(t, true, IsArchive("release.tar.gz"))
+	assert.Equal(t, true, IsArchive("bundle.zip"))
+	assert.Equal(t, false, IsArchive("image.iso"))
+}
+
+func TestHashArchive(t *testing.T) {
+
+	for _, name := range testArchives(t) {
+		res, err := HashArchive(name, "sha1", "")
+		assert.Equal(t, nil, err, name)
+		assert.Equal(t, 2, len(res), name)
+		for i, m := range archiveMembers {
+			assert.Equal(t, name+"/"+m.name, res[i].Name)
+			assert.Equal(t, *NewCalculator([]byte(m.data)).Sum("sha1"), res[i].Digest)
+			assert.Equal(t, int64(len(m.data)), res[i].Size)
+		}
+	}
+}
+
+func TestHashArchiveErrors(t *testing.T) {
+
+	_, err := HashArchive("image.iso", "sha1", "")
+	assert.Equal(t, "image.iso is not a tar, tar.gz or zip archive", err.Error())
+
+	name := testArchives(t)[0]
+	_, err = HashArchive(name, "nope", "")
+	assert.Equal(t, name+"/bin/tool: unknown algo nope", err.Error())
+
+	broken := filepath.Join(t.TempDir(), "broken.zip")
+	assert.Equal(t, nil, os.WriteFile(broken, []byte("not a zip"), 0644))
+	_, err = HashArchive(broken, "sha1", "")
+	assert.Equal(t, zip.ErrFormat, err)
+}
@@ -0,0 +1,149 @@
+package gohash
+
+import (
+	"context"
+	"fmt"
+)
+
+// Match is a key found by a search started with Start
+type Match struct {
+	Key string
+
+	// hex encoded digest of the key
+	Hash string
+}
+
+// asyncSearch is a search running in the background
+type asyncSearch struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	results  chan Match
+	progress chan Stats
+	done     chan struct{}
+	err      error
+}
+
+// Start starts FindSequential in the background and returns at once. The
+// matches are sent on Results, which is closed when the search ends, and
+// the stats every progress interval on Progress. Stop ends it early
+func (h *Hasher) Start() error {
+
+	mutex.Lock()
+	running := h.async != nil
+	mutex.Unlock()
+	if running {
+		return fmt.Errorf("search already started")
+	}
+	if err := h.verify(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &asyncSearch{
+		ctx:      ctx,
+		cancel:   cancel,
+		results:  make(chan Match, statusInterval),
+		progress: make(chan Stats, 1),
+		done:     make(chan struct{}),
+	}
+	mutex.Lock()
+	h.async = s
+	h.search = s
+	mutex.Unlock()
+
+	go func() {
+		_, err := h.FindSequentialContext(ctx)
+
+		mutex.Lock()
+		h.async = nil
+		mutex.Unlock()
+		if ctx.Err() == nil {
+			s.err = err
+		}
+		cancel()
+		close(s.results)
+		close(s.progress)
+		close(s.done)
+	}()
+	return nil
+}
+
+// Results returns the matches of the search started with Start. It must
+// be read until closed, or the search blocks
+func (h *Hasher) Results() <-chan Match {
+	return h.lastSearch().results
+}
+
+// Progress returns the stats of the search started with Start, every
+// progress interval. Stats are dropped when not read in time
+func (h *Hasher) Progress() <-chan Stats {
+	return h.lastSearch().progress
+}
+
+// Wait waits for the search started with Start to end, and returns its
+// error. It is nil when all expected hashes were found, or Stop was called
+func (h *Hasher) Wait() error {
+
+	s := h.lastSearch()
+	<-s.done
+	return s.err
+}
+
+// Stop ends the search started with Start, and waits for it to stop
+func (h *Hasher) Stop() {
+
+	s := h.lastSearch()
+	s.cancel()
+	<-s.done
+}
+
+// lastSearch returns the search of the last Start, or one that has
+// already ended if none was started
+func (h *Hasher) lastSearch() *asyncSearch {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if h.search == nil {
+		s := &asyncSearch{
+			cancel:   func() {},
+			results:  make(chan Match),
+			progress: make(chan Stats),
+			done:     make(chan struct{}),
+		}
+		close(s.results)
+		close(s.progress)
+		close(s.done)
+		h.search = s
+	}
+	return h.search
+}
+
+// sendMatch sends a match to the Results of a running Start
+func (h *Hasher) sendMatch(m Match) {
+
+	mutex.Lock()
+	s := h.async
+	mutex.Unlock()
+	if s == nil {
+		return
+	}
+	select {
+	case s.results <- m:
+	case <-s.ctx.Done():
+	}
+}
+
+// sendProgress sends stats to the Progress of a running Start, unless the
+// previous stats were not read yet
+func (h *Hasher) sendProgress(stats Stats) {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if h.async == nil {
+		return
+	}
+	select {
+	case h.async.progress <- stats:
+	default:
+	}
+}
@@ -0,0 +1,76 @@
+package gohash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherStart(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ehj")
+	hasher.Length(3)
+	hasher.ExpectedHash(md5Hej)
+
+	assert.Equal(t, nil, hasher.Start())
+
+	matches := []Match{}
+	for m := range hasher.Results() {
+		matches = append(matches, m)
+	}
+	assert.Equal(t, []Match{{Key: "hej", Hash: md5Hej}}, matches)
+	assert.Equal(t, nil, hasher.Wait())
+}
+
+func TestHasherStartTargets(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ehjtx")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej, md5Tex, md5Zero}))
+
+	assert.Equal(t, nil, hasher.Start())
+
+	keys := []string{}
+	for m := range hasher.Results() {
+		keys = append(keys, m.Key)
+	}
+	assert.ElementsMatch(t, []string{"hej", "tex"}, keys)
+	assert.Equal(t, "found 2 of 3 hashes", hasher.Wait().Error())
+}
+
+func TestHasherStop(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	hasher.ExpectedHash(md5Zero)
+	hasher.ProgressInterval(time.Millisecond)
+
+	assert.Equal(t, nil, hasher.Start())
+	assert.Equal(t, "search already started", hasher.Start().Error())
+
+	stats := <-hasher.Progress()
+	assert.Equal(t, "md5", stats.Algo)
+
+	hasher.Stop()
+	_, ok := <-hasher.Results()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, nil, hasher.Wait())
+}
+
+func TestHasherStartInvalid(t *testing.T) {
+
+	hasher := NewHasher()
+	assert.Equal(t, "minLength unset", hasher.Start().Error())
+
+	// nothing was started
+	_, ok := <-hasher.Results()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, nil, hasher.Wait())
+}
@@ -0,0 +1,130 @@
+package gohash
+
+const (
+	// marks a two-byte character holding only the final 7 bits
+	base122Shortened = 0x07
+)
+
+var (
+	// 7-bit values that can't be emitted as single byte characters
+	base122Illegals = []byte{0, '\n', '\r', '"', '&', '\\'}
+)
+
+// encodeBase122 encodes src 7 bits at a time into UTF-8, as described at
+// http://blog.kevinalbs.com/base122
+func encodeBase122(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src)*8/7+2)
+	r := bitReader7{data: src}
+
+	for {
+		bits, ok := r.next()
+		if !ok {
+			break
+		}
+
+		illegal := indexOfByte(bits, base122Illegals)
+		if illegal == -1 {
+			res = append(res, bits)
+			continue
+		}
+
+		// two-byte character: 110sss1x 10xxxxxx
+		b1 := byte(0xc2)
+		b2 := byte(0x80)
+		nextBits, ok := r.next()
+		if ok {
+			b1 |= byte(illegal) << 2
+		} else {
+			b1 |= base122Shortened << 2
+			nextBits = bits
+		}
+		b1 |= nextBits >> 6
+		b2 |= nextBits & 0x3f
+		res = append(res, b1, b2)
+	}
+	return res, nil
+}
+
+func decodeBase122(src []byte) ([]byte, error) {
+
+	w := bitWriter7{res: make([]byte, 0, len(src)*7/8)}
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if c < 0x80 {
+			w.push(c)
+			continue
+		}
+
+		if c&0xe2 != 0xc2 || i+1 >= len(src) || src[i+1]&0xc0 != 0x80 {
+			return nil, newDecodeError("base122", src, i, "invalid character")
+		}
+		illegal := (c >> 2) & 0x07
+		if illegal != base122Shortened {
+			if int(illegal) >= len(base122Illegals) {
+				return nil, newDecodeError("base122", src, i, "invalid character")
+			}
+			w.push(base122Illegals[illegal])
+		}
+		w.push((c&0x01)<<6 | src[i+1]&0x3f)
+		i++
+	}
+	return w.res, nil
+}
+
+// bitReader7 reads 7-bit chunks, zero padding the last one
+type bitReader7 struct {
+	data []byte
+	pos  int
+	bit  uint
+}
+
+func (r *bitReader7) next() (byte, bool) {
+
+	if r.pos >= len(r.data) {
+		return 0, false
+	}
+
+	res := byte((uint(r.data[r.pos])<<r.bit)&0xff) >> 1
+	r.bit += 7
+	if r.bit < 8 {
+		return res, true
+	}
+
+	r.bit -= 8
+	r.pos++
+	if r.pos < len(r.data) {
+		res |= r.data[r.pos] >> (8 - r.bit)
+	}
+	return res, true
+}
+
+// bitWriter7 collects 7-bit chunks into bytes, dropping trailing padding
+type bitWriter7 struct {
+	res  []byte
+	cur  byte
+	bits uint
+}
+
+func (w *bitWriter7) push(b byte) {
+
+	b <<= 1
+	w.cur |= b >> w.bits
+	w.bits += 7
+	if w.bits >= 8 {
+		w.res = append(w.res, w.cur)
+		w.bits -= 8
+		w.cur = b << (7 - w.bits)
+	}
+}
+
+func indexOfByte(a byte, list []byte) int {
+
+	for i, b := range list {
+		if b == a {
+			return i
+		}
+	}
+	return -1
+}
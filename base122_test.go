@@ -0,0 +1,36 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase122Illegals(t *testing.T) {
+
+	// illegal 7-bit values are folded into two-byte characters
+	res, err := encodeBase122([]byte{0, 0, 0})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xc2, 0x80, 0xc2, 0x80}, res)
+
+	// an illegal in the last chunk uses the shortened marker
+	res, err = encodeBase122([]byte{'\\'})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{'.', 0xde, 0x80}, res)
+
+	back, err := decodeBase122(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{'\\'}, back)
+}
+
+func TestDecodeBase122Invalid(t *testing.T) {
+
+	for _, b := range [][]byte{
+		{0xc2},
+		{0xe0, 0x80},
+		{0xda, 0x80}, // illegal index 6
+	} {
+		_, err := decodeBase122(b)
+		assert.NotEqual(t, nil, err)
+	}
+}
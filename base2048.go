@@ -0,0 +1,163 @@
+package gohash
+
+import (
+	"unicode/utf8"
+)
+
+const (
+	// bits encoded by each code point of the 2048 repertoire, and by a
+	// final code point of the 8 repertoire
+	base2048Bits      = 11
+	base2048FinalBits = 3
+)
+
+var (
+	// the 2048 code points, as ranges, and the 8 code points for the final
+	// 3 bits of the reference encoder, https://github.com/qntm/base2048
+	base2048Repertoire = base2048MakeRepertoire([][2]rune{
+		{0x0038, 0x0039}, {0x0041, 0x005a}, {0x0061, 0x007a}, {0x00c6, 0x00c6}, {0x00d0, 0x00d0},
+		{0x00d8, 0x00d8}, {0x00de, 0x00df}, {0x00e6, 0x00e6}, {0x00f0, 0x00f0}, {0x00f8, 0x00f8},
+		{0x00fe, 0x00fe}, {0x0110, 0x0111}, {0x0126, 0x0127}, {0x0131, 0x0131}, {0x0138, 0x0138},
+		{0x0141, 0x0142}, {0x014a, 0x014b}, {0x0152, 0x0153}, {0x0166, 0x0167}, {0x0180, 0x019f},
+		{0x01a2, 0x01ae}, {0x01b1, 0x01c3}, {0x01dd, 0x01dd}, {0x01e4, 0x01e5}, {0x01f6, 0x01f7},
+		{0x021c, 0x021d}, {0x0220, 0x0225}, {0x0234, 0x02af}, {0x0370, 0x0373}, {0x0376, 0x0377},
+		{0x037b, 0x037d}, {0x037f, 0x037f}, {0x0391, 0x03a1}, {0x03a3, 0x03a9}, {0x03b1, 0x03c9},
+		{0x03cf, 0x03cf}, {0x03d7, 0x03ef}, {0x03f3, 0x03f3}, {0x03f7, 0x03f8}, {0x03fa, 0x03ff},
+		{0x0402, 0x0402}, {0x0404, 0x0406}, {0x0408, 0x040b}, {0x040f, 0x0418}, {0x041a, 0x0438},
+		{0x043a, 0x044f}, {0x0452, 0x0452}, {0x0454, 0x0456}, {0x0458, 0x045b}, {0x045f, 0x0475},
+		{0x0478, 0x0481}, {0x048a, 0x04c0}, {0x04c3, 0x04cf}, {0x04d4, 0x04d5}, {0x04d8, 0x04d9},
+		{0x04e0, 0x04e1}, {0x04e8, 0x04e9}, {0x04f6, 0x04f7}, {0x04fa, 0x052f}, {0x0531, 0x0556},
+		{0x0561, 0x0586}, {0x05d0, 0x05ea}, {0x05f0, 0x05f2}, {0x0620, 0x0621}, {0x0627, 0x063f},
+		{0x0641, 0x064a}, {0x0660, 0x0669}, {0x066e, 0x066f}, {0x0671, 0x0674}, {0x0679, 0x06bf},
+		{0x06c1, 0x06c1}, {0x06c3, 0x06d2}, {0x06d5, 0x06d5}, {0x06ee, 0x06fc}, {0x06ff, 0x06ff},
+		{0x0710, 0x0710}, {0x0712, 0x072f}, {0x074d, 0x07a5}, {0x07b1, 0x07b1}, {0x07c0, 0x07ea},
+		{0x0800, 0x0815}, {0x0840, 0x0858}, {0x0860, 0x086a}, {0x08a0, 0x08b4}, {0x08b6, 0x08bd},
+		{0x0904, 0x0928}, {0x092a, 0x0930}, {0x0932, 0x0933}, {0x0935, 0x0939}, {0x093d, 0x093d},
+		{0x0950, 0x0950}, {0x0960, 0x0961}, {0x0966, 0x096f}, {0x0972, 0x0980}, {0x0985, 0x098c},
+		{0x098f, 0x0990}, {0x0993, 0x09a8}, {0x09aa, 0x09b0}, {0x09b2, 0x09b2}, {0x09b6, 0x09b9},
+		{0x09bd, 0x09bd}, {0x09ce, 0x09ce}, {0x09e0, 0x09e1}, {0x09e6, 0x09f1}, {0x09f4, 0x09f9},
+		{0x09fc, 0x09fc}, {0x0a05, 0x0a0a}, {0x0a0f, 0x0a10}, {0x0a13, 0x0a28}, {0x0a2a, 0x0a30},
+		{0x0a32, 0x0a32}, {0x0a35, 0x0a35}, {0x0a38, 0x0a39}, {0x0a5c, 0x0a5c}, {0x0a66, 0x0a6f},
+		{0x0a72, 0x0a74}, {0x0a85, 0x0a8d}, {0x0a8f, 0x0a91}, {0x0a93, 0x0aa8}, {0x0aaa, 0x0ab0},
+		{0x0ab2, 0x0ab3}, {0x0ab5, 0x0ab9}, {0x0abd, 0x0abd}, {0x0ad0, 0x0ad0}, {0x0ae0, 0x0ae1},
+		{0x0ae6, 0x0aef}, {0x0af9, 0x0af9}, {0x0b05, 0x0b0c}, {0x0b0f, 0x0b10}, {0x0b13, 0x0b28},
+		{0x0b2a, 0x0b30}, {0x0b32, 0x0b33}, {0x0b35, 0x0b39}, {0x0b3d, 0x0b3d}, {0x0b5f, 0x0b61},
+		{0x0b66, 0x0b6f}, {0x0b71, 0x0b77}, {0x0b83, 0x0b83}, {0x0b85, 0x0b8a}, {0x0b8e, 0x0b90},
+		{0x0b92, 0x0b93}, {0x0b95, 0x0b95}, {0x0b99, 0x0b9a}, {0x0b9c, 0x0b9c}, {0x0b9e, 0x0b9f},
+		{0x0ba3, 0x0ba4}, {0x0ba8, 0x0baa}, {0x0bae, 0x0bb9}, {0x0bd0, 0x0bd0}, {0x0be6, 0x0bf2},
+		{0x0c05, 0x0c0c}, {0x0c0e, 0x0c10}, {0x0c12, 0x0c28}, {0x0c2a, 0x0c39}, {0x0c3d, 0x0c3d},
+		{0x0c58, 0x0c5a}, {0x0c60, 0x0c61}, {0x0c66, 0x0c6f}, {0x0c78, 0x0c7e}, {0x0c80, 0x0c80},
+		{0x0c85, 0x0c8c}, {0x0c8e, 0x0c90}, {0x0c92, 0x0ca8}, {0x0caa, 0x0cb3}, {0x0cb5, 0x0cb9},
+		{0x0cbd, 0x0cbd}, {0x0cde, 0x0cde}, {0x0ce0, 0x0ce1}, {0x0ce6, 0x0cef}, {0x0cf1, 0x0cf2},
+		{0x0d05, 0x0d0c}, {0x0d0e, 0x0d10}, {0x0d12, 0x0d3a}, {0x0d3d, 0x0d3d}, {0x0d4e, 0x0d4e},
+		{0x0d54, 0x0d56}, {0x0d58, 0x0d61}, {0x0d66, 0x0d78}, {0x0d7a, 0x0d7f}, {0x0d85, 0x0d96},
+		{0x0d9a, 0x0db1}, {0x0db3, 0x0dbb}, {0x0dbd, 0x0dbd}, {0x0dc0, 0x0dc6}, {0x0de6, 0x0def},
+		{0x0e01, 0x0e30}, {0x0e32, 0x0e32}, {0x0e40, 0x0e45}, {0x0e50, 0x0e59}, {0x0e81, 0x0e82},
+		{0x0e84, 0x0e84}, {0x0e87, 0x0e88}, {0x0e8a, 0x0e8a}, {0x0e8d, 0x0e8d}, {0x0e94, 0x0e97},
+		{0x0e99, 0x0e9f}, {0x0ea1, 0x0ea3}, {0x0ea5, 0x0ea5}, {0x0ea7, 0x0ea7}, {0x0eaa, 0x0eab},
+		{0x0ead, 0x0eb0}, {0x0eb2, 0x0eb2}, {0x0ebd, 0x0ebd}, {0x0ec0, 0x0ec4}, {0x0ed0, 0x0ed9},
+		{0x0ede, 0x0edf}, {0x0f00, 0x0f00}, {0x0f20, 0x0f33}, {0x0f40, 0x0f42}, {0x0f44, 0x0f47},
+		{0x0f49, 0x0f4c}, {0x0f4e, 0x0f51}, {0x0f53, 0x0f56}, {0x0f58, 0x0f5b}, {0x0f5d, 0x0f68},
+		{0x0f6a, 0x0f6c}, {0x0f88, 0x0f8c}, {0x1000, 0x1025}, {0x1027, 0x102a}, {0x103f, 0x1049},
+		{0x1050, 0x1055},
+	})
+	base2048FinalRepertoire = []rune("01234567")
+
+	base2048Index      = map[rune]int{}
+	base2048FinalIndex = map[rune]int{}
+)
+
+func init() {
+	for i, r := range base2048Repertoire {
+		base2048Index[r] = i
+	}
+	for i, r := range base2048FinalRepertoire {
+		base2048FinalIndex[r] = i
+	}
+}
+
+func base2048MakeRepertoire(ranges [][2]rune) []rune {
+
+	res := []rune{}
+	for _, r := range ranges {
+		for c := r[0]; c <= r[1]; c++ {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// encodeBase2048 encodes 11 bits per code point. The last bits are padded
+// with 1 bits, to 3 bits from the 8 repertoire when there are 3 or less
+func encodeBase2048(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, (len(src)*8+base2048Bits-1)/base2048Bits*3)
+	buf := make([]byte, utf8.UTFMax)
+
+	z, bits := 0, 0
+	for _, b := range src {
+		for i := 7; i >= 0; i-- {
+			z = z<<1 | int(b>>uint(i))&1
+			bits++
+			if bits == base2048Bits {
+				n := utf8.EncodeRune(buf, base2048Repertoire[z])
+				res = append(res, buf[0:n]...)
+				z, bits = 0, 0
+			}
+		}
+	}
+	if bits == 0 {
+		return res, nil
+	}
+
+	repertoire, size := base2048Repertoire, base2048Bits
+	if bits <= base2048FinalBits {
+		repertoire, size = base2048FinalRepertoire, base2048FinalBits
+	}
+	for ; bits < size; bits++ {
+		z = z<<1 | 1
+	}
+	n := utf8.EncodeRune(buf, repertoire[z])
+	return append(res, buf[0:n]...), nil
+}
+
+func decodeBase2048(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src))
+
+	b, bits := 0, 0
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRune(src[pos:])
+		if r == utf8.RuneError {
+			return nil, newDecodeError("base2048", src, pos, "invalid utf8")
+		}
+
+		z, ok := base2048Index[r]
+		n := base2048Bits
+		if !ok {
+			if z, ok = base2048FinalIndex[r]; !ok {
+				return nil, newDecodeError("base2048", src, pos, "invalid code point")
+			}
+			if pos+size != len(src) {
+				return nil, newDecodeError("base2048", src, pos, "final code point before the end")
+			}
+			n = base2048FinalBits
+		}
+
+		for i := n - 1; i >= 0; i-- {
+			b = b<<1 | z>>uint(i)&1
+			bits++
+			if bits == 8 {
+				res = append(res, byte(b))
+				b, bits = 0, 0
+			}
+		}
+		pos += size
+	}
+
+	// the bits after the last byte are padding, all 1
+	if b != 1<<uint(bits)-1 {
+		return nil, newDecodeError("base2048", src, len(src), "padding mismatch")
+	}
+	return res, nil
+}
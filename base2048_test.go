@@ -0,0 +1,58 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase2048(t *testing.T) {
+
+	// from the readme of the reference encoder
+	res, err := encodeBase2048([]byte{1, 2, 4, 8, 16, 32, 64, 128})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GƸOʜeҩ", string(res))
+
+	dec, err := decodeBase2048([]byte("GƸOʜeҩ"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{1, 2, 4, 8, 16, 32, 64, 128}, dec)
+
+	assert.Equal(t, 2048, len(base2048Repertoire))
+	assert.Equal(t, 2048, len(base2048Index))
+	assert.Equal(t, '8', base2048Repertoire[0])
+	assert.Equal(t, 'ၕ', base2048Repertoire[2047])
+}
+
+func TestBase2048FinalBits(t *testing.T) {
+
+	// 3 bytes leave 2 bits, padded to 3 from the 8 repertoire
+	res, err := encodeBase2048([]byte{0xff, 0xff, 0xff})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ၕၕ7", string(res))
+
+	for n := 0; n < 24; n++ {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(i * 37)
+		}
+		enc, err := encodeBase2048(src)
+		assert.Equal(t, nil, err)
+		dec, err := decodeBase2048(enc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, dec, n)
+	}
+}
+
+func TestDecodeBase2048Invalid(t *testing.T) {
+
+	for _, s := range []string{
+		"7G",     // final code point must be last
+		"G3",     // padding must be 1 bits
+		"hello!", // ! is not in the repertoire
+		"\xff",
+	} {
+		_, err := decodeBase2048([]byte(s))
+		_, ok := err.(*DecodeError)
+		assert.Equal(t, true, ok, s)
+	}
+}
@@ -0,0 +1,18 @@
+package gohash
+
+import "bytes"
+
+var (
+	base36Alphabet = []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+)
+
+// encodeBase36 encodes src as one big number, keeping leading zero bytes
+// as '0' digits
+func encodeBase36(src []byte) ([]byte, error) {
+	return encodeBaseN(base36Alphabet, src), nil
+}
+
+// decodeBase36 decodes base36 in either case
+func decodeBase36(src []byte) ([]byte, error) {
+	return decodeBaseN("base36", base36Alphabet, bytes.ToUpper(src))
+}
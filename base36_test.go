@@ -0,0 +1,54 @@
+package gohash
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase36LeadingZeros(t *testing.T) {
+
+	for clear, coded := range map[string]string{
+		"":             "",
+		"\x00":         "0",
+		"\x00\x00":     "00",
+		"\x00\x01":     "01",
+		"\x00\x00\xff": "0073",
+		"\x01\x00":     "74",
+	} {
+		res, err := encodeBase36([]byte(clear))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, coded, string(res))
+
+		res, err = decodeBase36([]byte(coded))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte(clear), res)
+	}
+}
+
+func TestBase36RoundTrip(t *testing.T) {
+
+	for n := 0; n < 200; n++ {
+		src := make([]byte, n)
+		rand.Read(src)
+		for i := 0; i < n%5 && i < n; i++ {
+			src[i] = 0
+		}
+		enc, err := encodeBase36(src)
+		assert.Equal(t, nil, err)
+		dec, err := decodeBase36(enc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, dec)
+	}
+}
+
+func TestDecodeBase36(t *testing.T) {
+
+	res, err := decodeBase36([]byte("2n9c"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("\x01\xe2\x40"), res)
+
+	_, err = decodeBase36([]byte("2N9C!"))
+	assert.Equal(t, &DecodeError{Encoding: "base36", Offset: 4, Char: '!', Reason: "invalid character"}, err)
+}
@@ -0,0 +1,60 @@
+package gohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+)
+
+// encodeBase45 encodes src as described in RFC 9285
+func encodeBase45(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, (len(src)+1)/2*3)
+
+	for i := 0; i+1 < len(src); i += 2 {
+		n := int(src[i])*256 + int(src[i+1])
+		res = append(res, base45Alphabet[n%45], base45Alphabet[n/45%45], base45Alphabet[n/(45*45)])
+	}
+	if len(src)%2 == 1 {
+		n := int(src[len(src)-1])
+		res = append(res, base45Alphabet[n%45], base45Alphabet[n/45])
+	}
+	return res, nil
+}
+
+func decodeBase45(src []byte) ([]byte, error) {
+
+	if len(src)%3 == 1 {
+		return nil, fmt.Errorf("base45: invalid length %d", len(src))
+	}
+
+	values := make([]int, len(src))
+	for i, c := range src {
+		values[i] = strings.IndexByte(base45Alphabet, c)
+		if values[i] == -1 {
+			return nil, newDecodeError("base45", src, i, "invalid character")
+		}
+	}
+
+	res := make([]byte, 0, len(src)/3*2+1)
+
+	for i := 0; i+2 < len(values); i += 3 {
+		n := values[i] + values[i+1]*45 + values[i+2]*45*45
+		if n > 0xffff {
+			return nil, newDecodeError("base45", src, i, "invalid triplet")
+		}
+		res = append(res, byte(n>>8), byte(n))
+	}
+	if len(values)%3 == 2 {
+		i := len(values) - 2
+		n := values[i] + values[i+1]*45
+		if n > 0xff {
+			return nil, newDecodeError("base45", src, i, "invalid pair")
+		}
+		res = append(res, byte(n))
+	}
+	return res, nil
+}
@@ -0,0 +1,38 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase45RFCExamples(t *testing.T) {
+
+	for clear, coded := range map[string]string{
+		"AB":      "BB8",
+		"Hello!!": "%69 VD92EX0",
+		"base-45": "UJCLQE7W581",
+		"ietf!":   "QED8WEX0",
+	} {
+		res, err := encodeBase45([]byte(clear))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, coded, string(res))
+
+		res, err = decodeBase45([]byte(coded))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, clear, string(res))
+	}
+}
+
+func TestDecodeBase45Invalid(t *testing.T) {
+
+	for _, s := range []string{
+		"GGW",  // 65536, out of range
+		"GGWA", // invalid length
+		"abc",  // lower case
+		":~",
+	} {
+		_, err := decodeBase45([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
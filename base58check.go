@@ -0,0 +1,52 @@
+package gohash
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// EncodeBase58Check encodes payload prefixed by version, with a 4-byte
+// double sha256 checksum appended
+func EncodeBase58Check(version byte, payload []byte) string {
+
+	buf := make([]byte, 0, 1+len(payload)+4)
+	buf = append(buf, version)
+	buf = append(buf, payload...)
+	buf = append(buf, base58Checksum(buf)...)
+	res, _ := encodeBase58(buf)
+	return string(res)
+}
+
+// DecodeBase58Check decodes a base58check string, returning the version
+// byte and payload. The checksum must match
+func DecodeBase58Check(s string) (byte, []byte, error) {
+
+	b, err := decodeBase58([]byte(s))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) < 5 {
+		return 0, nil, fmt.Errorf("base58check: input too short")
+	}
+	data, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(base58Checksum(data), sum) {
+		return 0, nil, fmt.Errorf("base58check: checksum mismatch")
+	}
+	return data[0], data[1:], nil
+}
+
+// base58Checksum returns the first 4 bytes of sha256(sha256(b))
+func base58Checksum(b []byte) []byte {
+
+	sha := hashers["sha256"]
+	return (*sha(sha(&b)))[0:4]
+}
+
+func encodeBase58Check(c *Coder, src []byte) ([]byte, error) {
+	return []byte(EncodeBase58Check(c.version, src)), nil
+}
+
+func decodeBase58Check(c *Coder, src []byte) ([]byte, error) {
+	_, payload, err := DecodeBase58Check(string(src))
+	return payload, err
+}
@@ -0,0 +1,43 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase58Check(t *testing.T) {
+
+	assert.Equal(t, "1111111111111111111114oLvT2", EncodeBase58Check(0, make([]byte, 20)))
+
+	version, payload, err := DecodeBase58Check("1111111111111111111114oLvT2")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, byte(0), version)
+	assert.Equal(t, make([]byte, 20), payload)
+
+	version, payload, err = DecodeBase58Check(EncodeBase58Check(5, []byte("hello")))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, byte(5), version)
+	assert.Equal(t, []byte("hello"), payload)
+}
+
+func TestBase58CheckInvalid(t *testing.T) {
+
+	_, _, err := DecodeBase58Check("1111111111111111111114oLvT3")
+	assert.NotEqual(t, nil, err)
+
+	_, _, err = DecodeBase58Check("1Wh4")
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoder("base58check").Decode([]byte("1Wh4bi"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCoderBase58CheckVersion(t *testing.T) {
+
+	coder := NewCoder("base58check")
+	coder.Version(5)
+	res, err := coder.Encode(make([]byte, 20))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, byte('3'), res[0])
+}
@@ -0,0 +1,91 @@
+package gohash
+
+import (
+	"unicode/utf8"
+)
+
+const (
+	// block used for a trailing odd byte
+	base65536SingleBlock = 0x1500
+)
+
+var (
+	// the 256 blocks of 256 code points used for byte pairs, indexed by the
+	// second byte of the pair. from https://github.com/qntm/base65536
+	base65536Blocks = base65536MakeBlocks([][2]int{
+		{0x3400, 0x4c00},
+		{0x4e00, 0x9e00},
+		{0xa100, 0xa300},
+		{0xa500, 0xa500},
+		{0x10600, 0x10600},
+		{0x12000, 0x12200},
+		{0x13000, 0x13300},
+		{0x14400, 0x14500},
+		{0x16800, 0x16900},
+		{0x20000, 0x28500},
+	})
+
+	base65536BlockIndex = map[rune]int{}
+)
+
+func init() {
+	for i, start := range base65536Blocks {
+		base65536BlockIndex[start] = i
+	}
+}
+
+func base65536MakeBlocks(ranges [][2]int) []rune {
+
+	res := []rune{}
+	for _, r := range ranges {
+		for start := r[0]; start <= r[1]; start += 0x100 {
+			res = append(res, rune(start))
+		}
+	}
+	return res
+}
+
+// encodeBase65536 encodes two bytes per code point
+func encodeBase65536(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src)*2)
+	buf := make([]byte, utf8.UTFMax)
+
+	for i := 0; i < len(src); i += 2 {
+		block := rune(base65536SingleBlock)
+		if i+1 < len(src) {
+			block = base65536Blocks[src[i+1]]
+		}
+		n := utf8.EncodeRune(buf, block+rune(src[i]))
+		res = append(res, buf[0:n]...)
+	}
+	return res, nil
+}
+
+func decodeBase65536(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src)/2)
+	done := false
+
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRune(src[pos:])
+		if r == utf8.RuneError {
+			return nil, newDecodeError("base65536", src, pos, "invalid utf8")
+		}
+		if done {
+			return nil, newDecodeError("base65536", src, pos, "data after final code point")
+		}
+
+		block := r &^ 0xff
+		if block == base65536SingleBlock {
+			res = append(res, byte(r))
+			done = true
+		} else if second, ok := base65536BlockIndex[block]; ok {
+			res = append(res, byte(r), byte(second))
+		} else {
+			return nil, newDecodeError("base65536", src, pos, "invalid code point")
+		}
+		pos += size
+	}
+	return res, nil
+}
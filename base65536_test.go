@@ -0,0 +1,32 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase65536(t *testing.T) {
+
+	res, err := encodeBase65536([]byte("hello world"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "驨ꍬ啯𒁷ꍲᕤ", string(res))
+
+	res, err = decodeBase65536([]byte("驨ꍬ啯𒁷ꍲᕤ"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hello world", string(res))
+
+	assert.Equal(t, 256, len(base65536Blocks))
+}
+
+func TestDecodeBase65536Invalid(t *testing.T) {
+
+	for _, s := range []string{
+		"ᕤ驨", // single byte block must be last
+		"hello",
+		"\xff",
+	} {
+		_, err := decodeBase65536([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
@@ -0,0 +1,146 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"hash"
+)
+
+// batchHasher hashes the candidates of one search goroutine. Where the
+// algo has a hash.Hash, one state is reused for the whole batch instead of
+// a new one per candidate, and with a prefix of at least a block, the state
+// after the prefix is restored instead of hashing the prefix again
+type batchHasher struct {
+	h      *Hasher
+	state  hash.Hash
+	digest []byte
+
+	// state after the prefix, or nil
+	mid     []byte
+	restore encoding.BinaryUnmarshaler
+
+	// first 8 bytes of the expected hash, compared before the rest
+	first      uint64
+	compareAll bool
+
+	// number of the worker using the batch, counting from 0
+	worker int
+}
+
+// verifyBatch chooses how candidates are hashed, for the plain algo sum
+func (h *Hasher) verifyBatch(plain bool) {
+
+	h.stateHash = nil
+	h.fastSum = nil
+	h.midstate = false
+	h.implementation = implementations[h.algo]
+	if h.implementation == "" {
+		h.implementation = implGeneric
+	}
+	if !plain {
+		h.implementation += ", one shot"
+		return
+	}
+
+	fastSum, fast := fastSums[h.algo]
+	if newHash, ok := hmacHashes[h.algo]; ok {
+		state := newHash()
+		_, marshal := state.(encoding.BinaryMarshaler)
+		if marshal && len(h.prefix) >= state.BlockSize() {
+			h.stateHash = newHash
+			h.midstate = true
+			h.implementation += ", prefix midstate"
+			return
+		}
+		if !fast {
+			h.stateHash = newHash
+			h.implementation += ", reused state"
+			return
+		}
+	}
+	if fast {
+		// the stack allocated sum is faster than a reused state
+		h.fastSum = fastSum
+	}
+	h.implementation += ", one shot"
+}
+
+// newBatch returns a batchHasher for the search goroutine of worker
+func (h *Hasher) newBatch(worker int) *batchHasher {
+
+	b := &batchHasher{h: h, worker: worker, digest: make([]byte, 0, algos[h.algo]/8)}
+	if len(h.expected) >= 8 {
+		b.first = binary.LittleEndian.Uint64(h.expected)
+	} else {
+		b.compareAll = true
+	}
+	if h.stateHash == nil {
+		return b
+	}
+	b.state = h.stateHash()
+	if h.midstate {
+		b.state.Write([]byte(h.prefix))
+		b.mid, _ = b.state.(encoding.BinaryMarshaler).MarshalBinary()
+		b.restore = b.state.(encoding.BinaryUnmarshaler)
+	}
+	return b
+}
+
+// sum returns the digest of buf, valid until the next call
+func (b *batchHasher) sum(buf []byte) []byte {
+
+	switch {
+	case b.mid != nil:
+		b.restore.UnmarshalBinary(b.mid)
+		b.state.Write(buf[len(b.h.prefix):])
+	case b.state != nil:
+		b.state.Reset()
+		b.state.Write(buf)
+	case b.h.fastSum != nil:
+		b.digest = b.h.fastSum(b.digest[:0], buf)
+		return b.digest
+	default:
+		// a copy, so only this path moves the slice header to the heap
+		p := buf
+		return *b.h.sum(&p)
+	}
+	b.digest = b.state.Sum(b.digest[:0])
+	return b.digest
+}
+
+// equals returns true when buf completes the search
+func (b *batchHasher) equals(buf []byte) bool {
+
+	if b.equalsAlgo(buf) {
+		return true
+	}
+	return b.h.extra != nil && b.equalsExtra(buf)
+}
+
+// equalsAlgo returns true when buf completes the search with the hash of
+// the algo of the Hasher
+func (b *batchHasher) equalsAlgo(buf []byte) bool {
+
+	h := b.h
+	digest := b.sum(buf)
+	if h.pattern != nil {
+		if !h.pattern(digest) {
+			return false
+		}
+		return h.matched(buf, digest, b.worker)
+	}
+	if h.targets != nil {
+		if !h.targets[string(digest)] {
+			return false
+		}
+		return h.matched(buf, digest, b.worker)
+	}
+	if !b.compareAll && (len(digest) < 8 || binary.LittleEndian.Uint64(digest) != b.first) {
+		return false
+	}
+	if !bytes.Equal(digest, h.expected) {
+		return false
+	}
+	return h.matched(buf, h.expected, b.worker)
+}
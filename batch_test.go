@@ -0,0 +1,123 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSum(t *testing.T) {
+
+	for _, prefix := range []string{"", "pre-", strings.Repeat("p", 200)} {
+		for _, algo := range AvailableHMACs() {
+			hasher := NewHasher()
+			hasher.Algo(algo)
+			hasher.Prefix(prefix)
+			hasher.DigestPrefix("00")
+			assert.Equal(t, nil, hasher.verifyTarget())
+			assert.Equal(t, true, hasher.stateHash != nil || hasher.fastSum != nil, algo)
+
+			batch := hasher.newBatch(0)
+			for _, key := range []string{"a", "hej", strings.Repeat("k", 100)} {
+				buf := []byte(prefix + key)
+				expected := *hasher.sum(&buf)
+				assert.Equal(t, expected, batch.sum(buf), algo+" "+key)
+			}
+		}
+	}
+}
+
+func TestBatchImplementation(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	assert.Equal(t, nil, hasher.verifyTarget())
+	assert.Equal(t, "generic, one shot", hasher.implementation)
+
+	hasher.Prefix(strings.Repeat("p", 64))
+	assert.Equal(t, nil, hasher.verifyTarget())
+	assert.Equal(t, "generic, prefix midstate", hasher.implementation)
+
+	hasher.Prefix("")
+	hasher.Algo("sha224")
+	hasher.ExpectedHash("00000000000000000000000000000000000000000000000000000000")
+	assert.Equal(t, nil, hasher.verifyTarget())
+	assert.Equal(t, "generic, reused state", hasher.implementation)
+
+	hasher.Algo("crc32")
+	hasher.ExpectedHash("00000000")
+	assert.Equal(t, nil, hasher.verifyTarget())
+	assert.Equal(t, "generic, one shot", hasher.implementation)
+}
+
+func TestHashPrefixMidstate(t *testing.T) {
+
+	prefix := strings.Repeat("x", 130)
+	key := []byte(prefix + "hej")
+	calc := NewCalculator(key)
+
+	for _, algo := range []string{"md5", "sha1", "sha224", "sha256", "sha512"} {
+		hasher := NewHasher()
+		hasher.Algo(algo)
+		hasher.AllowedKeys("ehj")
+		hasher.Length(3)
+		hasher.Prefix(prefix)
+		hasher.ExpectedHash(hex.EncodeToString(*calc.Sum(algo)))
+
+		res, err := hasher.FindSequential()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, string(key), res)
+		assert.Equal(t, true, strings.HasSuffix(hasher.stats().Implementation, "prefix midstate"))
+	}
+}
+
+func TestBatchEqualsAllocs(t *testing.T) {
+
+	for _, setup := range []func(*Hasher){
+		func(h *Hasher) { h.Algo("md5"); h.ExpectedHash(md5Hej) },
+		func(h *Hasher) { h.Algo("sha256"); h.DigestPrefix("ffff") },
+		func(h *Hasher) {
+			h.Algo("sha224")
+			h.ExpectedHash("00000000000000000000000000000000000000000000000000000000")
+		},
+		func(h *Hasher) {
+			h.Algo("sha1")
+			h.Prefix(strings.Repeat("p", 64))
+			h.ExpectedHash(md5Hej + "00000000")
+		},
+		func(h *Hasher) { h.Algo("md5"); h.ExpectedHashes([]string{md5Hej, md5Tex}) },
+	} {
+		hasher := NewHasher()
+		setup(hasher)
+		assert.Equal(t, nil, hasher.verifyTarget())
+
+		batch := hasher.newBatch(0)
+		buf := []byte(hasher.prefix + "candidate")
+		allocs := testing.AllocsPerRun(100, func() {
+			batch.equals(buf)
+		})
+		assert.Equal(t, 0.0, allocs, hasher.algo)
+	}
+}
+
+func TestBatchEqualsFirstBytes(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	assert.Equal(t, nil, hasher.verifyTarget())
+
+	batch := hasher.newBatch(0)
+	assert.Equal(t, true, batch.equals([]byte("hej")))
+	assert.Equal(t, false, batch.equals([]byte("hek")))
+
+	// only the last byte differs
+	expected := append([]byte{}, hasher.expected...)
+	expected[len(expected)-1] ^= 1
+	hasher.expected = expected
+	batch = hasher.newBatch(0)
+	assert.Equal(t, false, batch.equals([]byte("hej")))
+}
@@ -0,0 +1,246 @@
+package gohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+
+	// hrp used by the Coder unless set with Coder.HRP
+	defaultBech32HRP = "data"
+)
+
+var (
+	bech32Generator = []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+)
+
+// EncodeBech32 encodes data as bech32 (BIP 173), or bech32m (BIP 350)
+func EncodeBech32(hrp string, data []byte, bech32m bool) (string, error) {
+
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encodeBech32Values(hrp, values, bech32m)
+}
+
+// DecodeBech32 decodes a bech32 or bech32m string, returning the hrp and
+// the 8-bit payload
+func DecodeBech32(s string) (string, []byte, bool, error) {
+
+	hrp, values, bech32m, err := decodeBech32Values(s)
+	if err != nil {
+		return "", nil, false, err
+	}
+	data, err := convertBits(values, 5, 8, false)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return hrp, data, bech32m, nil
+}
+
+// DecodeSegwitAddress decodes a segwit address, such as "bc1q...",
+// returning the hrp, witness version and witness program
+func DecodeSegwitAddress(addr string) (string, int, []byte, error) {
+
+	hrp, values, bech32m, err := decodeBech32Values(addr)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(values) == 0 || values[0] > 16 {
+		return "", 0, nil, fmt.Errorf("segwit: invalid witness version")
+	}
+
+	version := int(values[0])
+	if (version == 0) == bech32m {
+		return "", 0, nil, fmt.Errorf("segwit: wrong checksum variant for version %d", version)
+	}
+
+	program, err := convertBits(values[1:], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", 0, nil, fmt.Errorf("segwit: invalid program length %d", len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", 0, nil, fmt.Errorf("segwit: invalid v0 program length %d", len(program))
+	}
+	return hrp, version, program, nil
+}
+
+func encodeBech32(c *Coder, src []byte) ([]byte, error) {
+	s, err := EncodeBech32(c.bech32HRP(), src, false)
+	return []byte(s), err
+}
+
+func decodeBech32(c *Coder, src []byte) ([]byte, error) {
+	return decodeBech32Variant(src, false)
+}
+
+func encodeBech32m(c *Coder, src []byte) ([]byte, error) {
+	s, err := EncodeBech32(c.bech32HRP(), src, true)
+	return []byte(s), err
+}
+
+func decodeBech32m(c *Coder, src []byte) ([]byte, error) {
+	return decodeBech32Variant(src, true)
+}
+
+func decodeBech32Variant(src []byte, bech32m bool) ([]byte, error) {
+
+	_, data, isBech32m, err := DecodeBech32(string(src))
+	if err != nil {
+		return nil, err
+	}
+	if isBech32m != bech32m {
+		return nil, fmt.Errorf("bech32: invalid checksum")
+	}
+	return data, nil
+}
+
+func encodeBech32Values(hrp string, values []byte, bech32m bool) (string, error) {
+
+	if len(hrp) == 0 {
+		return "", fmt.Errorf("bech32: empty hrp")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("bech32: invalid hrp character %q", hrp[i])
+		}
+	}
+	hrp = strings.ToLower(hrp)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for _, v := range bech32Checksum(hrp, values, bech32m) {
+		sb.WriteByte(bech32Charset[v])
+	}
+	return sb.String(), nil
+}
+
+func decodeBech32Values(s string) (string, []byte, bool, error) {
+
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, false, fmt.Errorf("bech32: mixed case")
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, false, fmt.Errorf("bech32: invalid separator position")
+	}
+
+	hrp := s[0:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, false, fmt.Errorf("bech32: invalid hrp character %q", hrp[i])
+		}
+	}
+
+	values := make([]byte, len(s)-pos-1)
+	for i := range values {
+		idx := strings.IndexByte(bech32Charset, s[pos+1+i])
+		if idx == -1 {
+			return "", nil, false, newDecodeError("bech32", []byte(s), pos+1+i, "invalid character")
+		}
+		values[i] = byte(idx)
+	}
+
+	var bech32m bool
+	switch bech32Polymod(append(bech32HRPExpand(hrp), values...)) {
+	case bech32Const:
+		bech32m = false
+	case bech32mConst:
+		bech32m = true
+	default:
+		return "", nil, false, fmt.Errorf("bech32: invalid checksum")
+	}
+
+	return hrp, values[0 : len(values)-6], bech32m, nil
+}
+
+func bech32Checksum(hrp string, values []byte, bech32m bool) []byte {
+
+	c := uint32(bech32Const)
+	if bech32m {
+		c = bech32mConst
+	}
+
+	v := append(bech32HRPExpand(hrp), values...)
+	v = append(v, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(v) ^ c
+
+	res := make([]byte, 6)
+	for i := range res {
+		res[i] = byte(mod>>uint(5*(5-i))) & 31
+	}
+	return res
+}
+
+func bech32Polymod(values []byte) uint32 {
+
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+
+	res := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]&31)
+	}
+	return res
+}
+
+// convertBits regroups data from fromBits to toBits sized values
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+
+	acc := uint(0)
+	bits := uint(0)
+	max := uint(1)<<toBits - 1
+	res := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, v := range data {
+		if uint(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("bech32: invalid data value %d", v)
+		}
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			res = append(res, byte(acc>>bits&max))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(toBits-bits)&max))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&max != 0 {
+		return nil, fmt.Errorf("bech32: invalid padding")
+	}
+	return res, nil
+}
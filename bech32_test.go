@@ -0,0 +1,78 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBech32Valid(t *testing.T) {
+
+	for s, bech32m := range map[string]bool{
+		"A12UEL5L": false,
+		"a12uel5l": false,
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw":                false,
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w": false,
+		"A1LQFN3A": true,
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx": true,
+	} {
+		_, _, isBech32m, err := DecodeBech32(s)
+		assert.Equal(t, nil, err, s)
+		assert.Equal(t, bech32m, isBech32m, s)
+	}
+}
+
+func TestDecodeBech32Invalid(t *testing.T) {
+
+	for _, s := range []string{
+		"pzry9x0s0muk",  // no separator
+		"1pzry9x0s0muk", // empty hrp
+		"x1b4n0q5v",     // invalid data character
+		"li1dgmt3",      // too short checksum
+		"A1G7SGD8",      // checksum calculated with uppercase hrp
+		"A12UEl5L",      // mixed case
+		"a12uel5m",      // bad checksum
+		"\x201nwldj5",   // hrp character out of range
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx",
+	} {
+		_, _, _, err := DecodeBech32(s)
+		assert.NotEqual(t, nil, err, s)
+	}
+}
+
+func TestDecodeSegwitAddress(t *testing.T) {
+
+	hrp, version, program, err := DecodeSegwitAddress("BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bc", hrp)
+	assert.Equal(t, 0, version)
+	assert.Equal(t, "751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(program))
+
+	hrp, version, program, err = DecodeSegwitAddress("bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, version)
+	assert.Equal(t, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", hex.EncodeToString(program))
+
+	// v0 program with bech32m checksum
+	_, _, _, err = DecodeSegwitAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCoderBech32HRP(t *testing.T) {
+
+	coder := NewCoder("bech32")
+	coder.HRP("bc")
+	program, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+	res, err := coder.Encode(program)
+	assert.Equal(t, nil, err)
+
+	hrp, data, _, err := DecodeBech32(string(res))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bc", hrp)
+	assert.Equal(t, program, data)
+
+	// bech32m coder refuses bech32 checksums
+	_, err = NewCoder("bech32m").Decode(res)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,81 @@
+package gohash
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// BenchmarkResult is the throughput measured by Benchmark
+type BenchmarkResult struct {
+	Algo           string
+	Implementation string
+	Tries          uint64
+	Elapsed        time.Duration
+
+	// number of keys of a full search
+	Keyspace *big.Int
+}
+
+// Rate returns the number of tries per second
+func (b BenchmarkResult) Rate() float64 {
+
+	if b.Elapsed <= 0 {
+		return 0
+	}
+	return float64(b.Tries) / b.Elapsed.Seconds()
+}
+
+// ETA returns the projected time of a full search, or 0 if unknown
+func (b BenchmarkResult) ETA() time.Duration {
+
+	rate := b.Rate()
+	if b.Keyspace == nil || rate == 0 {
+		return 0
+	}
+	total, _ := new(big.Float).SetInt(b.Keyspace).Float64()
+	secs := total / rate
+	if secs >= math.MaxInt64/float64(time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+func (b BenchmarkResult) String() string {
+	return fmt.Sprintf("%s (%s) ~%.0f/s, %s keys, full search in %s",
+		b.Algo, b.Implementation, b.Rate(), b.Keyspace, b.ETA().Round(time.Second))
+}
+
+// Benchmark runs FindSequential with the configured algo and keys for
+// about d, and returns the measured throughput. The Hasher itself is left
+// as it was, no progress or matches are reported
+func (h *Hasher) Benchmark(d time.Duration) (BenchmarkResult, error) {
+
+	b := *h
+	b.onMatch = nil
+	b.onProgress = nil
+	b.progressWriter = nil
+	b.progressInterval = -1
+	b.resume = nil
+	b.async = nil
+	b.search = nil
+	b.maxAttempts = 0
+	b.maxDuration = d
+	if err := b.verify(); err != nil {
+		return BenchmarkResult{}, err
+	}
+
+	// stops at the limit, at a match or when all keys were tried
+	b.FindSequentialContext(context.Background())
+
+	stats := b.stats()
+	return BenchmarkResult{
+		Algo:           stats.Algo,
+		Implementation: stats.Implementation,
+		Tries:          stats.Tries,
+		Elapsed:        stats.Elapsed,
+		Keyspace:       b.keyspace(),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package gohash
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherBenchmark(t *testing.T) {
+
+	found := 0
+	hasher := NewHasher()
+	hasher.Algo("sha1")
+	hasher.AllowedKeys("580%(=QWI+qwi*Nn")
+	hasher.ExpectedHash("0000000000000000000000000000000000000000")
+	hasher.Length(8)
+	hasher.OnMatch(func(key, hash string) { found++ })
+
+	res, err := hasher.Benchmark(50 * time.Millisecond)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sha1", res.Algo)
+	assert.Equal(t, true, res.Tries > 0)
+	assert.Equal(t, true, res.Elapsed >= 50*time.Millisecond)
+	assert.Equal(t, big.NewInt(1<<32), res.Keyspace)
+	assert.Equal(t, true, res.Rate() > 0)
+	assert.Equal(t, true, res.ETA() > 0)
+	assert.Equal(t, true, strings.HasPrefix(res.String(), "sha1 (generic, one shot) ~"))
+
+	// the hasher is left alone
+	assert.Equal(t, 0, found)
+	assert.Equal(t, uint64(0), hasher.stats().Tries)
+}
+
+func TestHasherBenchmarkInvalid(t *testing.T) {
+
+	hasher := NewHasher()
+	_, err := hasher.Benchmark(time.Millisecond)
+	assert.Equal(t, "minLength unset", err.Error())
+}
+
+func TestBenchmarkResultETA(t *testing.T) {
+
+	res := BenchmarkResult{Tries: 1000, Elapsed: 500 * time.Millisecond, Keyspace: big.NewInt(10000)}
+	assert.Equal(t, 2000.0, res.Rate())
+	assert.Equal(t, 5*time.Second, res.ETA())
+
+	res.Keyspace = nil
+	assert.Equal(t, time.Duration(0), res.ETA())
+}
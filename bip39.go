@@ -0,0 +1,142 @@
+package gohash
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+const defaultBIP39Wordlist = "english"
+
+var (
+	bip39Wordlists = map[string][]string{
+		"chinese-simplified":  wordlists.ChineseSimplified,
+		"chinese-traditional": wordlists.ChineseTraditional,
+		"czech":               wordlists.Czech,
+		"english":             wordlists.English,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	}
+)
+
+// EncodeBIP39 encodes 128-256 bits of entropy as a BIP39 mnemonic using
+// the named wordlist
+func EncodeBIP39(entropy []byte, wordlist string) (string, error) {
+
+	words, err := bip39Words(wordlist)
+	if err != nil {
+		return "", err
+	}
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", fmt.Errorf("bip39: entropy must be 16-32 bytes in steps of 4, got %d", len(entropy))
+	}
+
+	// entropy followed by the first len/32 bits of its sha256
+	checksumBits := len(entropy) / 4
+	bits := append(append([]byte{}, entropy...), bip39Checksum(entropy))
+
+	res := make([]string, (len(entropy)*8+checksumBits)/11)
+	for i := range res {
+		idx := 0
+		for j := 0; j < 11; j++ {
+			pos := i*11 + j
+			idx = idx<<1 | int(bits[pos/8]>>(7-uint(pos%8))&1)
+		}
+		res[i] = words[idx]
+	}
+
+	sep := separator
+	if wordlist == "japanese" {
+		// ideographic space, as required by the spec
+		sep = "　"
+	}
+	return strings.Join(res, sep), nil
+}
+
+// DecodeBIP39 decodes a BIP39 mnemonic to its entropy, verifying the
+// checksum
+func DecodeBIP39(mnemonic string, wordlist string) ([]byte, error) {
+
+	words, err := bip39Words(wordlist)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(mnemonic)
+	if len(fields) < 12 || len(fields) > 24 || len(fields)%3 != 0 {
+		return nil, fmt.Errorf("bip39: invalid mnemonic length %d", len(fields))
+	}
+
+	bits := make([]byte, (len(fields)*11+7)/8)
+	for i, word := range fields {
+		idx := indexOfString(words, word)
+		if idx == -1 {
+			return nil, fmt.Errorf("bip39: unknown word %q", word)
+		}
+		for j := 0; j < 11; j++ {
+			if idx>>(10-uint(j))&1 == 1 {
+				pos := i*11 + j
+				bits[pos/8] |= 1 << (7 - uint(pos%8))
+			}
+		}
+	}
+
+	checksumBits := len(fields) * 11 / 33
+	entropy := bits[:checksumBits*4]
+	mask := byte(0xff << (8 - uint(checksumBits)))
+	if bits[len(entropy)]&mask != bip39Checksum(entropy)&mask {
+		return nil, fmt.Errorf("bip39: checksum mismatch")
+	}
+	return entropy, nil
+}
+
+// BIP39Wordlists returns the names of the available BIP39 wordlists
+func BIP39Wordlists() []string {
+
+	res := []string{}
+	for key := range bip39Wordlists {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func bip39Words(wordlist string) ([]string, error) {
+
+	words, ok := bip39Wordlists[wordlist]
+	if !ok {
+		return nil, fmt.Errorf("bip39: unknown wordlist %s", wordlist)
+	}
+	return words, nil
+}
+
+// bip39Checksum returns the first byte of sha256(entropy), enough for
+// the at most 8 checksum bits
+func bip39Checksum(entropy []byte) byte {
+	return (*hashers["sha256"](&entropy))[0]
+}
+
+// indexOfString returns the index of s in list, or -1
+func indexOfString(list []string, s string) int {
+
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func encodeBIP39(c *Coder, src []byte) ([]byte, error) {
+	s, err := EncodeBIP39(src, c.bip39Wordlist())
+	return []byte(s), err
+}
+
+func decodeBIP39(c *Coder, src []byte) ([]byte, error) {
+	return DecodeBIP39(string(src), c.bip39Wordlist())
+}
@@ -0,0 +1,62 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBIP39(t *testing.T) {
+
+	for entropy, mnemonic := range map[string]string{
+		"00000000000000000000000000000000":                                 "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f":                                 "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"9e885d952ad362caeb4efe34a8e91bd2":                                 "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff": "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	} {
+		src, _ := hex.DecodeString(entropy)
+		res, err := EncodeBIP39(src, "english")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, mnemonic, res)
+
+		dec, err := DecodeBIP39(mnemonic, "english")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, dec)
+	}
+}
+
+func TestBIP39Invalid(t *testing.T) {
+
+	_, err := EncodeBIP39([]byte("short"), "english")
+	assert.NotEqual(t, nil, err)
+
+	_, err = EncodeBIP39(make([]byte, 16), "klingon")
+	assert.NotEqual(t, nil, err)
+
+	// bad checksum
+	_, err = DecodeBIP39("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "english")
+	assert.NotEqual(t, nil, err)
+
+	_, err = DecodeBIP39("abandon abandon abandon", "english")
+	assert.NotEqual(t, nil, err)
+
+	_, err = DecodeBIP39("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon nope", "english")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCoderBIP39Wordlist(t *testing.T) {
+
+	src := bytes.Repeat([]byte{0x80}, 32)
+	for _, wordlist := range BIP39Wordlists() {
+		coder := NewCoder("mnemonic")
+		coder.Wordlist(wordlist)
+		res, err := coder.Encode(src)
+		assert.Equal(t, nil, err, wordlist)
+
+		dec, err := coder.Decode(res)
+		assert.Equal(t, nil, err, wordlist)
+		assert.Equal(t, src, dec, wordlist)
+	}
+}
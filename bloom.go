@@ -0,0 +1,165 @@
+package gohash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"math"
+	"sync/atomic"
+)
+
+const (
+	// false positive rate the filter is sized for
+	bloomFalsePositives = 0.01
+)
+
+// bloomFilter is a set of byte strings that may report strings it does not
+// hold, but never misses one it does. It is safe for concurrent use
+type bloomFilter struct {
+	bits []uint64
+	k    uint64
+}
+
+// newBloomFilter returns a filter sized for n strings
+func newBloomFilter(n uint64) *bloomFilter {
+
+	if n < 1 {
+		n = 1
+	}
+	m := math.Ceil(-float64(n) * math.Log(bloomFalsePositives) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return &bloomFilter{
+		bits: make([]uint64, (uint64(m)+63)/64),
+		k:    uint64(math.Max(1, k)),
+	}
+}
+
+// add adds b, and returns true if it was already in the filter, or a false
+// positive
+func (f *bloomFilter) add(b []byte) bool {
+
+	h := fnv.New64a()
+	h.Write(b)
+	// fnv of similar strings is similar, so it is mixed, and the second
+	// hash of double hashing is made from the first
+	h1 := bloomMix(h.Sum64())
+	h2 := bloomMix(h1) | 1
+
+	m := uint64(len(f.bits)) * 64
+	present := true
+	for i := uint64(0); i < f.k; i++ {
+		n := (h1 + i*h2) % m
+		word, bit := &f.bits[n/64], uint64(1)<<(n%64)
+		for {
+			old := atomic.LoadUint64(word)
+			if old&bit != 0 {
+				break
+			}
+			if atomic.CompareAndSwapUint64(word, old, old|bit) {
+				present = false
+				break
+			}
+		}
+	}
+	return present
+}
+
+// bloomMix is the finalizer of murmur3
+func bloomMix(x uint64) uint64 {
+
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// MarshalBinary returns the number of hashes and the bits of the filter
+func (f *bloomFilter) MarshalBinary() ([]byte, error) {
+
+	res := make([]byte, 8+len(f.bits)*8)
+	binary.BigEndian.PutUint64(res, f.k)
+	for i := range f.bits {
+		binary.BigEndian.PutUint64(res[8+i*8:], atomic.LoadUint64(&f.bits[i]))
+	}
+	return res, nil
+}
+
+// UnmarshalBinary restores a filter written by MarshalBinary
+func (f *bloomFilter) UnmarshalBinary(b []byte) error {
+
+	if len(b) < 16 || len(b)%8 != 0 {
+		return fmt.Errorf("bloom filter is %d bytes, should be at least 16 and a multiple of 8", len(b))
+	}
+	f.k = binary.BigEndian.Uint64(b)
+	f.bits = make([]uint64, len(b)/8-1)
+	for i := range f.bits {
+		f.bits[i] = binary.BigEndian.Uint64(b[8+i*8:])
+	}
+	return nil
+}
+
+// merge adds the strings of other, which must be of the same size
+func (f *bloomFilter) merge(other *bloomFilter) error {
+
+	if len(f.bits) != len(other.bits) || f.k != other.k {
+		return fmt.Errorf("bloom filters are of different sizes")
+	}
+	for i := range f.bits {
+		word, bits := &f.bits[i], other.bits[i]
+		for {
+			old := atomic.LoadUint64(word)
+			if atomic.CompareAndSwapUint64(word, old, old|bits) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// TriedFilter keeps the candidates tried by FindRandom in a bloom filter,
+// sized for n candidates, and skips those already in it. With SaveTried and
+// LoadTried or SaveState, resumed and distributed random searches do not
+// try them again. About 1% of the candidates are skipped wrongly once the
+// filter holds n candidates
+func (h *Hasher) TriedFilter(n uint64) {
+
+	h.tried = nil
+	if n > 0 {
+		h.tried = newBloomFilter(n)
+	}
+}
+
+// SaveTried writes the filter of TriedFilter
+func (h *Hasher) SaveTried(w io.Writer) error {
+
+	if h.tried == nil {
+		return fmt.Errorf("tried filter unset")
+	}
+	b, _ := h.tried.MarshalBinary()
+	_, err := w.Write(b)
+	return err
+}
+
+// LoadTried adds the candidates of a filter written by SaveTried, such as
+// by other machines, to the filter of TriedFilter. Without one, the filter
+// is used as is
+func (h *Hasher) LoadTried(r io.Reader) error {
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	loaded := &bloomFilter{}
+	if err := loaded.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	if h.tried == nil {
+		h.tried = loaded
+		return nil
+	}
+	return h.tried.merge(loaded)
+}
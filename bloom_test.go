@@ -0,0 +1,103 @@
+package gohash
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomFilter(t *testing.T) {
+
+	f := newBloomFilter(10000)
+	assert.Equal(t, uint64(7), f.k)
+	for i := 0; i < 10000; i++ {
+		f.add([]byte(fmt.Sprintf("in %d", i)))
+	}
+	for i := 0; i < 10000; i++ {
+		assert.Equal(t, true, f.add([]byte(fmt.Sprintf("in %d", i))))
+	}
+
+	// few enough to barely fill the filter more
+	falsePositives := 0
+	for i := 0; i < 1000; i++ {
+		if f.add([]byte(fmt.Sprintf("out %d", i))) {
+			falsePositives++
+		}
+	}
+	assert.Equal(t, true, falsePositives < 30, falsePositives)
+}
+
+func TestBloomFilterMarshal(t *testing.T) {
+
+	f := newBloomFilter(100)
+	f.add([]byte("foo"))
+	b, err := f.MarshalBinary()
+	assert.Equal(t, nil, err)
+
+	loaded := &bloomFilter{}
+	assert.Equal(t, nil, loaded.UnmarshalBinary(b))
+	assert.Equal(t, f, loaded)
+
+	other := newBloomFilter(100)
+	other.add([]byte("bar"))
+	assert.Equal(t, nil, loaded.merge(other))
+	assert.Equal(t, true, loaded.add([]byte("foo")))
+	assert.Equal(t, true, loaded.add([]byte("bar")))
+
+	assert.Equal(t, "bloom filters are of different sizes", loaded.merge(newBloomFilter(1000)).Error())
+	assert.Equal(t, "bloom filter is 12 bytes, should be at least 16 and a multiple of 8",
+		loaded.UnmarshalBinary(make([]byte, 12)).Error())
+}
+
+func TestHasherTriedFilter(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+	hasher.AllowedKeys("abc")
+	hasher.Length(3)
+	hasher.TriedFilter(100)
+
+	_, err := hasher.FindRandom()
+	assert.Equal(t, "no match found", err.Error())
+
+	var saved bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveTried(&saved))
+
+	// the keys of the first search are skipped by the second
+	resumed := NewHasher()
+	resumed.Algo("md5")
+	resumed.ExpectedHash("900150983cd24fb0d6963f7d28e17f72")
+	resumed.AllowedKeys("abc")
+	resumed.Length(3)
+	assert.Equal(t, nil, resumed.LoadTried(bytes.NewReader(saved.Bytes())))
+	_, err = resumed.FindRandom()
+	assert.Equal(t, "no match found", err.Error())
+
+	resumed.TriedFilter(100)
+	res, err := resumed.FindRandom()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "abc", res)
+
+	assert.Equal(t, "tried filter unset", NewHasher().SaveTried(&saved).Error())
+}
+
+func TestHasherSaveStateTried(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+	hasher.AllowedKeys("abc")
+	hasher.Length(3)
+	hasher.TriedFilter(100)
+	hasher.tried.add([]byte("abc"))
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveState(&buf))
+
+	loaded := NewHasher()
+	assert.Equal(t, nil, loaded.LoadState(&buf))
+	assert.Equal(t, hasher.tried, loaded.tried)
+}
@@ -0,0 +1,104 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// names used by the bsd tools and coreutils --tag, where they differ
+	// from opensslNames
+	bsdNames = map[string]string{
+		"blake2b-512": "BLAKE2b",
+		"ripemd160":   "RMD160",
+		"sha512-224":  "SHA512t224",
+		"sha512-256":  "SHA512t256",
+	}
+)
+
+// FormatBSD renders a digest like the bsd md5 and sha256 tools and
+// coreutils --tag, "SHA256 (file) = <hex>". Like coreutils, the line starts
+// with a "\" when the file name has a "\", newline or CR, which are escaped
+func FormatBSD(algo string, fileName string, digest []byte) (string, error) {
+
+	algo = resolveAlgoAliases(algo)
+	name, ok := bsdNames[algo]
+	if !ok {
+		name, ok = opensslNames[algo]
+	}
+	if !ok {
+		return "", fmt.Errorf("bsd: unsupported algo %s", algo)
+	}
+	escaped := coreutilsEscaper.Replace(fileName)
+	prefix := ""
+	if escaped != fileName {
+		prefix = "\\"
+	}
+	return prefix + name + " (" + escaped + ") = " + hex.EncodeToString(digest), nil
+}
+
+// ParseBSD parses a line of bsd tag format, returning algo id, file name
+// and digest
+func ParseBSD(line string) (string, string, []byte, error) {
+
+	line = strings.TrimRight(line, "\r\n")
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+
+	open := strings.Index(line, " (")
+	end := strings.LastIndex(line, ") = ")
+	if open <= 0 || end < open+2 {
+		return "", "", nil, fmt.Errorf("bsd: malformed line %q", line)
+	}
+
+	algo := bsdAlgo(line[:open])
+	if algo == "" {
+		return "", "", nil, fmt.Errorf("bsd: unknown algo %s", line[:open])
+	}
+
+	digest, err := hex.DecodeString(line[end+4:])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("bsd: bad digest: %v", err)
+	}
+	if len(digest)*8 != algos[algo] {
+		return "", "", nil, fmt.Errorf("bsd: %s digest should be %d bit, is %d",
+			algo, algos[algo], len(digest)*8)
+	}
+
+	name := line[open+2 : end]
+	if escaped {
+		name = coreutilsUnescaper.Replace(name)
+	}
+	return algo, name, digest, nil
+}
+
+// bsdAlgo returns the algo id of a bsd tag, or "" if it is unknown
+func bsdAlgo(name string) string {
+
+	for algo, n := range bsdNames {
+		if strings.EqualFold(n, name) {
+			return algo
+		}
+	}
+	return opensslAlgo(name)
+}
+
+// WriteBSDManifest writes a line of bsd tag format for each of the
+// results, see FormatBSD. The results may be of different algos
+func WriteBSDManifest(w io.Writer, results []Result) error {
+
+	for _, res := range results {
+		line, err := FormatBSD(res.Algo, res.Name, res.Digest)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBSD(t *testing.T) {
+
+	digest := *NewCalculator([]byte(fox)).Sum("sha256")
+	line, err := FormatBSD("sha256", "fox.txt", digest)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "SHA256 (fox.txt) = "+hex.EncodeToString(digest), line)
+
+	line, err = FormatBSD("sha512-256", "new\nline", *NewCalculator([]byte(fox)).Sum("sha512-256"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(line, `\SHA512t256 (new\nline) = `), line)
+
+	_, err = FormatBSD("crc32", "fox.txt", []byte{1, 2, 3, 4})
+	assert.Equal(t, "bsd: unsupported algo crc32-ieee", err.Error())
+}
+
+func TestParseBSD(t *testing.T) {
+
+	for _, algo := range []string{"md5", "sha1", "sha256", "sha512", "ripemd160", "blake2b-512", "sha512-256"} {
+		for _, name := range []string{"fox.txt", "a (b) = c", `back\slash`, "cr\r"} {
+			digest := *NewCalculator([]byte(fox)).Sum(algo)
+			line, err := FormatBSD(algo, name, digest)
+			assert.Equal(t, nil, err)
+
+			parsedAlgo, parsedName, parsedDigest, err := ParseBSD(line + "\n")
+			assert.Equal(t, nil, err, line)
+			assert.Equal(t, algo, parsedAlgo)
+			assert.Equal(t, name, parsedName)
+			assert.Equal(t, digest, parsedDigest)
+		}
+	}
+
+	algo, name, _, err := ParseBSD("sha256 (fox.txt) = " + strings.Repeat("00", 32))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sha256", algo)
+	assert.Equal(t, "fox.txt", name)
+
+	_, _, _, err = ParseBSD("SHA256(fox.txt)= 00")
+	assert.Equal(t, `bsd: malformed line "SHA256(fox.txt)= 00"`, err.Error())
+	_, _, _, err = ParseBSD("NOPE (fox.txt) = 00")
+	assert.Equal(t, "bsd: unknown algo NOPE", err.Error())
+	_, _, _, err = ParseBSD("MD5 (fox.txt) = 00")
+	assert.Equal(t, "bsd: md5 digest should be 128 bit, is 8", err.Error())
+	_, _, _, err = ParseBSD("MD5 (fox.txt) = xx")
+	assert.Equal(t, "bsd: bad digest: encoding/hex: invalid byte: U+0078 'x'", err.Error())
+}
+
+func TestWriteBSDManifest(t *testing.T) {
+
+	results := []Result{
+		{Algo: "md5", Name: "a.txt", Digest: *NewCalculator([]byte("a")).Sum("md5")},
+		{Algo: "sha1", Name: "a.txt", Digest: *NewCalculator([]byte("a")).Sum("sha1")},
+	}
+	var buf bytes.Buffer
+	assert.Equal(t, nil, WriteBSDManifest(&buf, results))
+	assert.Equal(t, "MD5 (a.txt) = 0cc175b9c0f1b6a831c399e269772661\n"+
+		"SHA1 (a.txt) = 86f7e437faa5a7fce15d1ddcb9eaeaea377667b8\n", buf.String())
+
+	// mixed with coreutils lines
+	buf.WriteString("0cc175b9c0f1b6a831c399e269772661  b.txt\n")
+	entries, malformed, err := ReadManifest(&buf, "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, malformed)
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "md5", entries[0].Algo)
+	assert.Equal(t, "sha1", entries[1].Algo)
+	assert.Equal(t, "md5", entries[2].Algo)
+	assert.Equal(t, "b.txt", entries[2].Name)
+
+	entries, malformed, err = ReadManifest(strings.NewReader("MD5 (a.txt) = 0cc175b9c0f1b6a831c399e269772661\n"), "sha1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, malformed)
+	assert.Equal(t, 0, len(entries))
+}
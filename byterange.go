@@ -0,0 +1,153 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// ByteRange is a part of an input, from Offset and Length bytes long, or
+// to the end of the input if Length is 0
+type ByteRange struct {
+	Offset int64
+	Length int64
+}
+
+// String returns the range as "offset:length", or "offset:" to the end
+func (b ByteRange) String() string {
+
+	if b.Length == 0 {
+		return fmt.Sprintf("%d:", b.Offset)
+	}
+	return fmt.Sprintf("%d:%d", b.Offset, b.Length)
+}
+
+// ParseByteRanges parses comma separated "offset:length" ranges, such as
+// "0:512,0x8000:" for the first 512 bytes and everything from 0x8000
+func ParseByteRanges(s string) ([]ByteRange, error) {
+
+	res := []ByteRange{}
+	for _, part := range strings.Split(s, ",") {
+		pair := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("byte range %q should be offset:length", part)
+		}
+		var b ByteRange
+		var err error
+		if b.Offset, err = strconv.ParseInt(pair[0], 0, 64); err != nil || b.Offset < 0 {
+			return nil, fmt.Errorf("byte range %q has an invalid offset", part)
+		}
+		if pair[1] != "" {
+			if b.Length, err = strconv.ParseInt(pair[1], 0, 64); err != nil || b.Length < 1 {
+				return nil, fmt.Errorf("byte range %q has an invalid length", part)
+			}
+		}
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+// rangeReader reads the ranges of an input one after the other
+type rangeReader struct {
+	r      io.Reader
+	at     io.ReaderAt
+	ranges []ByteRange
+
+	// position in r, when it is read as a stream
+	pos int64
+
+	// current range, and bytes read of it
+	cur  int
+	done int64
+}
+
+// NewRangeReader returns a reader of the ranges of r, one after the other.
+// When r is an io.ReaderAt, such as a file, only the bytes of the ranges
+// are read. Otherwise the ranges must be in order without overlapping,
+// and the bytes between them are skipped. Reading fails when a range
+// with a length is past the end of r
+func NewRangeReader(r io.Reader, ranges []ByteRange) (io.Reader, error) {
+
+	res := &rangeReader{r: r, ranges: ranges}
+	if at, ok := r.(io.ReaderAt); ok {
+		res.at = at
+		return res, nil
+	}
+	end := int64(0)
+	for i, b := range ranges {
+		if b.Offset < end || (b.Length == 0 && i != len(ranges)-1) {
+			return nil, fmt.Errorf("byte ranges of a stream must be in order, without overlapping")
+		}
+		end = b.Offset + b.Length
+	}
+	return res, nil
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for r.cur < len(r.ranges) {
+		b := r.ranges[r.cur]
+		if b.Length != 0 && r.done == b.Length {
+			r.cur++
+			r.done = 0
+			continue
+		}
+		if b.Length != 0 && int64(len(p)) > b.Length-r.done {
+			p = p[:b.Length-r.done]
+		}
+
+		var n int
+		var err error
+		if r.at != nil {
+			n, err = r.at.ReadAt(p, b.Offset+r.done)
+		} else {
+			if skip := b.Offset + r.done - r.pos; skip > 0 {
+				skipped, _ := io.CopyN(ioutil.Discard, r.r, skip)
+				r.pos += skipped
+			}
+			if r.pos == b.Offset+r.done {
+				n, err = r.r.Read(p)
+			} else {
+				err = io.EOF
+			}
+			r.pos += int64(n)
+		}
+		r.done += int64(n)
+
+		if err == io.EOF {
+			if b.Length != 0 && r.done < b.Length {
+				return n, fmt.Errorf("byte range %s is past the end of the input", b)
+			}
+			// the rest of the input
+			r.cur++
+			r.done = 0
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+	return 0, io.EOF
+}
+
+// RangeInput returns the ranges of in, see NewRangeReader
+func RangeInput(in Input, ranges []ByteRange) Input {
+
+	return Input{Name: in.Name, Open: func() (io.ReadCloser, error) {
+		r, err := in.Open()
+		if err != nil {
+			return nil, err
+		}
+		ranged, err := NewRangeReader(r, ranges)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		return readCloser{ranged, r}, nil
+	}}
+}
@@ -0,0 +1,93 @@
+package gohash
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseByteRanges(t *testing.T) {
+
+	res, err := ParseByteRanges("0:512, 0x8000:")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []ByteRange{{0, 512}, {0x8000, 0}}, res)
+	assert.Equal(t, "0:512", res[0].String())
+	assert.Equal(t, "32768:", res[1].String())
+
+	_, err = ParseByteRanges("512")
+	assert.Equal(t, `byte range "512" should be offset:length`, err.Error())
+	_, err = ParseByteRanges("-1:5")
+	assert.Equal(t, `byte range "-1:5" has an invalid offset`, err.Error())
+	_, err = ParseByteRanges("1:0")
+	assert.Equal(t, `byte range "1:0" has an invalid length`, err.Error())
+}
+
+// onlyReader hides the io.ReaderAt of a reader
+type onlyReader struct {
+	r *strings.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func readRanges(t *testing.T, ranges []ByteRange, stream bool) (string, error) {
+
+	var r io.Reader = strings.NewReader(fox)
+	if stream {
+		r = iotest.OneByteReader(onlyReader{strings.NewReader(fox)})
+	}
+	ranged, err := NewRangeReader(r, ranges)
+	assert.Equal(t, nil, err)
+	b, err := ioutil.ReadAll(ranged)
+	return string(b), err
+}
+
+func TestRangeReader(t *testing.T) {
+
+	// "The quick brown fox jumps over the lazy dog"
+	for _, stream := range []bool{false, true} {
+		res, err := readRanges(t, []ByteRange{{4, 5}, {16, 3}}, stream)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "quickfox", res)
+
+		res, err = readRanges(t, []ByteRange{{0, 3}, {40, 0}}, stream)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "Thedog", res)
+
+		res, err = readRanges(t, []ByteRange{{50, 0}}, stream)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "", res)
+
+		res, err = readRanges(t, []ByteRange{{40, 10}}, stream)
+		assert.Equal(t, "byte range 40:10 is past the end of the input", err.Error())
+		assert.Equal(t, "dog", res)
+	}
+
+	// out of order ranges need an io.ReaderAt
+	res, err := readRanges(t, []ByteRange{{16, 3}, {4, 5}, {4, 5}}, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "foxquickquick", res)
+
+	_, err = NewRangeReader(onlyReader{strings.NewReader(fox)}, []ByteRange{{16, 3}, {4, 5}})
+	assert.Equal(t, "byte ranges of a stream must be in order, without overlapping", err.Error())
+	_, err = NewRangeReader(onlyReader{strings.NewReader(fox)}, []ByteRange{{0, 0}, {4, 5}})
+	assert.Equal(t, "byte ranges of a stream must be in order, without overlapping", err.Error())
+}
+
+func TestRangeInput(t *testing.T) {
+
+	name := filepath.Join(t.TempDir(), "disk.img")
+	assert.Equal(t, nil, os.WriteFile(name, []byte(fox), 0644))
+
+	res := HashInputs([]Input{RangeInput(FileInput(name), []ByteRange{{16, 3}})}, "md5", "", 1)
+	assert.Equal(t, nil, res[0].Err)
+	assert.Equal(t, *NewCalculator([]byte("fox")).Sum("md5"), res[0].Result.Digest)
+	assert.Equal(t, int64(3), res[0].Result.Size)
+}
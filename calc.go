@@ -28,6 +28,7 @@ import (
 	"golang.org/x/crypto/md4"
 	"golang.org/x/crypto/ripemd160"
 	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
 )
 
 // Calculator is used to calculate hash of input cleartext
@@ -53,6 +54,7 @@ var (
 		"blake2b-256":       256,
 		"blake2b-512":       512,
 		"blake2s-256":       256,
+		"blake3-256":        256,
 		"crc8-atm":          8,
 		"crc16-ccitt":       16,
 		"crc16-ccitt-false": 16,
@@ -64,10 +66,19 @@ var (
 		"crc32-koopman":     32,
 		"crc64-iso":         64,
 		"crc64-ecma":        64,
+		"fnv1-32":           32,
+		"fnv1a-32":          32,
+		"fnv1-64":           64,
+		"fnv1a-64":          64,
 		"gost":              256,
+		"lm":                128,
+		"lm-half":           64,
 		"md2":               128,
 		"md4":               128,
 		"md5":               128,
+		"mysql323":          64,
+		"mysql41":           160,
+		"ntlm":              128,
 		"ripemd160":         160,
 		"sha1":              160,
 		"sha224":            224,
@@ -98,6 +109,7 @@ var (
 		"blake2b-256":       blake2b256Sum,
 		"blake2b-512":       blake2b512Sum,
 		"blake2s-256":       blake2s256Sum,
+		"blake3-256":        blake3_256Sum,
 		"crc8-atm":          crc8AtmSum,
 		"crc16-ccitt":       crc16CcittSum,
 		"crc16-ccitt-false": crc16CcittFalseSum,
@@ -114,9 +126,14 @@ var (
 		"fnv1-64":           fnv1_64Sum,
 		"fnv1a-64":          fnv1a64Sum,
 		"gost":              gostSum,
+		"lm":                lmSum,
+		"lm-half":           lmHalfSum,
 		"md2":               md2Sum,
 		"md4":               md4Sum,
 		"md5":               md5Sum,
+		"mysql323":          mysql323Sum,
+		"mysql41":           mysql41Sum,
+		"ntlm":              ntlmSum,
 		"ripemd160":         ripemd160Sum,
 		"sha1":              sha1Sum,
 		"sha224":            sha224Sum,
@@ -150,6 +167,34 @@ func (c *Calculator) Sum(algo string) *[]byte {
 	return nil
 }
 
+// AlgoInfo holds metadata about a hash algorithm
+type AlgoInfo struct {
+	ID             string
+	BitSize        int
+	Implementation string
+}
+
+// HashInfo returns metadata about algo, or nil if algo is unknown
+func HashInfo(algo string) *AlgoInfo {
+
+	algo = resolveAlgoAliases(algo)
+
+	if _, ok := hashers[algo]; !ok {
+		return nil
+	}
+
+	impl, ok := implementations[algo]
+	if !ok {
+		impl = implGeneric
+	}
+
+	return &AlgoInfo{
+		ID:             algo,
+		BitSize:        algos[algo],
+		Implementation: impl,
+	}
+}
+
 // AvailableHashes returns the available hash id's
 func AvailableHashes() []string {
 
@@ -165,6 +210,9 @@ func AvailableHashes() []string {
 
 func resolveAlgoAliases(s string) string {
 
+	if s == "blake3" {
+		return "blake3-256"
+	}
 	if s == "crc32" {
 		return "crc32-ieee"
 	}
@@ -246,6 +294,12 @@ func blake2s256Sum(b *[]byte) *[]byte {
 	return &res
 }
 
+func blake3_256Sum(b *[]byte) *[]byte {
+	x := blake3.Sum256(*b)
+	res := x[:]
+	return &res
+}
+
 func crc8AtmSum(b *[]byte) *[]byte {
 	i := crc8.ChecksumATM(*b)
 	bs := make([]byte, 1)
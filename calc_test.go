@@ -30,6 +30,9 @@ var (
 		"blake2s-256": {
 			fox:   "606beeec743ccbeff6cbcdf5d5302aa855c256c29b88c8ed331ea1a6bf3c8812",
 			blank: "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+		"blake3-256": {
+			fox:   "2f1514181aadccd913abd94cfa592701a5686ab23f8df1dff1b74710febc6d4a",
+			blank: "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
 		"crc8-atm": {
 			fox:   "c1",
 			blank: "00"},
@@ -81,12 +84,27 @@ var (
 		"md2": {
 			fox:   "03d85a0d629d2c442e987525319fc471",
 			blank: "8350e5a3e24c153df2275c9f80692773"},
+		"lm": {
+			fox:   "a7b07f9948d8cc7f97c4b0b30cae500f",
+			blank: "aad3b435b51404eeaad3b435b51404ee"},
+		"lm-half": {
+			fox:   "a7b07f9948d8cc7f",
+			blank: "aad3b435b51404ee"},
 		"md4": {
 			fox:   "1bee69a46ba811185c194762abaeae90",
 			blank: "31d6cfe0d16ae931b73c59d7e0c089c0"},
 		"md5": {
 			fox:   "9e107d9d372bb6826bd81d3542a419d6",
 			blank: "d41d8cd98f00b204e9800998ecf8427e"},
+		"mysql323": {
+			fox:   "7ea6156f183bc34f",
+			blank: "5030573512345671"},
+		"mysql41": {
+			fox:   "a4e4d26fd0c6455e23e2187c3aabe844332aa1b3",
+			blank: "be1bdec0aa74b4dcb079943e70528096cca985f8"},
+		"ntlm": {
+			fox:   "4e6a076ae1b04a815fa6332f69e2e231",
+			blank: "31d6cfe0d16ae931b73c59d7e0c089c0"},
 		"ripemd160": {
 			fox:   "37f332f68db77bd9d7edd4969571ad671cf9dd3b",
 			blank: "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
@@ -172,3 +190,16 @@ func TestFuzzHashes(t *testing.T) {
 		}
 	}
 }
+
+func TestHashInfo(t *testing.T) {
+
+	info := HashInfo("blake3")
+	assert.Equal(t, "blake3-256", info.ID)
+	assert.Equal(t, 256, info.BitSize)
+	assert.NotEqual(t, "", info.Implementation)
+
+	info = HashInfo("md5")
+	assert.Equal(t, implGeneric, info.Implementation)
+
+	assert.Nil(t, HashInfo("nope"))
+}
@@ -0,0 +1,79 @@
+package gohash
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// named charsets, for AllowedCharset and ?{name} in masks
+	charsetPresets = map[string]string{
+		"lower":     maskLower,
+		"upper":     maskUpper,
+		"digits":    maskDigits,
+		"symbols":   maskSymbols,
+		"alpha":     maskLower + maskUpper,
+		"alnum":     maskLower + maskUpper + maskDigits,
+		"printable": maskLower + maskUpper + maskDigits + maskSymbols,
+		"hex":       maskDigits + "abcdef",
+		"HEX":       maskDigits + "ABCDEF",
+		"base32":    maskUpper + "234567",
+		"onion":     maskLower + "234567",
+		"base64":    maskUpper + maskLower + maskDigits + "+/",
+		"base64url": maskUpper + maskLower + maskDigits + "-_",
+		"bytes":     allBytes(),
+	}
+)
+
+// Charset returns the keys of a named charset, such as "lower" or "onion".
+// Several names can be joined with "+", as in "lower+digits"
+func Charset(name string) (string, error) {
+
+	res := ""
+	for _, part := range strings.Split(name, "+") {
+		keys, ok := charsetPresets[part]
+		if !ok {
+			return "", fmt.Errorf("unknown charset %q, use one of %s", part, strings.Join(CharsetNames(), ", "))
+		}
+		res += keys
+	}
+	return string(strToDistinctByteSlice(res)), nil
+}
+
+// CharsetNames returns the names of the charsets, in sorted order
+func CharsetNames() []string {
+
+	res := make([]string, 0, len(charsetPresets))
+	for name := range charsetPresets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// AllowedCharset sets the allowed keys to a named charset, see Charset
+func (h *Hasher) AllowedCharset(name string) error {
+
+	keys, err := Charset(name)
+	if err != nil {
+		return err
+	}
+	h.AllowedKeys(keys)
+	return nil
+}
+
+// namedPlaceholder returns the keys of the ?{name} placeholder starting at
+// s[i], and the index of its closing brace
+func namedPlaceholder(s string, i int) ([]byte, int, error) {
+
+	end := strings.IndexByte(s[i:], '}')
+	if end == -1 {
+		return nil, 0, fmt.Errorf("mask: unterminated charset name in %q", s)
+	}
+	keys, err := Charset(s[i+1 : i+end])
+	if err != nil {
+		return nil, 0, fmt.Errorf("mask: %v", err)
+	}
+	return []byte(keys), i + end, nil
+}
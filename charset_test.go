@@ -0,0 +1,71 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharset(t *testing.T) {
+
+	keys, err := Charset("hex")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0123456789abcdef", keys)
+
+	keys, err = Charset("digits+hex")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "0123456789abcdef", keys)
+
+	keys, err = Charset("onion")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 32, len(keys))
+
+	keys, err = Charset("printable")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 95, len(keys))
+
+	_, err = Charset("lower+klingon")
+	assert.Contains(t, err.Error(), `unknown charset "klingon", use one of HEX, alnum, alpha,`)
+}
+
+func TestHasherAllowedCharset(t *testing.T) {
+
+	hasher := NewHasher()
+	assert.Equal(t, nil, hasher.AllowedCharset("lower+digits"))
+	assert.Equal(t, maskDigits+maskLower, hasher.GetAllowedKeys())
+
+	assert.NotEqual(t, nil, hasher.AllowedCharset("nope"))
+}
+
+func TestParseMaskNamedCharset(t *testing.T) {
+
+	positions, err := parseMask("?{hex}x?{upper+digits}", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]byte{
+		[]byte(maskDigits + "abcdef"),
+		[]byte("x"),
+		[]byte(maskDigits + maskUpper),
+	}, positions)
+
+	keys, err := expandCharset("?{digits}?l", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte(maskDigits+maskLower), keys)
+
+	_, err = parseMask("?{hex", nil)
+	assert.Equal(t, `mask: unterminated charset name in "?{hex"`, err.Error())
+
+	_, err = parseMask("?{nope}", nil)
+	assert.Contains(t, err.Error(), `mask: unknown charset "nope"`)
+}
+
+func TestHashMaskNamedCharset(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("7887b8f9dc39bba09eebd4c0993dc78e")
+	hasher.Mask("?{upper}?{lower}?l?{digits}")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Hej7", res)
+}
@@ -0,0 +1,288 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// hasherState is the JSON checkpoint of a Hasher
+type hasherState struct {
+	Algo           string         `json:"algo"`
+	Template       string         `json:"template,omitempty"`
+	Salt           string         `json:"salt,omitempty"`
+	Iterations     int            `json:"iterations,omitempty"`
+	IterateHex     bool           `json:"iterate_hex,omitempty"`
+	HMACKey        *string        `json:"hmac_key,omitempty"`
+	HMACMessage    *string        `json:"hmac_message,omitempty"`
+	Expected       string         `json:"expected,omitempty"`
+	Targets        []string       `json:"targets,omitempty"`
+	Crypt          string         `json:"crypt,omitempty"`
+	DigestPrefix   string         `json:"digest_prefix,omitempty"`
+	ZeroBits       int            `json:"zero_bits,omitempty"`
+	DigestRegexp   string         `json:"digest_regexp,omitempty"`
+	DigestEncoding string         `json:"digest_encoding,omitempty"`
+	Prefix         string         `json:"prefix,omitempty"`
+	Suffix         string         `json:"suffix,omitempty"`
+	MinLength      int            `json:"min_length"`
+	MaxLength      int            `json:"max_length"`
+	AllowedKeys    string         `json:"allowed_keys,omitempty"`
+	Binary         *binaryState   `json:"binary,omitempty"`
+	Mask           string         `json:"mask,omitempty"`
+	CustomCharsets map[int]string `json:"custom_charsets,omitempty"`
+	PositionKeys   map[int]string `json:"position_keys,omitempty"`
+	Markov         [][4]int       `json:"markov,omitempty"`
+	Reverse        bool           `json:"reverse,omitempty"`
+	Workers        int            `json:"workers"`
+
+	// hashes in hex by algo, see AlsoExpect
+	Extra map[string][]string `json:"extra,omitempty"`
+
+	// bloom filter of the candidates tried by random searches
+	Tried []byte `json:"tried,omitempty"`
+
+	// keys by hash in hex, when there are several targets
+	Found map[string]string `json:"found,omitempty"`
+
+	Tries    uint64        `json:"tries"`
+	Length   int           `json:"length"`
+	Progress []workerState `json:"progress,omitempty"`
+}
+
+// binaryState holds the settings that are not valid utf-8, as json
+// strings would not keep them
+type binaryState struct {
+	AllowedKeys []byte `json:"allowed_keys,omitempty"`
+	Prefix      []byte `json:"prefix,omitempty"`
+	Suffix      []byte `json:"suffix,omitempty"`
+}
+
+// workerState is how far a worker of a sequential search has come
+type workerState struct {
+	// Key is the last key reported by the worker
+	Key  []byte `json:"key,omitempty"`
+	Done bool   `json:"done,omitempty"`
+}
+
+// SaveState writes the configuration and progress of the Hasher as JSON.
+// It can be called while FindSequential is running, from another goroutine
+func (h *Hasher) SaveState(w io.Writer) error {
+
+	mutex.Lock()
+	state := hasherState{
+		Algo:           h.algo,
+		Template:       h.template,
+		Salt:           h.salt,
+		Iterations:     h.iterations,
+		IterateHex:     h.iterateHex,
+		HMACKey:        h.hmacKey,
+		HMACMessage:    h.hmacMessage,
+		Expected:       hex.EncodeToString(h.expected),
+		DigestPrefix:   h.digestPrefix,
+		ZeroBits:       h.zeroBits,
+		DigestRegexp:   h.digestRegexp,
+		DigestEncoding: h.digestEncoding,
+		MinLength:      h.minLength,
+		MaxLength:      h.maxLength,
+		Mask:           h.mask,
+		CustomCharsets: h.customCharsets,
+		PositionKeys:   h.positionKeys,
+		Markov:         h.markovState(),
+		Reverse:        h.reverse,
+		Workers:        h.workers,
+		Tries:          h.try,
+		Length:         h.length,
+	}
+	if h.tried != nil {
+		state.Tried, _ = h.tried.MarshalBinary()
+	}
+	binary := binaryState{}
+	state.AllowedKeys, binary.AllowedKeys = textOrBytes(h.allowedKeys)
+	state.Prefix, binary.Prefix = textOrBytes([]byte(h.prefix))
+	state.Suffix, binary.Suffix = textOrBytes([]byte(h.suffix))
+	if binary.AllowedKeys != nil || binary.Prefix != nil || binary.Suffix != nil {
+		state.Binary = &binary
+	}
+	if h.cryptHash != "" {
+		state.Expected = ""
+		state.Crypt = h.cryptHash
+	}
+	for digest := range h.targets {
+		state.Targets = append(state.Targets, hex.EncodeToString([]byte(digest)))
+	}
+	for _, extra := range h.extra {
+		if state.Extra == nil {
+			state.Extra = make(map[string][]string)
+		}
+		for digest := range extra.targets {
+			state.Extra[extra.algo] = append(state.Extra[extra.algo], hex.EncodeToString([]byte(digest)))
+		}
+	}
+	if h.targets != nil {
+		state.Expected = ""
+	}
+	if h.targets != nil || h.extra != nil {
+		state.Found = make(map[string]string, len(h.found))
+		for digest, key := range h.found {
+			state.Found[hex.EncodeToString([]byte(digest))] = key
+		}
+	}
+	for _, p := range h.progress {
+		state.Progress = append(state.Progress, workerState{
+			Key:  append([]byte{}, p.Key...),
+			Done: p.Done,
+		})
+	}
+	mutex.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(state)
+}
+
+// LoadState configures the Hasher from a state written by SaveState, so
+// FindSequential resumes from the last keys reported before it was saved
+func (h *Hasher) LoadState(r io.Reader) error {
+
+	var state hasherState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return err
+	}
+
+	h.Algo(state.Algo)
+	h.Template(state.Template)
+	h.Salt(state.Salt)
+	h.Iterations(state.Iterations)
+	h.IterateHex(state.IterateHex)
+	h.hmacKey = state.HMACKey
+	h.hmacMessage = state.HMACMessage
+	if state.Targets != nil {
+		if err := h.ExpectedHashes(state.Targets); err != nil {
+			return err
+		}
+	} else if state.Crypt != "" {
+		h.ExpectedHash(state.Crypt)
+	} else {
+		h.ExpectedHash(state.Expected)
+	}
+	h.DigestPrefix(state.DigestPrefix)
+	h.LeadingZeroBits(state.ZeroBits)
+	h.DigestRegexp(state.DigestRegexp)
+	h.DigestEncoding(state.DigestEncoding)
+	h.Prefix(state.Prefix)
+	h.Suffix(state.Suffix)
+	h.MinLength(state.MinLength)
+	h.MaxLength(state.MaxLength)
+	h.AllowedKeys(state.AllowedKeys)
+	h.Mask(state.Mask)
+	h.customCharsets = state.CustomCharsets
+	h.positionKeys = state.PositionKeys
+	h.markovCounts = nil
+	for _, m := range state.Markov {
+		if h.markovCounts == nil {
+			h.markovCounts = make(map[markovKey]int)
+		}
+		h.markovCounts[markovKey{m[0], byte(m[1]), byte(m[2])}] = m[3]
+	}
+	if b := state.Binary; b != nil {
+		if b.AllowedKeys != nil {
+			h.AllowedBytes(b.AllowedKeys)
+		}
+		if b.Prefix != nil {
+			h.Prefix(string(b.Prefix))
+		}
+		if b.Suffix != nil {
+			h.Suffix(string(b.Suffix))
+		}
+	}
+	h.extra = nil
+	for algo, hashes := range state.Extra {
+		if err := h.AlsoExpect(algo, hashes); err != nil {
+			return err
+		}
+	}
+	h.tried = nil
+	if state.Tried != nil {
+		h.tried = &bloomFilter{}
+		if err := h.tried.UnmarshalBinary(state.Tried); err != nil {
+			return err
+		}
+	}
+	h.Reverse(state.Reverse)
+	h.Workers(state.Workers)
+	h.try = state.Tries
+	h.resume = nil
+
+	if err := h.verify(); err != nil {
+		return err
+	}
+	for s, key := range state.Found {
+		digest, err := hex.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		h.found[string(digest)] = key
+	}
+
+	if state.Length == 0 {
+		return nil
+	}
+	if state.Length < h.minLength || state.Length > h.maxLength {
+		return fmt.Errorf("state length %d is out of range", state.Length)
+	}
+	for _, p := range state.Progress {
+		if p.Key == nil {
+			continue
+		}
+		if len(p.Key) != state.Length {
+			return fmt.Errorf("state key %q is not %d long", p.Key, state.Length)
+		}
+		for i, b := range p.Key {
+			if !isByteInSlice(b, h.positions[i]) {
+				return fmt.Errorf("state key %q is not allowed", p.Key)
+			}
+		}
+	}
+	h.resume = &state
+	return nil
+}
+
+// checkpoint records the progress of worker w
+func (h *Hasher) checkpoint(w int, key []byte, done bool) {
+
+	mutex.Lock()
+	h.progress[w].Key = append(h.progress[w].Key[:0], key...)
+	h.progress[w].Done = done
+	mutex.Unlock()
+}
+
+// startLength resets the progress of the workers for a new length, or
+// restores it when resuming at length
+func (h *Hasher) startLength(length, workers int) []workerState {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	h.length = length
+	h.progress = make([]workerState, workers)
+	if h.resume != nil && h.resume.Length == length && len(h.resume.Progress) == workers {
+		copy(h.progress, h.resume.Progress)
+	}
+	h.resume = nil
+
+	res := make([]workerState, workers)
+	copy(res, h.progress)
+	return res
+}
+
+// indexOfKey returns the search order index of b at pos of key
+func (h *Hasher) indexOfKey(key []byte, pos int, b byte) int {
+
+	for i := range h.positions[pos] {
+		if h.keyAt(key, pos, i) == b {
+			return i
+		}
+	}
+	return 0
+}
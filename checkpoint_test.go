@@ -0,0 +1,93 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCheckpointHasher() *Hasher {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("holej")
+	hasher.MinLength(2)
+	hasher.MaxLength(3)
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+	hasher.Workers(2)
+	return hasher
+}
+
+// resumeAt returns a state of h with the workers at keys
+func resumeAt(t *testing.T, h *Hasher, length int, progress []workerState) *bytes.Buffer {
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, h.SaveState(&buf))
+
+	var state hasherState
+	assert.Equal(t, nil, json.Unmarshal(buf.Bytes(), &state))
+	state.Length = length
+	state.Progress = progress
+
+	buf.Reset()
+	assert.Equal(t, nil, json.NewEncoder(&buf).Encode(state))
+	return &buf
+}
+
+func TestSaveAndLoadState(t *testing.T) {
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, newCheckpointHasher().SaveState(&buf))
+
+	hasher := NewHasher()
+	assert.Equal(t, nil, hasher.LoadState(&buf))
+	assert.Equal(t, "md5", hasher.algo)
+	assert.Equal(t, 2, hasher.workers)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestLoadStateResumes(t *testing.T) {
+
+	// with 5 keys and 2 workers, worker 0 has keys starting with e, j, o
+	// and worker 1 those starting with h, l
+	hasher := NewHasher()
+	state := resumeAt(t, newCheckpointHasher(), 3, []workerState{
+		{Done: true},
+		{Key: []byte("hee")},
+	})
+	assert.Equal(t, nil, hasher.LoadState(state))
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+
+	// worker 1 is past the match
+	hasher = NewHasher()
+	state = resumeAt(t, newCheckpointHasher(), 3, []workerState{
+		{Done: true},
+		{Key: []byte("hel")},
+	})
+	assert.Equal(t, nil, hasher.LoadState(state))
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+}
+
+func TestLoadStateInvalid(t *testing.T) {
+
+	for _, progress := range [][]workerState{
+		{{Key: []byte("he")}},
+		{{Key: []byte("hex")}},
+	} {
+		hasher := NewHasher()
+		state := resumeAt(t, newCheckpointHasher(), 3, progress)
+		assert.NotEqual(t, nil, hasher.LoadState(state))
+	}
+
+	hasher := NewHasher()
+	state := resumeAt(t, newCheckpointHasher(), 4, nil)
+	assert.Equal(t, "state length 4 is out of range", hasher.LoadState(state).Error())
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
@@ -13,6 +14,7 @@ import (
 var (
 	encoding          = kingpin.Arg("encoding", "Output encoding.").String()
 	listEncodings     = kingpin.Flag("list-encodings", "List available encodings.").Short('E').Bool()
+	detect            = kingpin.Flag("detect", "List plausible encodings of input, most likely first.").Bool()
 	fileName          = kingpin.Arg("file", "Input file to read.").String()
 	encode            = kingpin.Flag("encode", "Encode input (default).").Short('e').Bool()
 	decode            = kingpin.Flag("decode", "Decode input.").Short('d').Bool()
@@ -31,6 +33,16 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *detect {
+		appInputData, err := gohash.ReadPipeOrFile(*fileName)
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		fmt.Println(gohash.DetectEncoding(string(appInputData.Data)))
+		os.Exit(0)
+	}
+
 	encodings := strings.Split(*encoding, "+")
 
 	if len(encodings) == 0 {
@@ -43,6 +55,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(encodings) == 1 {
+		coder := gohash.NewCoder(encodings[0])
+		if coder.CanStream() {
+			if err := stream(coder); err != nil {
+				log.Fatal("error:", err)
+			}
+			return
+		}
+	}
+
 	appInputData, err := gohash.ReadPipeOrFile(*fileName)
 	if err != nil {
 		fmt.Println("error:", err)
@@ -72,3 +94,46 @@ func main() {
 		}
 	}
 }
+
+// stream transcodes input to output without reading it all into memory
+func stream(coder *gohash.Coder) error {
+
+	in, err := gohash.OpenPipeOrFile(*fileName)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out := os.Stdout
+	if *outFileName != "" {
+		out, err = os.Create(*outFileName)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	if *decode {
+		r, err := coder.NewDecoder(in)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, r)
+		return err
+	}
+
+	w, err := coder.NewEncoder(out)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, in); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	if *outFileName == "" && !*noTrailingNewline {
+		fmt.Println()
+	}
+	return nil
+}
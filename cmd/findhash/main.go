@@ -1,10 +1,17 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/martinlindhe/gohash"
@@ -12,17 +19,61 @@ import (
 )
 
 var (
-	hash        = kingpin.Arg("hash", "Hash to crack, in hex string").Required().String()
+	hash        = kingpin.Arg("hash", "Hash to crack, in hex or base64").String()
+	hashFile    = kingpin.Flag("hash-file", "File of hashes to crack, one per line (with algo).").String()
+	alsoFiles   = kingpin.Flag("also", "Also check candidates against a file of hashes of another algo, as algo:file.").Strings()
+	hashPrefix  = kingpin.Flag("digest-prefix", "Find a digest starting with hex prefix instead of hash.").String()
+	zeroBits    = kingpin.Flag("zero-bits", "Find a digest with leading zero bits instead of hash.").Int()
+	hashRegexp  = kingpin.Flag("digest-regexp", "Find a digest matching regexp instead of hash.").String()
+	hashEncode  = kingpin.Flag("digest-encoding", "Encoding of digest matched by digest-regexp.").Default("hex").String()
 	algo        = kingpin.Flag("algo", "Hash algorithm to use.").String()
+	template    = kingpin.Flag("template", "Hash template instead of algo, such as md5($salt.md5($pass)).").String()
+	salt        = kingpin.Flag("salt", "Salt, used as $salt in template.").String()
+	iterations  = kingpin.Flag("iterations", "Number of times the hash is applied.").Default("1").Int()
+	iterateHex  = kingpin.Flag("iterate-hex", "Hash the hex digest of the previous iteration.").Bool()
+	hmacMessage = kingpin.Flag("hmac-message", "Known hmac message, find the key.").String()
+	hmacKey     = kingpin.Flag("hmac-key", "Known hmac key, find the message.").String()
 	allowedKeys = kingpin.Flag("allowed", "Allowed keys to use.").String()
+	onion       = kingpin.Flag("onion", "Generate a v3 onion address starting with this prefix.").String()
+	onionRegexp = kingpin.Flag("onion-regexp", "Generate a v3 onion address matching this regular expression.").String()
+	onionDir    = kingpin.Flag("onion-dir", "Directory to write the tor keys and hostname of the onion address to.").String()
+	pgUser      = kingpin.Flag("postgres-user", "User name of a postgres md5 hash.").String()
+	escapes     = kingpin.Flag("escapes", "Decode escapes such as \\x00 in allowed, prefix and suffix, for any byte.").Bool()
+	allowedSet  = kingpin.Flag("allowed-charset", "Named charset of allowed keys, such as lower+digits or onion.").String()
 	minLength   = kingpin.Flag("min-length", "Minimum length.").Int()
+	maxLength   = kingpin.Flag("max-length", "Maximum length, defaults to min-length.").Int()
+	mask        = kingpin.Flag("mask", "Mask, such as ?u?l?l?d?d.").String()
+	charsets    = kingpin.Flag("charset", "Custom charset for ?1 to ?4 in mask, in order.").Strings()
+	positions   = kingpin.Flag("position", "Allowed keys at a position, counting from 0, such as 0=?u.").Strings()
 	prefix      = kingpin.Flag("prefix", "Prefix.").String()
 	suffix      = kingpin.Flag("suffix", "Suffix.").String()
 	random      = kingpin.Flag("random", "Random mutation mode.").Bool()
+	all         = kingpin.Flag("all", "Find all matches in keyspace, not just the first (if not random mode).").Bool()
 	reverse     = kingpin.Flag("reverse", "Reverse order (if not random mode).").Bool()
+	workers     = kingpin.Flag("workers", "Number of workers (if not random mode).").Default(strconv.Itoa(runtime.NumCPU())).Int()
 	dictionary  = kingpin.Flag("dictionary", "Dictionary file.").String()
+	markov      = kingpin.Flag("markov", "Wordlist to train the key order from (if not random mode).").String()
+	stdin       = kingpin.Flag("stdin", "Read candidates from stdin, one per line (with algo).").Bool()
+	rules       = kingpin.Flag("rules", "Rules file, applied to each dictionary word (with algo).").String()
+	combine     = kingpin.Flag("combine", "Second dictionary, each word appended to each dictionary word (with algo).").String()
+	separator   = kingpin.Flag("separator", "Text between the words of --combine.").String()
+	appendMask  = kingpin.Flag("append-mask", "Mask appended to each dictionary word (with algo).").String()
+	prependMask = kingpin.Flag("prepend-mask", "Mask prepended to each dictionary word (with algo).").String()
+	triedFile   = kingpin.Flag("tried", "Skip candidates tried before, as kept in this file, and add to it (random mode).").String()
+	triedFrom   = kingpin.Flag("tried-from", "Also skip the candidates of this tried file, such as from another machine.").Strings()
+	triedSize   = kingpin.Flag("tried-size", "Number of candidates the tried file is sized for.").Default("10000000").Uint64()
+	stateFile   = kingpin.Flag("state", "Resume from state file if it exists, save to it on ctrl-c (if not random mode).").String()
+	maxAttempts = kingpin.Flag("max-attempts", "Give up after this many tries.").Uint64()
+	maxDuration = kingpin.Flag("max-duration", "Give up after this long, such as 1h30m.").Duration()
+	maxRate     = kingpin.Flag("max-rate", "Cap the search at about this many tries per second.").Uint64()
+	nice        = kingpin.Flag("nice", "Pause each worker this long after every 1024 tries, such as 1ms.").Duration()
+	units       = kingpin.Flag("units", "Split the keyspace in this many work units, printed as json.").Int()
+	unit        = kingpin.Flag("unit", "Search only this work unit, as printed by --units.").String()
+	jobFile     = kingpin.Flag("job", "Job file in yaml or json, instead of the other flags.").String()
+	benchmark   = kingpin.Flag("benchmark", "Measure the speed for this long first, and print the projected time (if not random mode).").Duration()
 	startTime   = time.Now()
 	result      = ""
+	hasher      = gohash.NewHasher()
 )
 
 func main() {
@@ -31,6 +82,13 @@ func main() {
 	kingpin.CommandLine.HelpFlag.Short('h')
 	kingpin.Parse()
 
+	if *escapes {
+		if err := unescapeFlags(); err != nil {
+			fmt.Println("ERROR", err)
+			os.Exit(1)
+		}
+	}
+
 	rand.Seed(startTime.UTC().UnixNano())
 
 	// catch ctrl-c interrupt signal
@@ -43,15 +101,71 @@ func main() {
 			// XXX when exited, show number of tries and time ran, and tries/sec
 			fmt.Println("")
 			fmt.Println("total time: ", time.Since(startTime))
+			if *stateFile != "" && !*random {
+				if err := saveState(); err != nil {
+					fmt.Println("ERROR", err)
+					os.Exit(1)
+				}
+				fmt.Println("state saved to", *stateFile)
+			}
+			if *triedFile != "" && *random {
+				if err := saveTried(); err != nil {
+					fmt.Println("ERROR", err)
+					os.Exit(1)
+				}
+				fmt.Println("tried candidates saved to", *triedFile)
+			}
 			os.Exit(0)
 		}
 	}()
 
-	if *dictionary != "" {
-		if *algo != "" {
-			fmt.Println("ERROR dictionary and algo dont mix")
+	if *jobFile != "" {
+		runJob()
+		return
+	}
+	if *onion != "" || *onionRegexp != "" {
+		runOnion()
+		return
+	}
+
+	patterns := *hashPrefix != "" || *zeroBits != 0 || *hashRegexp != ""
+	if *hash == "" && *hashFile == "" && !patterns {
+		fmt.Println("ERROR hash, hash-file or a digest pattern must be set")
+		os.Exit(1)
+	}
+	// crypt(3) hashes, such as from /etc/shadow, include the algo
+	isCrypt := strings.HasPrefix(*hash, "$") || strings.Contains(*hash, ":$")
+	hasAlgo := *algo != "" || *template != "" || isCrypt || *pgUser != ""
+	if (*hashFile != "" || patterns) && !hasAlgo {
+		fmt.Println("ERROR hash-file and digest patterns requires algo")
+		os.Exit(1)
+	}
+
+	if *stdin {
+		if !hasAlgo {
+			fmt.Println("ERROR stdin requires algo")
 			os.Exit(1)
 		}
+
+		runWordlist()
+
+	} else if *combine != "" || *appendMask != "" || *prependMask != "" {
+		if *dictionary == "" || !hasAlgo {
+			fmt.Println("ERROR combine and masks require dictionary and algo")
+			os.Exit(1)
+		}
+		if *appendMask != "" && *prependMask != "" || *combine != "" && (*appendMask != "" || *prependMask != "") {
+			fmt.Println("ERROR combine, append-mask and prepend-mask dont mix")
+			os.Exit(1)
+		}
+
+		runWordlist()
+
+	} else if *dictionary != "" && hasAlgo {
+
+		runWordlist()
+
+	} else if *dictionary != "" {
 		if *minLength != 0 {
 			fmt.Println("ERROR dictionary and minLength dont mix")
 			os.Exit(1)
@@ -63,17 +177,23 @@ func main() {
 
 		runDictionary()
 
+	} else if stateExists() {
+
+		runHasher()
+
 	} else {
 
-		if *algo == "" {
-			fmt.Println("ERROR algo must be set")
-			os.Exit(1)
+		if !hasAlgo {
+			if err := chooseAlgo(); err != nil {
+				fmt.Println("ERROR", err)
+				os.Exit(1)
+			}
 		}
-		if *allowedKeys == "" {
-			fmt.Println("ERROR allowed must be set")
+		if *allowedKeys == "" && *allowedSet == "" && *mask == "" && len(*positions) == 0 {
+			fmt.Println("ERROR allowed, allowed-charset, mask or position must be set")
 			os.Exit(1)
 		}
-		if *minLength == 0 {
+		if *minLength == 0 && *mask == "" {
 			fmt.Println("ERROR minLength must be set")
 			os.Exit(1)
 		}
@@ -82,6 +202,71 @@ func main() {
 	}
 }
 
+// unescapeFlags decodes the escapes of the allowed keys, prefix and suffix
+func unescapeFlags() error {
+
+	for _, flag := range []*string{allowedKeys, prefix, suffix} {
+		b, err := gohash.Unescape(*flag)
+		if err != nil {
+			return err
+		}
+		*flag = string(b)
+	}
+	return nil
+}
+
+// printResult prints the key found, quoted if escapes are used as it may
+// not be printable, and how it was found
+func printResult(h *gohash.Hasher) {
+
+	if *escapes {
+		fmt.Printf("result:  %q\n", result)
+	} else {
+		fmt.Println("result: ", result)
+	}
+	if res := h.LastResult(); res != nil {
+		fmt.Println("found:  ", res)
+	}
+}
+
+// chooseAlgo sets the algo from the size of the hash, asking which one to
+// use if several algos have that size
+func chooseAlgo() error {
+
+	candidates, err := gohash.AlgosForHash(*hash)
+	if err != nil {
+		return err
+	}
+	switch len(candidates) {
+	case 0:
+		return fmt.Errorf("algo or template must be set, no algo has a hash of that size")
+	case 1:
+		*algo = candidates[0]
+		fmt.Println("using algo", *algo)
+		return nil
+	}
+
+	fmt.Println("the hash could be:")
+	for i, candidate := range candidates {
+		fmt.Printf("  %d) %s\n", i+1, candidate)
+	}
+	fmt.Print("algo: ")
+	var answer string
+	if _, err := fmt.Scanln(&answer); err != nil {
+		return fmt.Errorf("algo or template must be set")
+	}
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(candidates) {
+		answer = candidates[n-1]
+	}
+	for _, candidate := range candidates {
+		if answer == candidate {
+			*algo = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown algo %s", answer)
+}
+
 func runDictionary() {
 
 	dict, err := gohash.NewDictionary(*dictionary)
@@ -109,20 +294,425 @@ func runDictionary() {
 	}
 }
 
-func runHasher() {
+// runJob runs the search of the job file
+func runJob() {
+
+	f, err := os.Open(*jobFile)
+	if err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+	job, err := gohash.LoadJob(f)
+	f.Close()
+	if err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+
+	hasher = job.Hasher()
+	hasher.ProgressWriter(os.Stdout)
+	hasher.MaxAttempts(*maxAttempts)
+	hasher.MaxDuration(*maxDuration)
+	hasher.MaxRate(*maxRate)
+	hasher.Nice(*nice)
+
+	result, err = job.Find()
+	if err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+	printResult(hasher)
+}
+
+// runOnion generates onion keys until the address matches, and writes
+// them to onion-dir as tor uses them
+func runOnion() {
+
+	hasher.Workers(*workers)
+	hasher.ProgressWriter(os.Stdout)
+	hasher.MaxAttempts(*maxAttempts)
+	hasher.MaxDuration(*maxDuration)
+	hasher.MaxRate(*maxRate)
+	hasher.Nice(*nice)
+
+	var key *gohash.OnionKey
+	var err error
+	if *onionRegexp != "" {
+		key, err = hasher.FindOnionRegexp(*onionRegexp)
+	} else {
+		key, err = hasher.FindOnion(*onion)
+	}
+	if err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+	fmt.Println("result: ", key.Hostname())
+
+	if *onionDir == "" {
+		return
+	}
+	if err := os.MkdirAll(*onionDir, 0700); err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+	files := map[string][]byte{
+		"hostname":              []byte(key.Hostname() + "\n"),
+		"hs_ed25519_secret_key": key.SecretKeyFile(),
+		"hs_ed25519_public_key": key.PublicKeyFile(),
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(*onionDir, name), data, 0600); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+	}
+	fmt.Println("keys written to", *onionDir)
+}
+
+// runWordlist tries each line of the dictionary, or of stdin
+func runWordlist() {
+
+	var r io.Reader = os.Stdin
+	if !*stdin {
+		f, err := os.Open(*dictionary)
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		defer f.Close()
+		r = f
+	}
 
 	hasher := gohash.NewHasher()
 	hasher.Algo(*algo)
-	hasher.AllowedKeys(*allowedKeys)
+	hasher.Template(*template)
+	hasher.Salt(*salt)
+	hasher.Iterations(*iterations)
+	hasher.IterateHex(*iterateHex)
+	setHMAC(hasher)
+	if *pgUser != "" {
+		hasher.PostgresMD5(*pgUser)
+	}
 	hasher.Prefix(*prefix)
 	hasher.Suffix(*suffix)
-	hasher.ExpectedHash(*hash)
-	hasher.Length(*minLength)
-	hasher.Reverse(*reverse)
+	if err := setExpected(hasher); err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+
+	if *rules != "" {
+		rf, err := os.Open(*rules)
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		defer rf.Close()
+		parsed, err := gohash.ParseRules(rf)
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		hasher.Rules(parsed)
+	}
+
+	hasher.Workers(*workers)
+	hasher.MaxAttempts(*maxAttempts)
+	hasher.MaxDuration(*maxDuration)
+	hasher.MaxRate(*maxRate)
+	hasher.Nice(*nice)
+
+	var err error
+	if *stdin {
+		result, err = hasher.FindFromReader(r)
+	} else if *combine != "" {
+		cf, openErr := os.Open(*combine)
+		if openErr != nil {
+			fmt.Println("ERROR", openErr)
+			return
+		}
+		defer cf.Close()
+		hasher.Separator(*separator)
+		result, err = hasher.FindCombinator(r, cf)
+	} else if *appendMask != "" || *prependMask != "" {
+		for i, keys := range *charsets {
+			hasher.CustomCharset(i+1, keys)
+		}
+		result, err = hasher.FindHybrid(r, *appendMask+*prependMask, *prependMask != "")
+	} else {
+		result, err = hasher.FindFromWordlist(r)
+	}
+	if err != nil {
+		fmt.Println("ERROR", err)
+		return
+	}
+
+	printResult(hasher)
+}
+
+// setExpected sets the hash or digest pattern, or the hashes read from
+// hash-file, printing each match found
+func setExpected(h *gohash.Hasher) error {
+
+	h.DigestPrefix(*hashPrefix)
+	h.LeadingZeroBits(*zeroBits)
+	h.DigestRegexp(*hashRegexp)
+	h.DigestEncoding(*hashEncode)
+	if err := setAlso(h); err != nil {
+		return err
+	}
+	if *hashFile == "" {
+		h.ExpectedHash(*hash)
+		return nil
+	}
+
+	f, err := os.Open(*hashFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h.OnMatch(func(key, hash string) {
+		fmt.Println("match: ", hash, key)
+	})
+	return h.LoadExpectedHashes(f)
+}
+
+// setAlso reads the hashes of each "algo:file" of --also
+func setAlso(h *gohash.Hasher) error {
+
+	for _, s := range *alsoFiles {
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("also %q should be algo:file", s)
+		}
+		f, err := os.Open(parts[1])
+		if err != nil {
+			return err
+		}
+		err = h.LoadAlsoExpect(parts[0], f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if len(*alsoFiles) != 0 {
+		h.OnMatch(func(key, hash string) {
+			fmt.Println("match: ", hash, key)
+		})
+	}
+	return nil
+}
+
+// setPositions sets the keys of each "pos=keys" position
+func setPositions(h *gohash.Hasher) error {
+
+	for _, s := range *positions {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("position %q should be pos=keys", s)
+		}
+		pos, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return fmt.Errorf("position %q should be pos=keys", s)
+		}
+		h.PositionKeys(pos, parts[1])
+	}
+	return nil
+}
+
+func trainMarkov(h *gohash.Hasher) error {
+
+	if *markov == "" {
+		return nil
+	}
+	f, err := os.Open(*markov)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return h.TrainMarkov(f)
+}
+
+func setHMAC(h *gohash.Hasher) {
+
+	if *hmacMessage != "" {
+		h.HMACMessage(*hmacMessage)
+	}
+	if *hmacKey != "" {
+		h.HMACKey(*hmacKey)
+	}
+}
+
+// loadTried adds the candidates of the tried file, if it exists, and of
+// the tried-from files to a filter of tried-size
+func loadTried() error {
+
+	hasher.TriedFilter(*triedSize)
+	for _, name := range append([]string{*triedFile}, *triedFrom...) {
+		f, err := os.Open(name)
+		if os.IsNotExist(err) && name == *triedFile {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = hasher.LoadTried(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// saveTried writes the candidates tried to the tried file
+func saveTried() error {
+
+	f, err := os.Create(*triedFile)
+	if err != nil {
+		return err
+	}
+	if err := hasher.SaveTried(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func stateExists() bool {
+
+	if *stateFile == "" {
+		return false
+	}
+	_, err := os.Stat(*stateFile)
+	return err == nil
+}
+
+func saveState() error {
+
+	f, err := os.Create(*stateFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return hasher.SaveState(f)
+}
+
+func loadState() error {
+
+	f, err := os.Open(*stateFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return hasher.LoadState(f)
+}
+
+func runHasher() {
+
+	if stateExists() {
+		if err := loadState(); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+	} else {
+		hasher.Algo(*algo)
+		hasher.Template(*template)
+		hasher.Salt(*salt)
+		hasher.Iterations(*iterations)
+		hasher.IterateHex(*iterateHex)
+		setHMAC(hasher)
+		if *pgUser != "" {
+			hasher.PostgresMD5(*pgUser)
+		}
+		hasher.AllowedKeys(*allowedKeys)
+		if *allowedSet != "" {
+			if err := hasher.AllowedCharset(*allowedSet); err != nil {
+				fmt.Println("ERROR", err)
+				return
+			}
+		}
+		hasher.Mask(*mask)
+		for i, keys := range *charsets {
+			hasher.CustomCharset(i+1, keys)
+		}
+		if err := setPositions(hasher); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		if err := trainMarkov(hasher); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		hasher.Prefix(*prefix)
+		hasher.Suffix(*suffix)
+		if err := setExpected(hasher); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		hasher.MinLength(*minLength)
+		hasher.MaxLength(*maxLength)
+		hasher.Reverse(*reverse)
+		hasher.Workers(*workers)
+	}
+	hasher.ProgressWriter(os.Stdout)
+	hasher.MaxAttempts(*maxAttempts)
+	hasher.MaxDuration(*maxDuration)
+	hasher.MaxRate(*maxRate)
+	hasher.Nice(*nice)
+
+	if *units > 0 {
+		res, err := hasher.WorkUnits(*units)
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		for _, u := range res {
+			b, _ := json.Marshal(u)
+			fmt.Println(string(b))
+		}
+		return
+	}
+
+	if *benchmark > 0 && !*random {
+		res, err := hasher.Benchmark(*benchmark)
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		fmt.Println("benchmark:", res)
+	}
+
+	if *all && !*random {
+		res, err := hasher.FindAll()
+		for _, key := range res {
+			fmt.Println("result: ", key)
+		}
+		if err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		fmt.Println(len(res), "matches")
+		return
+	}
 
 	var err error
 	if *random {
+		if *triedFile != "" {
+			if err := loadTried(); err != nil {
+				fmt.Println("ERROR", err)
+				return
+			}
+			defer saveTried()
+		}
 		result, err = hasher.FindRandom()
+	} else if *unit != "" {
+		var u gohash.WorkUnit
+		if err := json.Unmarshal([]byte(*unit), &u); err != nil {
+			fmt.Println("ERROR", err)
+			return
+		}
+		result, err = hasher.FindWorkUnit(u)
 	} else {
 		result, err = hasher.FindSequential()
 	}
@@ -132,5 +722,5 @@ func runHasher() {
 		return
 	}
 
-	fmt.Println("result: ", result)
+	printResult(hasher)
 }
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/aybabtme/color/brush"
 	"github.com/martinlindhe/gohash"
@@ -10,14 +15,38 @@ import (
 )
 
 var (
-	fileName      = kingpin.Flag("file", "Input file to read.").Short('i').String()
+	fileName      = kingpin.Flag("file", "Input file, http(s), s3 or gs url to read.").Short('i').String()
 	algo          = kingpin.Arg("algo", "Hash algorithm to use.").String()
+	files         = kingpin.Arg("files", "Files, glob patterns such as *.iso or urls to hash, instead of --file.").Strings()
 	listAlgos     = kingpin.Flag("list-algos", "List available hash algorithms.").Short('A').Bool()
 	encoding      = kingpin.Flag("encoding", "Output encoding. Default is hex.").Short('e').String()
 	listEncodings = kingpin.Flag("list-encodings", "List available encodings.").Short('E').Bool()
 	skipNewline   = kingpin.Flag("skip-newline", "Don't output newline.").Short('n').Bool()
 	skipFilename  = kingpin.Flag("skip-filename", "Don't output filename.").Bool()
 	disableColor  = kingpin.Flag("disable-color", "Disable color output.").Bool()
+	openssl       = kingpin.Flag("openssl", "Output in `openssl dgst` format.").Bool()
+	check         = kingpin.Flag("check", "Check the files of a md5sum, sha256sum, bsd tag or .sfv manifest, - for stdin.").Short('c').String()
+	manifest      = kingpin.Flag("manifest", "Output in md5sum and sha256sum format.").Bool()
+	tag           = kingpin.Flag("tag", "Output in bsd tag format, SHA256 (file) = <hex>.").Bool()
+	sfv           = kingpin.Flag("sfv", "Output in .sfv format, with crc32.").Bool()
+	binaryMode    = kingpin.Flag("binary", "Mark files as read in binary mode in --manifest output.").Short('b').Bool()
+	jsonOutput    = kingpin.Flag("json", "Output result as JSON.").Bool()
+	csvOutput     = kingpin.Flag("csv", "Output result as CSV.").Bool()
+	connTimeout   = kingpin.Flag("connect-timeout", "Max time to connect to an url and get the response headers.").Default("30s").Duration()
+	timeout       = kingpin.Flag("timeout", "Max time for the whole download of an url, 0 for no limit.").Default("0").Duration()
+	maxRedirects  = kingpin.Flag("max-redirects", "Max redirects to follow when downloading an url.").Default("10").Int()
+	maxSize       = kingpin.Flag("max-size", "Max size in bytes of an url, 0 for no limit.").Int64()
+	recursive     = kingpin.Flag("recursive", "Hash the files below directories.").Short('r').Bool()
+	include       = kingpin.Flag("include", "Only hash files matching this glob pattern when recursive.").Strings()
+	exclude       = kingpin.Flag("exclude", "Skip files and directories matching this glob pattern when recursive.").Strings()
+	symlinks      = kingpin.Flag("symlinks", "Symlinks when recursive: skip, files or follow.").Default(gohash.SymlinksFiles).String()
+	hidden        = kingpin.Flag("hidden", "Hash files and directories starting with . when recursive.").Bool()
+	byteRanges    = kingpin.Flag("range", "Only hash these bytes of the input, as offset:length, such as 0:512,0x8000:.").String()
+	text          = kingpin.Flag("text", "Text mode, strip a byte order mark and hash CRLF as LF.").Short('t').Bool()
+	maxMemory     = kingpin.Flag("max-memory", "Max bytes of input kept in memory, larger inputs are streamed.").Default("268435456").Int64()
+	progress      = kingpin.Flag("progress", "Print the bytes read and rate to stderr while reading the input.").Bool()
+	files0From    = kingpin.Flag("files0-from", "Hash the NUL separated file names read from this file, - for stdin, as of find -print0.").String()
+	archives      = kingpin.Flag("archives", "Hash each file inside tar, tar.gz and zip archives instead of the archive.").Bool()
 )
 
 func main() {
@@ -36,30 +65,105 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *check != "" {
+		os.Exit(checkManifest())
+	}
+
+	if *sfv {
+		if *algo == "" {
+			*algo = "crc32"
+		}
+		if *algo != "crc32" && *algo != "crc32-ieee" {
+			fmt.Println("error: --sfv needs the crc32 algo, not", *algo)
+			os.Exit(1)
+		}
+	}
+
 	if *algo == "" {
 		fmt.Println("error: required algorithm not provided, try --help")
 		os.Exit(1)
 	}
 
-	appInputData, err := gohash.ReadPipeOrFile(*fileName)
+	if *progress {
+		gohash.InputProgress = printProgress
+	}
+	gohash.MaxInputMemory = *maxMemory
+
+	if len(*files) != 0 || *files0From != "" {
+		os.Exit(hashFiles())
+	}
+
+	res, isPipe, err := calculate()
+	if *progress {
+		fmt.Fprintln(os.Stderr)
+	}
 	if err != nil {
 		fmt.Println("error:", err)
 		os.Exit(1)
 	}
 
-	calc := gohash.NewCalculator(appInputData.Data)
+	if *jsonOutput || *csvOutput {
+		if *jsonOutput {
+			err = json.NewEncoder(os.Stdout).Encode(res)
+		} else {
+			err = gohash.WriteResultsCSV(os.Stdout, []gohash.Result{*res})
+		}
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
-	hash := calc.Sum(*algo)
-	if hash == nil {
-		fmt.Println("error: unknown algorithm", *algo)
+	if *manifest || *tag || *sfv {
+		if isPipe {
+			*fileName = "-"
+		}
+		line := gohash.FormatCoreutils(*fileName, res.Digest, *binaryMode)
+		if *tag {
+			line, err = gohash.FormatBSD(*algo, *fileName, res.Digest)
+		}
+		if *sfv {
+			line = gohash.FormatSFV(*fileName, res.Digest)
+		}
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		fmt.Println(line)
+		os.Exit(0)
+	}
+
+	if *openssl {
+		name := *fileName
+		if isPipe {
+			name = "stdin"
+		}
+		line, err := gohash.FormatOpenSSL(*algo, name, res.Digest)
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		fmt.Println(line)
+		os.Exit(0)
+	}
+
+	if isPipe {
+		*fileName = "-"
+	}
+	if err := printResult(res.Digest, *fileName); err != nil {
+		fmt.Println("error", err)
 		os.Exit(1)
 	}
+}
+
+// printResult prints the encoded digest and name of an input
+func printResult(digest []byte, name string) error {
 
 	coder := gohash.NewCoder(*encoding)
-	encodedHash, err := coder.Encode(*hash)
+	encodedHash, err := coder.Encode(digest)
 	if err != nil {
-		fmt.Println("error", err)
-		os.Exit(1)
+		return err
 	}
 
 	if *disableColor {
@@ -69,16 +173,295 @@ func main() {
 	}
 
 	if !*skipFilename {
-		if appInputData.IsPipe {
-			*fileName = "-"
-		}
 		if *disableColor {
-			fmt.Printf("  %s", *fileName)
+			fmt.Printf("  %s", name)
 		} else {
-			fmt.Printf("  %s", brush.White(*fileName))
+			fmt.Printf("  %s", brush.White(name))
 		}
 	}
 	if !*skipNewline {
 		fmt.Println()
 	}
+	return nil
+}
+
+// hashFiles hashes each of the files, printing a result or error for
+// each, and returns the exit code
+func hashFiles() int {
+
+	fetcher := gohash.NewFetcher()
+	fetcher.ConnectTimeout(*connTimeout)
+	fetcher.Timeout(*timeout)
+	fetcher.MaxRedirects(*maxRedirects)
+	fetcher.MaxSize(*maxSize)
+
+	walker := gohash.NewWalker()
+	walker.Include(*include...)
+	walker.Exclude(*exclude...)
+	walker.Symlinks(*symlinks)
+	walker.Hidden(*hidden)
+
+	inputs := []gohash.Input{}
+	for _, pattern := range *files {
+		if gohash.IsURL(pattern) {
+			inputs = append(inputs, fetcher.Input(pattern))
+			continue
+		}
+		var matched []gohash.Input
+		var err error
+		if fi, statErr := os.Stat(pattern); *recursive && statErr == nil && fi.IsDir() {
+			matched, err = walker.Walk(pattern)
+		} else {
+			matched, err = gohash.ReadInputs([]string{pattern})
+		}
+		if err != nil {
+			fmt.Println("error:", err)
+			return 1
+		}
+		inputs = append(inputs, matched...)
+	}
+	if *files0From != "" {
+		listed, err := readFiles0()
+		if err != nil {
+			fmt.Println("error:", err)
+			return 1
+		}
+		inputs = append(inputs, listed...)
+	}
+
+	code := 0
+	results := []gohash.Result{}
+	output := func(res *gohash.Result) error {
+		switch {
+		case *jsonOutput:
+			return json.NewEncoder(os.Stdout).Encode(res)
+		case *csvOutput:
+			results = append(results, *res)
+			return nil
+		case *openssl:
+			line, err := gohash.FormatOpenSSL(*algo, res.Name, res.Digest)
+			if err == nil {
+				fmt.Println(line)
+			}
+			return err
+		case *tag:
+			line, err := gohash.FormatBSD(*algo, res.Name, res.Digest)
+			if err == nil {
+				fmt.Println(line)
+			}
+			return err
+		case *manifest:
+			fmt.Println(gohash.FormatCoreutils(res.Name, res.Digest, *binaryMode))
+			return nil
+		case *sfv:
+			fmt.Println(gohash.FormatSFV(res.Name, res.Digest))
+			return nil
+		}
+		return printResult(res.Digest, res.Name)
+	}
+
+	// archives are hashed member by member, the other inputs by a pool
+	plain := []gohash.Input{}
+	for _, in := range inputs {
+		if !*archives || !gohash.IsArchive(in.Name) {
+			plain = append(plain, in)
+		}
+	}
+	ranges, err := parseRanges()
+	if err != nil {
+		fmt.Println("error:", err)
+		return 1
+	}
+	for i := range plain {
+		if ranges != nil {
+			plain[i] = gohash.RangeInput(plain[i], ranges)
+		}
+		if *text {
+			plain[i] = gohash.TextInput(plain[i])
+		}
+	}
+	hashed := gohash.HashInputs(plain, *algo, *encoding, runtime.NumCPU())
+	for _, in := range inputs {
+		var members []gohash.Result
+		var err error
+		if *archives && gohash.IsArchive(in.Name) {
+			members, err = gohash.HashArchive(in.Name, *algo, *encoding)
+		} else {
+			res := hashed[0]
+			hashed = hashed[1:]
+			if res.Result != nil {
+				members = []gohash.Result{*res.Result}
+			}
+			if res.Err != nil {
+				err = fmt.Errorf("%s: %v", in.Name, res.Err)
+			}
+		}
+		for i := range members {
+			if err := output(&members[i]); err != nil {
+				fmt.Println("error:", err)
+				return 1
+			}
+		}
+		if err != nil {
+			fmt.Println("error:", err)
+			code = 1
+		}
+	}
+	if *csvOutput {
+		if err := gohash.WriteResultsCSV(os.Stdout, results); err != nil {
+			fmt.Println("error:", err)
+			return 1
+		}
+	}
+	return code
+}
+
+// readFiles0 returns the inputs of the file list of --files0-from
+func readFiles0() ([]gohash.Input, error) {
+
+	if *files0From == "-" {
+		return gohash.ReadFiles0(os.Stdin)
+	}
+	f, err := os.Open(*files0From)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return gohash.ReadFiles0(f)
+}
+
+// printProgress prints the bytes of the input read so far to stderr
+func printProgress(read int64, rate float64) {
+	fmt.Fprintf(os.Stderr, "\r%d bytes read, %.1f MB/s ", read, rate/1e6)
+}
+
+// checkManifest checks the files of the --check manifest, printing the
+// status of each, and returns the exit code
+func checkManifest() int {
+
+	var r io.Reader = os.Stdin
+	if *check != "-" {
+		f, err := os.Open(*check)
+		if err != nil {
+			fmt.Println("error:", err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var report *gohash.CheckReport
+	var err error
+	if strings.EqualFold(filepath.Ext(*check), ".sfv") {
+		report, err = gohash.CheckSFV(r)
+	} else {
+		report, err = gohash.CheckManifest(r, *algo)
+	}
+	if err != nil {
+		fmt.Println("error:", err)
+		return 1
+	}
+	for _, res := range report.Results {
+		if res.Status == gohash.CheckFailed && res.Err != nil {
+			fmt.Printf("%s: %s open or read\n", res.Name, res.Status)
+			continue
+		}
+		fmt.Printf("%s: %s\n", res.Name, res.Status)
+	}
+	warn := func(n int, one, many string) {
+		if n == 1 {
+			fmt.Fprintln(os.Stderr, "WARNING: 1", one)
+		} else if n > 1 {
+			fmt.Fprintln(os.Stderr, "WARNING:", n, many)
+		}
+	}
+	warn(report.Malformed, "line is improperly formatted", "lines are improperly formatted")
+	warn(report.Missing, "listed file is missing", "listed files are missing")
+	warn(report.Failed, "computed checksum did NOT match", "computed checksums did NOT match")
+	if report.OK+report.Failed+report.Missing == 0 {
+		fmt.Fprintln(os.Stderr, "error: no properly formatted checksum lines found")
+	}
+	if !report.Passed() {
+		return 1
+	}
+	return 0
+}
+
+// parseRanges returns the ranges of --range, or nil if it is unset
+func parseRanges() ([]gohash.ByteRange, error) {
+
+	if *byteRanges == "" {
+		return nil, nil
+	}
+	return gohash.ParseByteRanges(*byteRanges)
+}
+
+// readerResult hashes the ranges of r, in text mode if set
+func readerResult(name string, r io.Reader) (*gohash.Result, error) {
+
+	ranges, err := parseRanges()
+	if err != nil {
+		return nil, err
+	}
+	if ranges != nil {
+		if r, err = gohash.NewRangeReader(r, ranges); err != nil {
+			return nil, err
+		}
+	}
+	if *text {
+		r = gohash.NewTextReader(r)
+	}
+	return gohash.ReaderResult(*algo, name, *encoding, r)
+}
+
+// calculate hashes the input, streaming urls as they are downloaded.
+// Returns true if the input was piped
+func calculate() (*gohash.Result, bool, error) {
+
+	input, remote := gohash.SchemeInput(*fileName)
+	if !remote && gohash.IsURL(*fileName) {
+		fetcher := gohash.NewFetcher()
+		fetcher.ConnectTimeout(*connTimeout)
+		fetcher.Timeout(*timeout)
+		fetcher.MaxRedirects(*maxRedirects)
+		fetcher.MaxSize(*maxSize)
+		input, remote = fetcher.Input(*fileName), true
+	}
+	if remote {
+		r, err := input.Open()
+		if err != nil {
+			return nil, false, err
+		}
+		defer r.Close()
+		var in io.Reader = r
+		if *progress {
+			in = gohash.NewCountingReader(r, printProgress)
+		}
+		res, err := readerResult(*fileName, in)
+		return res, false, err
+	}
+
+	appInputData, err := gohash.ReadPipeOrFile(*fileName)
+	if err != nil {
+		return nil, false, err
+	}
+	defer appInputData.Close()
+	name := *fileName
+	if appInputData.IsPipe {
+		name = "-"
+	}
+	if appInputData.IsStreamed() || *byteRanges != "" {
+		r, err := appInputData.Reader()
+		if err != nil {
+			return nil, appInputData.IsPipe, err
+		}
+		res, err := readerResult(name, r)
+		return res, appInputData.IsPipe, err
+	}
+	data := appInputData.Data
+	if *text {
+		data = gohash.NormalizeText(data)
+	}
+	res, err := gohash.NewCalculator(data).Result(*algo, name, *encoding)
+	return res, appInputData.IsPipe, err
 }
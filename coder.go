@@ -1,11 +1,14 @@
 package gohash
 
 import (
+	"bytes"
 	"encoding/ascii85"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
+	"mime/quotedprintable"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,7 +16,6 @@ import (
 
 	"github.com/bproctor/base91"
 	b58 "github.com/jbenet/go-base58"
-	"github.com/martinlindhe/base36"
 	"github.com/martinlindhe/bubblebabble"
 	"github.com/tejainece/uu"
 	"github.com/tilinna/z85"
@@ -21,72 +23,380 @@ import (
 
 // Coder is used to encode and decode various binary-to-text encodings
 type Coder struct {
-	encoding string
+	encoding   string
+	strict     bool
+	hrp        string
+	version    byte
+	wordlist   string
+	width      int
+	adobe      bool
+	btoa       bool
+	padCount   bool
+	alphabet   []rune
+	stages     []string
+	wrap       int
+	crlf       bool
+	ignoreCase bool
+	constTime  bool
 }
 
 var (
 	separator = " "
-	encoders  = map[string]func([]byte) ([]byte, error){
+
+	pipelineSeparator = "|"
+
+	// encodings that can be line wrapped, ignoring white space on decode
+	wrappableEncodings = map[string]bool{
+		"base32":    true,
+		"base32hex": true,
+		"base64":    true,
+	}
+
+	// ascii85 framing used by Adobe, and the btoa shortcut for 4 spaces
+	ascii85Start         = []byte("<~")
+	ascii85End           = []byte("~>")
+	ascii85Spaces        = []byte("    ")
+	ascii85SpacesEncoded = []byte("+<VdL")
+
+	zbase32Encoding = base32.NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(base32.NoPadding)
+
+	encoders = map[string]func([]byte) ([]byte, error){
+		"base100":         encodeBase100,
+		"base122":         encodeBase122,
+		"base2048":        encodeBase2048,
+		"base32":          encodeBase32,
+		"base32hex":       encodeBase32Hex,
+		"base36":          encodeBase36,
+		"base45":          encodeBase45,
+		"base58":          encodeBase58,
+		"base64":          encodeBase64,
+		"base65536":       encodeBase65536,
+		"base91":          encodeBase91,
+		"binary":          encodeBinary,
+		"bubblebabble":    encodeBubbleBabble,
+		"crockford":       encodeCrockford,
+		"crockford-chk":   encodeCrockfordCheck,
+		"decimal":         encodeDecimal,
+		"fingerprint":     encodeFingerprint,
+		"hex":             encodeHex,
+		"hex-colons":      encodeHexColons,
+		"hexdump":         encodeHexdump,
+		"hexup":           encodeHexUpper,
+		"ihex":            encodeIntelHex,
+		"morse":           encodeMorse,
+		"octal":           encodeOctal,
+		"percent":         encodePercent,
+		"pgpwords":        encodePGPWords,
+		"proquint":        encodeProquint,
+		"quotedprintable": encodeQuotedPrintable,
+		"rfc1924":         encodeRFC1924,
+		"srec":            encodeSRecord,
+		"uu":              encodeUU,
+		"uuencode":        encodeUUEncode,
+		"xxencode":        encodeXXEncode,
+		"zbase32":         encodeZBase32,
+	}
+
+	decoders = map[string]func([]byte) ([]byte, error){
+		"base100":         decodeBase100,
+		"base122":         decodeBase122,
+		"base2048":        decodeBase2048,
+		"base32":          decodeBase32,
+		"base32hex":       decodeBase32Hex,
+		"base36":          decodeBase36,
+		"base45":          decodeBase45,
+		"base58":          decodeBase58,
+		"base64":          decodeBase64,
+		"base65536":       decodeBase65536,
+		"base91":          decodeBase91,
+		"binary":          decodeBinary,
+		"bubblebabble":    decodeBubbleBabble,
+		"crockford":       decodeCrockford,
+		"crockford-chk":   decodeCrockfordCheck,
+		"decimal":         decodeDecimal,
+		"fingerprint":     decodeHexColons,
+		"hex":             decodeHex,
+		"hex-colons":      decodeHexColons,
+		"hexdump":         decodeHexdump,
+		"hexup":           decodeHex,
+		"ihex":            decodeIntelHex,
+		"morse":           decodeMorse,
+		"octal":           decodeOctal,
+		"percent":         decodePercent,
+		"pgpwords":        decodePGPWords,
+		"proquint":        decodeProquint,
+		"quotedprintable": decodeQuotedPrintable,
+		"rfc1924":         decodeRFC1924,
+		"srec":            decodeSRecord,
+		"uu":              decodeUU,
+		"uuencode":        decodeUUEncode,
+		"xxencode":        decodeXXEncode,
+		"zbase32":         decodeZBase32,
+	}
+
+	// codecs depending on Coder settings
+	configurableEncoders = map[string]func(*Coder, []byte) ([]byte, error){
 		"ascii85":      encodeASCII85,
-		"base32":       encodeBase32,
-		"base36":       encodeBase36,
-		"base58":       encodeBase58,
-		"base64":       encodeBase64,
-		"base91":       encodeBase91,
-		"bubblebabble": encodeBubbleBabble,
-		"binary":       encodeBinary,
-		"decimal":      encodeDecimal,
-		"hex":          encodeHex,
-		"hexup":        encodeHexUpper,
-		"octal":        encodeOctal,
-		"uu":           encodeUU,
+		"base58check":  encodeBase58Check,
+		"bip39":        encodeBIP39,
+		"c-array":      encodeCArray,
+		"go-bytes":     encodeGoBytes,
+		"python-bytes": encodePythonBytes,
 		"z85":          encodeZ85,
+		"bech32":       encodeBech32,
+		"bech32m":      encodeBech32m,
 	}
 
-	decoders = map[string]func([]byte) ([]byte, error){
+	configurableDecoders = map[string]func(*Coder, []byte) ([]byte, error){
 		"ascii85":      decodeASCII85,
-		"base32":       decodeBase32,
-		"base36":       decodeBase36,
-		"base58":       decodeBase58,
-		"base64":       decodeBase64,
-		"base91":       decodeBase91,
-		"binary":       decodeBinary,
-		"bubblebabble": decodeBubbleBabble,
-		"decimal":      decodeDecimal,
-		"hex":          decodeHex,
-		"hexup":        decodeHex,
-		"octal":        decodeOctal,
-		"uu":           decodeUU,
+		"base58check":  decodeBase58Check,
+		"bip39":        decodeBIP39,
+		"c-array":      decodeByteList,
+		"go-bytes":     decodeByteList,
+		"python-bytes": decodePythonBytes,
 		"z85":          decodeZ85,
+		"bech32":       decodeBech32,
+		"bech32m":      decodeBech32m,
+	}
+
+	// decoders used instead of the default ones in strict mode
+	strictDecoders = map[string]func([]byte) ([]byte, error){
+		"base32":    decodeBase32Strict,
+		"base32hex": decodeBase32HexStrict,
+		"base64":    decodeBase64Strict,
+		"binary":    decodeBinaryStrict,
+		"decimal":   decodeDecimalStrict,
+		"hex":       decodeHexStrict,
+		"hexup":     decodeHexStrict,
+		"octal":     decodeOctalStrict,
+		"percent":   decodePercentStrict,
+	}
+
+	// decoders used in constant time mode
+	constantTimeDecoders = map[string]func([]byte) ([]byte, error){
+		"base64": decodeBase64ConstantTime,
+		"hex":    decodeHexConstantTime,
+		"hexup":  decodeHexConstantTime,
+	}
+
+	// case conversions to the alphabet of case sensitive encodings
+	caseFolders = map[string]func([]byte) []byte{
+		"base32":    bytes.ToUpper,
+		"base32hex": bytes.ToUpper,
+		"hex":       bytes.ToUpper,
+		"hexup":     bytes.ToUpper,
+		"zbase32":   bytes.ToLower,
+	}
+
+	radixNames = map[int]string{
+		2:  "binary",
+		8:  "octal",
+		10: "decimal",
 	}
 )
 
-// NewCoder creates a new Coder
+// NewCoder creates a new Coder. A pipeline of encodings such as
+// "hex|base64" is applied left to right when encoding, and right to left
+// when decoding
 func NewCoder(encoding string) *Coder {
 
+	if strings.Contains(encoding, pipelineSeparator) {
+		stages := strings.Split(encoding, pipelineSeparator)
+		for i, stage := range stages {
+			stages[i] = resolveEncodingAliases(strings.TrimSpace(stage))
+		}
+		return &Coder{
+			encoding: strings.Join(stages, pipelineSeparator),
+			stages:   stages,
+		}
+	}
+
 	return &Coder{
 		encoding: resolveEncodingAliases(encoding),
 	}
 }
 
+// Strict sets wether to reject malformed input the decoder would otherwise
+// tolerate, such as white space, missing padding and mixed case hex
+func (c *Coder) Strict(b bool) {
+	c.strict = b
+}
+
+// HRP sets the human-readable part used by bech32 encodings
+func (c *Coder) HRP(hrp string) {
+	c.hrp = hrp
+}
+
+// Version sets the version byte used by base58check
+func (c *Coder) Version(v byte) {
+	c.version = v
+}
+
+// Wordlist sets the wordlist used by bip39, see BIP39Wordlists
+func (c *Coder) Wordlist(name string) {
+	c.wordlist = strings.ToLower(name)
+}
+
+// Width sets the number of bytes per line for source code literals,
+// 0 disables wrapping
+func (c *Coder) Width(n int) {
+	c.width = n
+}
+
+// AdobeFraming sets wether ascii85 is wrapped in <~ and ~>. When decoding,
+// the ~> end marker is then required
+func (c *Coder) AdobeFraming(b bool) {
+	c.adobe = b
+}
+
+// Btoa sets wether ascii85 uses the btoa 'y' shortcut for four spaces
+func (c *Coder) Btoa(b bool) {
+	c.btoa = b
+}
+
+// PadCount sets wether z85 appends the number of padding bytes, so data
+// ending in zero bytes survives a round-trip
+func (c *Coder) PadCount(b bool) {
+	c.padCount = b
+}
+
+// Wrap sets the line length of base32 and base64 output, 0 disables
+// wrapping. MIME uses 76 and PEM 64
+func (c *Coder) Wrap(columns int) {
+	c.wrap = columns
+}
+
+// CRLF sets wether wrapped lines end with CRLF instead of LF
+func (c *Coder) CRLF(b bool) {
+	c.crlf = b
+}
+
+// IgnoreCase sets wether base32 and hex decoding accepts input in any case,
+// also in strict mode
+func (c *Coder) IgnoreCase(b bool) {
+	c.ignoreCase = b
+}
+
+// ConstantTime sets wether hex and base64 are decoded in constant time, so
+// decoding key material does not leak it through timing. Only input
+// without white space is accepted, and errors do not tell where the input
+// is invalid
+func (c *Coder) ConstantTime(b bool) {
+	c.constTime = b
+}
+
+func (c *Coder) bip39Wordlist() string {
+	if c.wordlist == "" {
+		return defaultBIP39Wordlist
+	}
+	return c.wordlist
+}
+
+func (c *Coder) bech32HRP() string {
+	if c.hrp == "" {
+		return defaultBech32HRP
+	}
+	return c.hrp
+}
+
 // Encode encodes src into some encoding
 func (c *Coder) Encode(src []byte) ([]byte, error) {
 
+	if c.stages != nil {
+		var err error
+		for _, stage := range c.stages {
+			if src, err = c.stage(stage).Encode(src); err != nil {
+				return nil, err
+			}
+		}
+		return src, nil
+	}
+	if c.alphabet != nil {
+		return encodeAlphabet(c, src)
+	}
+	if coder, ok := configurableEncoders[c.encoding]; ok {
+		return coder(c, src)
+	}
 	if coder, ok := encoders[c.encoding]; ok {
-		return coder(src)
+		res, err := coder(src)
+		if err == nil && c.wrap > 0 && wrappableEncodings[c.encoding] {
+			res = c.wrapLines(res)
+		}
+		return res, err
 	}
 	return nil, fmt.Errorf("unknown encoding: %s", c.encoding)
 }
 
+// wrapLines breaks src into lines of c.wrap columns
+func (c *Coder) wrapLines(src []byte) []byte {
+
+	eol := "\n"
+	if c.crlf {
+		eol = "\r\n"
+	}
+
+	var buf bytes.Buffer
+	for len(src) > c.wrap {
+		buf.Write(src[:c.wrap])
+		buf.WriteString(eol)
+		src = src[c.wrap:]
+	}
+	buf.Write(src)
+	return buf.Bytes()
+}
+
 // Decode decodes src from some encoding
 func (c *Coder) Decode(src []byte) ([]byte, error) {
 
+	if c.stages != nil {
+		var err error
+		for i := len(c.stages) - 1; i >= 0; i-- {
+			if src, err = c.stage(c.stages[i]).Decode(src); err != nil {
+				return nil, err
+			}
+		}
+		return src, nil
+	}
+	if c.alphabet != nil {
+		return decodeAlphabet(c, src)
+	}
+	if coder, ok := constantTimeDecoders[c.encoding]; ok && c.constTime {
+		return coder(src)
+	}
+	if fold, ok := caseFolders[c.encoding]; ok && c.ignoreCase {
+		src = fold(src)
+	}
+	res, err := c.decode(src)
+	if err != nil {
+		return nil, locateDecodeError(c.encoding, src, err)
+	}
+	return res, nil
+}
+
+func (c *Coder) decode(src []byte) ([]byte, error) {
+
+	if coder, ok := strictDecoders[c.encoding]; ok && c.strict {
+		return coder(src)
+	}
+	if coder, ok := configurableDecoders[c.encoding]; ok {
+		return coder(c, src)
+	}
 	if coder, ok := decoders[c.encoding]; ok {
 		return coder(src)
 	}
 	return nil, fmt.Errorf("unknown encoding: %s", c.encoding)
 }
 
+// stage returns a copy of c for one encoding of a pipeline, sharing its
+// settings
+func (c *Coder) stage(encoding string) *Coder {
+
+	res := *c
+	res.encoding = encoding
+	res.stages = nil
+	return &res
+}
+
 // AvailableEncodings returns the available encoding id's
 func AvailableEncodings() []string {
 
@@ -95,6 +405,9 @@ func AvailableEncodings() []string {
 	for key := range encoders {
 		res = append(res, key)
 	}
+	for key := range configurableEncoders {
+		res = append(res, key)
+	}
 
 	sort.Strings(res)
 	return res
@@ -121,15 +434,63 @@ func RecodeInput(encodings []string, data []byte, decode bool) ([]byte, error) {
 	return data, err
 }
 
-func encodeASCII85(src []byte) ([]byte, error) {
-	buf := make([]byte, ascii85.MaxEncodedLen(len(src)))
-	n := ascii85.Encode(buf, src)
-	buf = buf[0:n]
+// Transcode decodes src from one encoding and encodes it in another
+func Transcode(src, fromEncoding, toEncoding string) (string, error) {
+
+	data, err := NewCoder(fromEncoding).Decode([]byte(src))
+	if err != nil {
+		return "", err
+	}
+	res, err := NewCoder(toEncoding).Encode(data)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+func encodeASCII85(c *Coder, src []byte) ([]byte, error) {
+
+	buf := []byte{}
+	if c.adobe {
+		buf = append(buf, ascii85Start...)
+	}
+
+	group := make([]byte, 5)
+	for len(src) > 0 {
+		n := len(src)
+		if n > 4 {
+			n = 4
+		}
+		switch {
+		case n == 4 && c.btoa && bytes.Equal(src[:4], ascii85Spaces):
+			buf = append(buf, 'y')
+		default:
+			// a full zero group is encoded as 'z' by the encoder
+			buf = append(buf, group[:ascii85.Encode(group, src[:n])]...)
+		}
+		src = src[n:]
+	}
+
+	if c.adobe {
+		buf = append(buf, ascii85End...)
+	}
 	return buf, nil
 }
 
-func decodeASCII85(src []byte) ([]byte, error) {
-	dst := make([]byte, len(src))
+func decodeASCII85(c *Coder, src []byte) ([]byte, error) {
+
+	if c.adobe {
+		s := bytes.TrimSpace(src)
+		if !bytes.HasSuffix(s, ascii85End) {
+			return nil, fmt.Errorf("ascii85: missing ~> end marker")
+		}
+		src = bytes.TrimPrefix(s[:len(s)-len(ascii85End)], ascii85Start)
+	}
+	if c.btoa {
+		src = bytes.Replace(src, []byte("y"), ascii85SpacesEncoded, -1)
+	}
+
+	dst := make([]byte, 4*len(src))
 	ndst, _, err := ascii85.Decode(dst, src, true)
 	return dst[0:ndst], err
 }
@@ -141,15 +502,25 @@ func encodeBase32(src []byte) ([]byte, error) {
 }
 
 func decodeBase32(src []byte) ([]byte, error) {
-	return base32.StdEncoding.DecodeString(string(src))
+	return base32.StdEncoding.DecodeString(padTo(stripSpaces(string(src)), 8))
 }
 
-func encodeBase36(src []byte) ([]byte, error) {
-	return base36.EncodeBytesAsBytes(src), nil
+func decodeBase32Strict(src []byte) ([]byte, error) {
+	return decodeStrict("base32", base32.StdEncoding, src)
+}
+
+func encodeBase32Hex(src []byte) ([]byte, error) {
+	dst := make([]byte, base32.HexEncoding.EncodedLen(len(src)))
+	base32.HexEncoding.Encode(dst, src)
+	return dst, nil
 }
 
-func decodeBase36(src []byte) ([]byte, error) {
-	return base36.DecodeToBytes(string(src)), nil
+func decodeBase32Hex(src []byte) ([]byte, error) {
+	return base32.HexEncoding.DecodeString(padTo(stripSpaces(string(src)), 8))
+}
+
+func decodeBase32HexStrict(src []byte) ([]byte, error) {
+	return decodeStrict("base32hex", base32.HexEncoding, src)
 }
 
 func encodeBase58(src []byte) ([]byte, error) {
@@ -167,44 +538,97 @@ func encodeBase64(src []byte) ([]byte, error) {
 }
 
 func decodeBase64(src []byte) ([]byte, error) {
-	return base64.StdEncoding.DecodeString(string(src))
+	return base64.StdEncoding.DecodeString(padTo(stripSpaces(string(src)), 4))
 }
 
-func encodeBase91(src []byte) ([]byte, error) {
-	return []byte(base91.Encode(src)), nil
+func decodeBase64Strict(src []byte) ([]byte, error) {
+	return decodeStrict("base64", base64.StdEncoding.Strict(), src)
 }
 
-func decodeBase91(src []byte) ([]byte, error) {
-	if len(src) == 0 {
-		return []byte{}, nil
+// decodeStrict only allows line breaks, as written when wrapping, besides
+// correctly padded input
+func decodeStrict(encoding string, enc interface {
+	DecodeString(string) ([]byte, error)
+}, src []byte) ([]byte, error) {
+
+	if i := bytes.IndexAny(src, " \t\v\f"); i != -1 {
+		return nil, newDecodeError(encoding, src, i, "unexpected white space")
 	}
-	return []byte(base91.Decode(src)), nil
+	return enc.DecodeString(string(src))
 }
 
-func encodeBinary(src []byte) ([]byte, error) {
+// padTo adds any missing '=' padding to make s a multiple of n long
+func padTo(s string, n int) string {
+
+	if len(s)%n == 0 {
+		return s
+	}
+	return s + strings.Repeat("=", n-len(s)%n)
+}
 
-	res := ""
+// encodeBase100 encodes each byte as an emoji, as https://github.com/AdamNiederer/base100
+func encodeBase100(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src)*4)
 	for _, b := range src {
-		res += fmt.Sprintf("%08b", b) + separator
+		n := int(b) + 55
+		res = append(res, 0xf0, 0x9f, byte(n/64+0x8f), byte(n%64+0x80))
 	}
+	return res, nil
+}
+
+func decodeBase100(src []byte) ([]byte, error) {
 
-	return []byte(strings.TrimRight(res, separator)), nil
+	if len(src)%4 != 0 {
+		return nil, fmt.Errorf("base100: invalid length %d", len(src))
+	}
+
+	res := make([]byte, len(src)/4)
+	for i := 0; i < len(src); i += 4 {
+		if src[i] != 0xf0 || src[i+1] != 0x9f || src[i+2] < 0x8f || src[i+3] < 0x80 || src[i+3] > 0xbf {
+			return nil, newDecodeError("base100", src, i, "invalid emoji")
+		}
+		n := (int(src[i+2])-0x8f)*64 + int(src[i+3]) - 0x80 - 55
+		if n < 0 || n > 255 {
+			return nil, newDecodeError("base100", src, i, "invalid emoji")
+		}
+		res[i/4] = byte(n)
+	}
+	return res, nil
 }
 
-func decodeBinary(src []byte) ([]byte, error) {
+func encodeBase91(src []byte) ([]byte, error) {
+	return []byte(base91.Encode(src)), nil
+}
 
+func decodeBase91(src []byte) ([]byte, error) {
 	if len(src) == 0 {
 		return []byte{}, nil
 	}
+	return []byte(base91.Decode(src)), nil
+}
 
-	parts := strings.Split(string(src), separator)
-	res := make([]byte, len(parts))
+func encodeBinary(src []byte) ([]byte, error) {
 
-	for i, part := range parts {
-		b, _ := strconv.ParseInt(part, 2, 8)
-		res[i] = byte(b)
+	var sb strings.Builder
+	sb.Grow(len(src) * (8 + len(separator)))
+	for i, b := range src {
+		if i > 0 {
+			sb.WriteString(separator)
+		}
+		for bit := uint(8); bit > 0; bit-- {
+			sb.WriteByte('0' + b>>(bit-1)&1)
+		}
 	}
-	return res, nil
+	return []byte(sb.String()), nil
+}
+
+func decodeBinary(src []byte) ([]byte, error) {
+	return decodeRadix(src, 2, false)
+}
+
+func decodeBinaryStrict(src []byte) ([]byte, error) {
+	return decodeRadix(src, 2, true)
 }
 
 func encodeBubbleBabble(src []byte) ([]byte, error) {
@@ -217,28 +641,23 @@ func decodeBubbleBabble(src []byte) ([]byte, error) {
 
 func encodeDecimal(src []byte) ([]byte, error) {
 
-	res := ""
-	for _, b := range src {
-		res += fmt.Sprintf("%d", b) + separator
+	var sb strings.Builder
+	sb.Grow(len(src) * (3 + len(separator)))
+	for i, b := range src {
+		if i > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(strconv.Itoa(int(b)))
 	}
-
-	return []byte(strings.TrimRight(res, separator)), nil
+	return []byte(sb.String()), nil
 }
 
 func decodeDecimal(src []byte) ([]byte, error) {
+	return decodeRadix(src, 10, false)
+}
 
-	if len(src) == 0 {
-		return []byte{}, nil
-	}
-
-	parts := strings.Split(string(src), separator)
-	res := make([]byte, len(parts))
-
-	for i, part := range parts {
-		b, _ := strconv.ParseInt(part, 10, 8)
-		res[i] = byte(b)
-	}
-	return res, nil
+func decodeDecimalStrict(src []byte) ([]byte, error) {
+	return decodeRadix(src, 10, true)
 }
 
 func encodeHex(src []byte) ([]byte, error) {
@@ -258,32 +677,110 @@ func decodeHex(src []byte) ([]byte, error) {
 	return res, err
 }
 
-func encodeOctal(src []byte) ([]byte, error) {
+// decodeHexStrict rejects white space and mixed case
+func decodeHexStrict(src []byte) ([]byte, error) {
 
-	res := ""
-	for _, b := range src {
-		res += fmt.Sprintf("%#o", b) + separator
+	if i := bytes.IndexFunc(src, unicode.IsSpace); i != -1 {
+		return nil, newDecodeError("hex", src, i, "unexpected white space")
 	}
+	lower, upper := bytes.IndexAny(src, "abcdef"), bytes.IndexAny(src, "ABCDEF")
+	if lower != -1 && upper != -1 {
+		// the first letter of the other case than the first one
+		if lower < upper {
+			lower = upper
+		}
+		return nil, newDecodeError("hex", src, lower, "mixed case")
+	}
+	return hex.DecodeString(string(src))
+}
 
-	return []byte(strings.TrimRight(res, separator)), nil
+func encodeOctal(src []byte) ([]byte, error) {
+
+	var sb strings.Builder
+	sb.Grow(len(src) * (4 + len(separator)))
+	for i, b := range src {
+		if i > 0 {
+			sb.WriteString(separator)
+		}
+		if b != 0 {
+			sb.WriteByte('0')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(b), 8))
+	}
+	return []byte(sb.String()), nil
 }
 
 func decodeOctal(src []byte) ([]byte, error) {
+	return decodeRadix(src, 8, false)
+}
 
-	if len(src) == 0 {
-		return []byte{}, nil
+func decodeOctalStrict(src []byte) ([]byte, error) {
+	return decodeRadix(src, 8, true)
+}
+
+// decodeRadix decodes space separated numbers in base. Any white space
+// separates numbers unless strict, where the exact output of the encoder
+// is required
+func decodeRadix(src []byte, base int, strict bool) ([]byte, error) {
+
+	name := radixNames[base]
+	var parts []string
+	var offsets []int
+	if strict {
+		if len(src) == 0 {
+			return []byte{}, nil
+		}
+		parts, offsets = splitOffsets(string(src), separator)
+	} else {
+		parts, offsets = splitOffsets(string(src), "")
 	}
 
-	parts := strings.Split(string(src), separator)
 	res := make([]byte, len(parts))
-
 	for i, part := range parts {
-		b, _ := strconv.ParseInt(part, 8, 8)
+		b, err := strconv.ParseUint(part, base, 8)
+		if err != nil {
+			return nil, newDecodeError(name, src, offsets[i], "invalid byte")
+		}
+		if strict && !radixCanonical(part, byte(b), base) {
+			return nil, newDecodeError(name, src, offsets[i], "non-canonical byte")
+		}
 		res[i] = byte(b)
 	}
 	return res, nil
 }
 
+// radixCanonical returns wether s is exactly how the encoder writes b
+func radixCanonical(s string, b byte, base int) bool {
+
+	switch base {
+	case 2:
+		return s == fmt.Sprintf("%08b", b)
+	case 8:
+		return s == fmt.Sprintf("%#o", b)
+	}
+	return s == strconv.Itoa(int(b))
+}
+
+// encodeQuotedPrintable encodes src in binary mode, so line breaks in src
+// are escaped and round-trip exactly. Output lines are soft broken at 76
+func encodeQuotedPrintable(src []byte) ([]byte, error) {
+
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	w.Binary = true
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeQuotedPrintable(src []byte) ([]byte, error) {
+	return ioutil.ReadAll(quotedprintable.NewReader(bytes.NewReader(src)))
+}
+
 func encodeUU(src []byte) ([]byte, error) {
 	res := uu.EncodeLine(src)
 	return res, nil
@@ -293,35 +790,79 @@ func decodeUU(src []byte) ([]byte, error) {
 	return uu.DecodeLine(src)
 }
 
-func encodeZ85(src []byte) ([]byte, error) {
-	src4pad := src
+// encodeZ85 zero-pads src to a multiple of 4 bytes. With PadCount the
+// number of padding bytes is appended as a digit, in strict mode src must
+// be a multiple of 4 bytes
+func encodeZ85(c *Coder, src []byte) ([]byte, error) {
 
-	// pad size, input must be divisible by 4
-	if len(src4pad)%4 != 0 {
-		l := len(src4pad) + 4 - (len(src4pad) % 4)
-		src4pad = make([]byte, l)
-		for i, b := range src {
-			src4pad[i] = b
+	pad := 0
+	if len(src)%4 != 0 {
+		if c.strict {
+			return nil, fmt.Errorf("z85: input must be a multiple of 4 bytes, got %d", len(src))
 		}
+		pad = 4 - len(src)%4
 	}
+	src4pad := make([]byte, len(src)+pad)
+	copy(src4pad, src)
 
 	b85 := make([]byte, z85.EncodedLen(len(src4pad)))
 	_, err := z85.Encode(b85, src4pad)
+	if c.padCount {
+		b85 = append(b85, byte('0'+pad))
+	}
 	return b85, err
 }
 
-func decodeZ85(src []byte) ([]byte, error) {
+// decodeZ85 strips all trailing zero bytes, unless the padding is known
+// from PadCount or is forbidden by strict mode
+func decodeZ85(c *Coder, src []byte) ([]byte, error) {
+
+	pad := -1
+	if c.padCount {
+		if len(src) == 0 || src[len(src)-1] < '0' || src[len(src)-1] > '3' {
+			return nil, fmt.Errorf("z85: missing padding count")
+		}
+		pad = int(src[len(src)-1] - '0')
+		src = src[:len(src)-1]
+	} else if c.strict {
+		pad = 0
+	}
+	if len(src)%5 != 0 {
+		return nil, fmt.Errorf("z85: input length must be a multiple of 5, got %d", len(src))
+	}
 
 	dst := make([]byte, z85.DecodedLen(len(src)))
 	n, err := z85.Decode(dst, src)
+	if err != nil {
+		return nil, err
+	}
 
-	// strip padding
-	for ; n > 0; n-- {
-		if dst[n-1] != 0 {
-			break
+	if pad == -1 {
+		// strip padding
+		for ; n > 0; n-- {
+			if dst[n-1] != 0 {
+				break
+			}
 		}
+		return dst[0:n], nil
 	}
-	return dst[0:n], err
+	if pad > n {
+		return nil, fmt.Errorf("z85: padding count %d exceeds data", pad)
+	}
+	for _, b := range dst[n-pad : n] {
+		if b != 0 {
+			return nil, fmt.Errorf("z85: padding bytes are not zero")
+		}
+	}
+	return dst[0 : n-pad], nil
+}
+
+func encodeZBase32(src []byte) ([]byte, error) {
+	return []byte(zbase32Encoding.EncodeToString(src)), nil
+}
+
+func decodeZBase32(src []byte) ([]byte, error) {
+	return zbase32Encoding.DecodeString(string(src))
 }
 
 // defaults to "hex" if encoding is unspecified
@@ -334,18 +875,57 @@ func resolveEncodingAliases(s string) string {
 	if s == "base85" {
 		return "ascii85"
 	}
+	if s == "emoji" {
+		return "base100"
+	}
 	if s == "bb" {
 		return "bubblebabble"
 	}
 	if s == "bin" {
 		return "binary"
 	}
+	if s == "crockford32" || s == "base32crockford" {
+		return "crockford"
+	}
+	if s == "c" || s == "carray" {
+		return "c-array"
+	}
 	if s == "dec" {
 		return "decimal"
 	}
 	if s == "base16" || s == "hexadecimal" {
 		return "hex"
 	}
+	if s == "xxd" {
+		return "hexdump"
+	}
+	if s == "z-base-32" || s == "z-base32" {
+		return "zbase32"
+	}
+	if s == "urlencode" || s == "url" {
+		return "percent"
+	}
+	if s == "pgp" || s == "pgp-words" {
+		return "pgpwords"
+	}
+	if s == "python" || s == "py" {
+		return "python-bytes"
+	}
+	if s == "qp" || s == "quoted-printable" {
+		return "quotedprintable"
+	}
+	if s == "go" || s == "gobytes" {
+		return "go-bytes"
+	}
+	if s == "intelhex" || s == "hex86" {
+		return "ihex"
+	}
+	if s == "mnemonic" {
+		return "bip39"
+	}
+	if s == "srecord" || s == "s19" || s == "motorola" {
+		return "srec"
+	}
 	if s == "oct" {
 		return "octal"
 	}
@@ -1,6 +1,9 @@
 package gohash
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/gofuzz"
@@ -19,42 +22,123 @@ var (
 		"ascii85": {
 			fox:   "<+ohcEHPu*CER),Dg-(AAoDo:C3=B4F!,CEATAo8BOr<&@=!2AA8c)",
 			blank: ""},
+		"base100": {
+			fox:   "👋👟👜🐗👨👬👠👚👢🐗👙👩👦👮👥🐗👝👦👯🐗👡👬👤👧👪🐗👦👭👜👩🐗👫👟👜🐗👣👘👱👰🐗👛👦👞",
+			blank: ""},
+		"base122": {
+			fox:   "*\x1a\x0cR\x03Eji1Zd\x06\x13I^w7\x08\x0cf{`@j:[.\x07\x19\x01^v2\xd7\x84\x07#!J 6\x18/'I\x01Ho3@",
+			blank: ""},
+		"base2048": {
+			fox:   "նҿԈคאଢહƕݑޛඒҬک௫ߤԾ۹ࠈಕධڤடߥࢻڛZɜཐपХఉ7",
+			blank: ""},
 		"base32": {
 			fox:   "KRUGKIDROVUWG2ZAMJZG653OEBTG66BANJ2W24DTEBXXMZLSEB2GQZJANRQXU6JAMRXWO===",
 			blank: ""},
+		"base32hex": {
+			fox:   "AHK6A83HELKM6QP0C9P6UTRE41J6UU10D9QMQS3J41NNCPBI41Q6GP90DHGNKU90CHNME===",
+			blank: ""},
 		"base36": {
 			fox:   "29T3UBYZNHH32O9X3PZVLJP1QA22WUN2QUO35NAEMPN0GTX0LXIYRF3QWWI0LZU165J",
 			blank: ""},
+		"base45": {
+			fox:   "8UADZCKFEOEDJOD2KC54EM-DX.CH8FSKDQ$D.OE44E5$CS44+8DK44OEC3EFGVCD2",
+			blank: ""},
 		"base58": {
 			fox:   "7DdiPPYtxLjCD3wA1po2rvZHTDYjkZYiEtazrfiwJcwnKCizhGFhBGHeRdx",
 			blank: ""},
+		"base58check": {
+			fox:   "1hgrbmYjTAB9gMSwZ6bUP86rvvhPkJzRkcqkmdZXXPyQCbXzuSLGmEDgmK4hs6fsT",
+			blank: "1Wh4bh"},
 		"base64": {
 			fox:   "VGhlIHF1aWNrIGJyb3duIGZveCBqdW1wcyBvdmVyIHRoZSBsYXp5IGRvZw==",
 			blank: ""},
+		"base65536": {
+			fox:   "鵔啥𓍱顩啫𓁢𔕯啮𒁦啸𓍪𒅭啳𔑯𓁥𓈠驨ꌠ𠁡啹𒁤ᕧ",
+			blank: ""},
 		"base91": {
 			fox:   "nX^Iz?T1s!2t:aRn#o>vf>6C9#`##mlLK#_1:Wzv;RG!,a%q3Lc=Z",
 			blank: ""},
 		"bubblebabble": {
 			fox:   "xihak-minod-besol-hopak-fypad-bumal-daril-lurad-binik-zovad-bepyl-hirol-bysod-barel-konal-domel-gipuk-hamok-somyl-pivad-bonuk-zanox",
 			blank: "xexax"},
+		"bech32": {
+			fox:   "data1235x2gr3w45kx6eqvfex7amwypnx77pqdf6k6urnyphhvetjyp6xsefqd3sh57fqv3hkwyp54jw",
+			blank: "data1tu4da0"},
+		"bech32m": {
+			fox:   "data1235x2gr3w45kx6eqvfex7amwypnx77pqdf6k6urnyphhvetjyp6xsefqd3sh57fqv3hkw3ayehv",
+			blank: "data17q9pcd"},
 		"binary": {
 			fox:   "01010100 01101000 01100101 00100000 01110001 01110101 01101001 01100011 01101011 00100000 01100010 01110010 01101111 01110111 01101110 00100000 01100110 01101111 01111000 00100000 01101010 01110101 01101101 01110000 01110011 00100000 01101111 01110110 01100101 01110010 00100000 01110100 01101000 01100101 00100000 01101100 01100001 01111010 01111001 00100000 01100100 01101111 01100111",
 			blank: ""},
+		"c-array": {
+			fox:   "{0x54, 0x68, 0x65, 0x20, 0x71, 0x75, 0x69, 0x63, 0x6b, 0x20, 0x62, 0x72, 0x6f, 0x77, 0x6e, 0x20, 0x66, 0x6f, 0x78, 0x20, 0x6a, 0x75, 0x6d, 0x70, 0x73, 0x20, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x74, 0x68, 0x65, 0x20, 0x6c, 0x61, 0x7a, 0x79, 0x20, 0x64, 0x6f, 0x67}",
+			blank: "{}"},
+		"crockford": {
+			fox:   "AHM6A83HENMP6TS0C9S6YXVE41K6YY10D9TPTW3K41QQCSBJ41T6GS90DHGQMY90CHQPE",
+			blank: ""},
+		"crockford-chk": {
+			fox:   "AHM6A83HENMP6TS0C9S6YXVE41K6YY10D9TPTW3K41QQCSBJ41T6GS90DHGQMY90CHQPEX",
+			blank: "0"},
 		"decimal": {
 			fox:   "84 104 101 32 113 117 105 99 107 32 98 114 111 119 110 32 102 111 120 32 106 117 109 112 115 32 111 118 101 114 32 116 104 101 32 108 97 122 121 32 100 111 103",
 			blank: ""},
+		"fingerprint": {
+			fox:   "54:68:65:20:71:75:69:63:6B:20:62:72:6F:77:6E:20:66:6F:78:20:6A:75:6D:70:73:20:6F:76:65:72:20:74:68:65:20:6C:61:7A:79:20:64:6F:67",
+			blank: ""},
+		"go-bytes": {
+			fox:   "[]byte{0x54, 0x68, 0x65, 0x20, 0x71, 0x75, 0x69, 0x63, 0x6b, 0x20, 0x62, 0x72, 0x6f, 0x77, 0x6e, 0x20, 0x66, 0x6f, 0x78, 0x20, 0x6a, 0x75, 0x6d, 0x70, 0x73, 0x20, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x74, 0x68, 0x65, 0x20, 0x6c, 0x61, 0x7a, 0x79, 0x20, 0x64, 0x6f, 0x67}",
+			blank: "[]byte{}"},
 		"hex": {
 			fox:   "54686520717569636b2062726f776e20666f78206a756d7073206f76657220746865206c617a7920646f67",
 			blank: ""},
+		"hex-colons": {
+			fox:   "54:68:65:20:71:75:69:63:6b:20:62:72:6f:77:6e:20:66:6f:78:20:6a:75:6d:70:73:20:6f:76:65:72:20:74:68:65:20:6c:61:7a:79:20:64:6f:67",
+			blank: ""},
+		"hexdump": {
+			fox:   "00000000: 5468 6520 7175 6963 6b20 6272 6f77 6e20  The quick brown \n00000010: 666f 7820 6a75 6d70 7320 6f76 6572 2074  fox jumps over t\n00000020: 6865 206c 617a 7920 646f 67              he lazy dog",
+			blank: ""},
 		"hexup": {
 			fox:   "54686520717569636B2062726F776E20666F78206A756D7073206F76657220746865206C617A7920646F67",
 			blank: ""},
+		"ihex": {
+			fox:   ":1000000054686520717569636B2062726F776E202A\n:10001000666F78206A756D7073206F7665722074D4\n:0B0020006865206C617A7920646F67CE\n:00000001FF",
+			blank: ":00000001FF"},
+		"morse": {
+			fox:   "..... ....- / -.... ---.. / -.... ..... / ..--- ----- / --... .---- / --... ..... / -.... ----. / -.... ...-- / -.... -... / ..--- ----- / -.... ..--- / --... ..--- / -.... ..-. / --... --... / -.... . / ..--- ----- / -.... -.... / -.... ..-. / --... ---.. / ..--- ----- / -.... .- / --... ..... / -.... -.. / --... ----- / --... ...-- / ..--- ----- / -.... ..-. / --... -.... / -.... ..... / --... ..--- / ..--- ----- / --... ....- / -.... ---.. / -.... ..... / ..--- ----- / -.... -.-. / -.... .---- / --... .- / --... ----. / ..--- ----- / -.... ....- / -.... ..-. / -.... --...",
+			blank: ""},
 		"octal": {
 			fox:   "0124 0150 0145 040 0161 0165 0151 0143 0153 040 0142 0162 0157 0167 0156 040 0146 0157 0170 040 0152 0165 0155 0160 0163 040 0157 0166 0145 0162 040 0164 0150 0145 040 0154 0141 0172 0171 040 0144 0157 0147",
 			blank: ""},
+		"percent": {
+			fox:   "The%20quick%20brown%20fox%20jumps%20over%20the%20lazy%20dog",
+			blank: ""},
+		"pgpwords": {
+			fox:   "eating gravity fracture butterfat hamlet impartial gazelle Galveston glitter butterfat flagpole holiness gremlin inception goldfish butterfat framework hemisphere island butterfat Geiger impartial goggles hesitate hockey butterfat gremlin impetus fracture holiness bison hydraulic frighten glossary bison handiwork fallout infancy jawbone butterfat flytrap hemisphere freedom",
+			blank: ""},
+		"python-bytes": {
+			fox:   "b\"\\x54\\x68\\x65\\x20\\x71\\x75\\x69\\x63\\x6b\\x20\\x62\\x72\\x6f\\x77\\x6e\\x20\\x66\\x6f\\x78\\x20\\x6a\\x75\\x6d\\x70\\x73\\x20\\x6f\\x76\\x65\\x72\\x20\\x74\\x68\\x65\\x20\\x6c\\x61\\x7a\\x79\\x20\\x64\\x6f\\x67\"",
+			blank: "b\"\""},
+		"quotedprintable": {
+			fox:   fox,
+			blank: ""},
+		"rfc1924": {
+			fox:   "6g_&&A1^d!FFTe9=>juprLCnsgQ9%u#`|ko4=C6f)dxd}ZQ#x>26S!",
+			blank: ""},
+		"srec": {
+			fox:   "S0030000FC\nS113000054686520717569636B2062726F776E2026\nS1130010666F78206A756D7073206F7665722074D0\nS10E00206865206C617A7920646F67CA\nS9030000FC",
+			blank: "S0030000FC\nS9030000FC"},
+		"uuencode": {
+			fox:   "begin 644 data\nK5&AE('%U:6-K(&)R;W=N(&9O>\"!J=6UP<R!O=F5R('1H92!L87IY(&1O9P``\n`\nend\n",
+			blank: "begin 644 data\n`\nend\n"},
+		"xxencode": {
+			fox:   "begin 644 data\nfJ4VZ653pOKBf647mPrRi64NjS0-eRKpkQm-jRaJm65FcNG-gMLdt64FjNk++\n+\nend\n",
+			blank: "begin 644 data\n+\nend\n"},
 		"z85": {
 			fox:   "ra]?=ADL#9yAN8bz*c7ww]z]pyisxjB0byAwPw]nxK@r5vs0hwwn=8X",
 			blank: ""},
+		"zbase32": {
+			fox:   "ktwgkedtqiwsg43ycj3g675qrbug66bypj4s4hdurbzzc3m1rb4go3jyptozw6jyctzsq",
+			blank: ""},
 	}
 )
 
@@ -72,14 +156,12 @@ func TestCalcExpectedEncodings(t *testing.T) {
 
 func TestCalcExpectedDecodings(t *testing.T) {
 
-	for algo := range decoders {
-		if forms, ok := expectedEncodings[algo]; ok {
-			for clear, coded := range forms {
-				coder := NewCoder(algo)
-				res, err := coder.Decode([]byte(coded))
-				assert.Equal(t, nil, err, algo)
-				assert.Equal(t, []byte(clear), res, algo)
-			}
+	for algo, forms := range expectedEncodings {
+		for clear, coded := range forms {
+			coder := NewCoder(algo)
+			res, err := coder.Decode([]byte(coded))
+			assert.Equal(t, nil, err, algo)
+			assert.Equal(t, []byte(clear), res, algo)
 		}
 	}
 }
@@ -110,11 +192,121 @@ func TestFuzzDecoders(t *testing.T) {
 
 func TestEncodeZ85(t *testing.T) {
 
-	res, err := encodeZ85([]byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B})
+	res, err := NewCoder("z85").Encode([]byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B})
 	assert.Equal(t, nil, err)
 	assert.Equal(t, "HelloWorld", string(res))
 }
 
+func TestZ85PadCount(t *testing.T) {
+
+	coder := NewCoder("z85")
+	coder.PadCount(true)
+	for _, src := range [][]byte{
+		{0xde, 0xad, 0x00},
+		{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B},
+		{0x00, 0x00, 0x00, 0x00, 0x00},
+		{},
+	} {
+		res, err := coder.Encode(src)
+		assert.Equal(t, nil, err)
+		dec, err := coder.Decode(res)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, dec)
+	}
+
+	res, _ := coder.Encode([]byte{0xde, 0xad, 0x00})
+	assert.Equal(t, byte('1'), res[len(res)-1])
+
+	_, err := coder.Decode([]byte("HelloWorld"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestZ85Strict(t *testing.T) {
+
+	coder := NewCoder("z85")
+	coder.Strict(true)
+	_, err := coder.Encode([]byte{0xde, 0xad, 0x00})
+	assert.NotEqual(t, nil, err)
+
+	res, err := coder.Decode([]byte("HelloWorld"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}, res)
+
+	// trailing zeros are kept in strict mode
+	res, err = coder.Decode([]byte("00000"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0, 0, 0, 0}, res)
+
+	_, err = coder.Decode([]byte("Hell"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestEncodeZBase32(t *testing.T) {
+
+	res, err := encodeZBase32([]byte{0xf0, 0xbf, 0xc7})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "6n9hq", string(res))
+}
+
+func TestBase100RoundTripAllBytes(t *testing.T) {
+
+	src := make([]byte, 256)
+	for i := range src {
+		src[i] = byte(i)
+	}
+
+	enc, err := encodeBase100(src)
+	assert.Equal(t, nil, err)
+	res, err := decodeBase100(enc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, res)
+
+	_, err = decodeBase100([]byte("hello"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestQuotedPrintable(t *testing.T) {
+
+	src := []byte(strings.Repeat("café = ok\n", 8))
+
+	res, err := encodeQuotedPrintable(src)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(string(res), "caf=C3=A9 =3D ok=0Acaf=C3=A9"))
+	for _, line := range strings.Split(string(res), "\r\n") {
+		assert.Equal(t, true, len(line) <= 76)
+	}
+
+	back, err := decodeQuotedPrintable(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, back)
+}
+
+func TestDecodeQuotedPrintableSoftLineBreak(t *testing.T) {
+
+	res, err := decodeQuotedPrintable([]byte("The quick brown =\r\nfox=\njumps"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "The quick brown foxjumps", string(res))
+}
+
+func TestPercent(t *testing.T) {
+
+	res, err := encodePercent([]byte{0x00, 0xff, '~', '/', '%'})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "%00%FF~%2F%25", string(res))
+
+	coder := NewCoder("urlencode")
+	res, err = coder.Decode([]byte("100%25 %e2%9C%93 50% off%"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "100% ✓ 50% off%", string(res))
+
+	coder.Strict(true)
+	_, err = coder.Decode([]byte("50% off"))
+	assert.Equal(t, "percent: malformed escape '%' at offset 2", err.Error())
+
+	_, err = coder.Decode([]byte("off%2"))
+	assert.NotEqual(t, nil, err)
+}
+
 func TestDecodeHexWithSpaces(t *testing.T) {
 
 	res, err := decodeHex([]byte("48 4f 2a"))
@@ -142,3 +334,264 @@ func TestRecodeInputDecodeChain(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, "hello", string(res))
 }
+
+func TestPipeline(t *testing.T) {
+
+	coder := NewCoder("hex|base64 | z85")
+	res, err := coder.Encode([]byte("hi"))
+	assert.Equal(t, nil, err)
+
+	// "hi" -> "6869" -> "Njg2OQ==" -> z85
+	expected, _ := NewCoder("z85").Encode([]byte("Njg2OQ=="))
+	assert.Equal(t, string(expected), string(res))
+
+	dec, err := coder.Decode(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("hi"), dec)
+
+	_, err = NewCoder("hex|nope").Encode([]byte("hi"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPipelineSharesSettings(t *testing.T) {
+
+	coder := NewCoder("base64|bech32")
+	coder.HRP("test")
+	res, err := coder.Encode([]byte("hi"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "test1", string(res[0:5]))
+}
+
+func TestWrap(t *testing.T) {
+
+	src := bytes.Repeat([]byte{0xff}, 60)
+
+	coder := NewCoder("base64")
+	coder.Wrap(76)
+	coder.CRLF(true)
+	res, err := coder.Encode(src)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, strings.Repeat("/", 76)+"\r\n"+strings.Repeat("/", 4), string(res))
+
+	dec, err := coder.Decode(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, dec)
+
+	coder = NewCoder("base32")
+	coder.Wrap(64)
+	res, _ = coder.Encode(src)
+	lines := strings.Split(string(res), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, 64, len(lines[0]))
+
+	// white space is ignored on decode
+	dec, err = NewCoder("base32").Decode([]byte(" " + strings.Join(lines, "\r\n ") + "\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, dec)
+}
+
+func TestStrictAndLenientDecoding(t *testing.T) {
+
+	for _, tc := range []struct {
+		encoding string
+		input    string
+		expected string
+	}{
+		{"base64", "aGVsbG8", "hello"},
+		{"base64", " aGVs\tbG8=\n", "hello"},
+		{"base32", "NBSWY3DPEE", "hello!"},
+		{"base32hex", "D1IMOR3F44", "hello!"},
+		{"hex", "68 65 6C 6c 6f", "hello"},
+	} {
+		coder := NewCoder(tc.encoding)
+		res, err := coder.Decode([]byte(tc.input))
+		assert.Equal(t, nil, err, tc.input)
+		assert.Equal(t, tc.expected, string(res), tc.input)
+
+		coder.Strict(true)
+		_, err = coder.Decode([]byte(tc.input))
+		assert.NotEqual(t, nil, err, tc.input)
+	}
+
+	for encoding, input := range map[string]string{
+		"base64":    "aGVs\nbG8=",
+		"base32":    "NBSWY3DPEE======",
+		"base32hex": "D1IMOR3F44======",
+		"hex":       "68656C6C6F",
+	} {
+		coder := NewCoder(encoding)
+		coder.Strict(true)
+		_, err := coder.Decode([]byte(input))
+		assert.Equal(t, nil, err, input)
+	}
+}
+
+func TestIgnoreCase(t *testing.T) {
+
+	for encoding, input := range map[string]string{
+		"base32":    "nbswy3dpEE======",
+		"base32hex": "d1imor3f44======",
+		"hex":       "68656c6C6F21",
+		"zbase32":   "PB1SA5DXRR",
+	} {
+		coder := NewCoder(encoding)
+		coder.Strict(true)
+		_, err := coder.Decode([]byte(input))
+		assert.NotEqual(t, nil, err, encoding)
+
+		coder.IgnoreCase(true)
+		res, err := coder.Decode([]byte(input))
+		assert.Equal(t, nil, err, encoding)
+		assert.Equal(t, "hello!", string(res), encoding)
+	}
+}
+
+func TestTranscode(t *testing.T) {
+
+	res, err := Transcode("1B2M2Y8AsgTpgAmY7PhCfg==", "base64", "hex")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", res)
+
+	res, err = Transcode("d41d8cd98f00b204e9800998ecf8427e", "hex", "base58")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "TCByYo9r1su7nMQP3WHDFK", res)
+
+	_, err = Transcode("zz", "hex", "base58")
+	assert.NotEqual(t, nil, err)
+
+	_, err = Transcode("00", "hex", "nope")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestASCII85AdobeFraming(t *testing.T) {
+
+	coder := NewCoder("ascii85")
+	coder.AdobeFraming(true)
+	res, err := coder.Encode([]byte("hello"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "<~BOu!rDZ~>", string(res))
+
+	dec, err := coder.Decode([]byte(" <~BOu!\nrDZ~>\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("hello"), dec)
+
+	// the start marker is optional, as in PostScript
+	dec, err = coder.Decode([]byte("BOu!rDZ~>"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("hello"), dec)
+
+	_, err = coder.Decode([]byte("<~BOu!rDZ"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestASCII85Btoa(t *testing.T) {
+
+	src := []byte("\x00\x00\x00\x00        ab")
+
+	coder := NewCoder("ascii85")
+	res, err := coder.Encode(src)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "z+<VdL+<VdL@:B", string(res))
+
+	coder.Btoa(true)
+	res, err = coder.Encode(src)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "zyy@:B", string(res))
+
+	dec, err := coder.Decode(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, dec)
+}
+
+func TestDecodeRadixErrors(t *testing.T) {
+
+	for encoding, garbage := range map[string]string{
+		"binary":  "01010100 0110x000",
+		"decimal": "84 104 256",
+		"octal":   "0124 0150 09",
+	} {
+		_, err := NewCoder(encoding).Decode([]byte(garbage))
+		assert.NotEqual(t, nil, err, encoding)
+	}
+
+	_, err := NewCoder("decimal").Decode([]byte("84 abc"))
+	assert.Equal(t, `decimal: invalid byte 'a' at offset 3`, err.Error())
+}
+
+func TestDecodeRadixHighBytes(t *testing.T) {
+
+	src := []byte{0x00, 0x7f, 0x80, 0xff}
+	for _, encoding := range []string{"binary", "decimal", "octal"} {
+		coder := NewCoder(encoding)
+		enc, _ := coder.Encode(src)
+		res, err := coder.Decode(enc)
+		assert.Equal(t, nil, err, encoding)
+		assert.Equal(t, src, res, encoding)
+	}
+}
+
+func TestDecodeRadixStrict(t *testing.T) {
+
+	// lenient mode accepts any white space
+	res, err := NewCoder("decimal").Decode([]byte(" 84\n104  101\t"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("The"), res)
+
+	for encoding, input := range map[string]string{
+		"binary":  "1010100",
+		"decimal": "84  104",
+		"octal":   "124",
+	} {
+		coder := NewCoder(encoding)
+		_, err := coder.Decode([]byte(input))
+		assert.Equal(t, nil, err, encoding)
+
+		coder.Strict(true)
+		_, err = coder.Decode([]byte(input))
+		assert.NotEqual(t, nil, err, encoding)
+	}
+}
+
+func TestEncodeRadixAllBytes(t *testing.T) {
+
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		res, _ := encodeBinary([]byte{b})
+		assert.Equal(t, fmt.Sprintf("%08b", b), string(res))
+		res, _ = encodeDecimal([]byte{b})
+		assert.Equal(t, fmt.Sprintf("%d", b), string(res))
+		res, _ = encodeOctal([]byte{b})
+		assert.Equal(t, fmt.Sprintf("%#o", b), string(res))
+	}
+}
+
+var benchmarkInput = bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog\x00\xff"), 100000)
+
+func benchmarkEncode(b *testing.B, encoding string) {
+
+	coder := NewCoder(encoding)
+	b.SetBytes(int64(len(benchmarkInput)))
+	for i := 0; i < b.N; i++ {
+		coder.Encode(benchmarkInput)
+	}
+}
+
+func benchmarkDecode(b *testing.B, encoding string) {
+
+	coder := NewCoder(encoding)
+	enc, _ := coder.Encode(benchmarkInput)
+	b.SetBytes(int64(len(benchmarkInput)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		coder.Decode(enc)
+	}
+}
+
+func BenchmarkEncodeBinary(b *testing.B)  { benchmarkEncode(b, "binary") }
+func BenchmarkEncodeDecimal(b *testing.B) { benchmarkEncode(b, "decimal") }
+func BenchmarkEncodeOctal(b *testing.B)   { benchmarkEncode(b, "octal") }
+func BenchmarkEncodeHex(b *testing.B)     { benchmarkEncode(b, "hex") }
+func BenchmarkEncodeBase64(b *testing.B)  { benchmarkEncode(b, "base64") }
+func BenchmarkDecodeBinary(b *testing.B)  { benchmarkDecode(b, "binary") }
+func BenchmarkDecodeDecimal(b *testing.B) { benchmarkDecode(b, "decimal") }
+func BenchmarkDecodeOctal(b *testing.B)   { benchmarkDecode(b, "octal") }
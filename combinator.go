@@ -0,0 +1,83 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+)
+
+// Separator sets the text put between the words of FindCombinator
+func (h *Hasher) Separator(s string) {
+	h.separator = s
+}
+
+// FindCombinator tries every word of left followed by every word of right,
+// one per line, like the combinator attack of hashcat. The right list is
+// read into memory, left is read as it is searched
+func (h *Hasher) FindCombinator(left, right io.Reader) (string, error) {
+	return h.FindCombinatorContext(context.Background(), left, right)
+}
+
+// FindCombinatorContext is like FindCombinator, but stops with the error
+// of ctx when it is done
+func (h *Hasher) FindCombinatorContext(ctx context.Context, left, right io.Reader) (string, error) {
+
+	rights, err := readWords(right)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan []byte, statusInterval)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		scanner := bufio.NewScanner(left)
+		for scanner.Scan() {
+			word := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+			if len(word) == 0 {
+				continue
+			}
+			for _, r := range rights {
+				candidate := make([]byte, 0, len(word)+len(h.separator)+len(r))
+				candidate = append(append(append(candidate, word...), h.separator...), r...)
+				select {
+				case ch <- candidate:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	res, err := h.FindFromChannelContext(ctx, ch)
+	if err != nil {
+		select {
+		case e := <-readErr:
+			if e != nil {
+				// the whole input was not searched
+				return "", e
+			}
+		default:
+		}
+	}
+	return res, err
+}
+
+// readWords returns the non-empty lines of r
+func readWords(r io.Reader) ([][]byte, error) {
+
+	res := [][]byte{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		if len(word) != 0 {
+			res = append(res, append([]byte{}, word...))
+		}
+	}
+	return res, scanner.Err()
+}
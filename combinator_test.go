@@ -0,0 +1,51 @@
+package gohash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCombinator(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("ea93317d0b4393666aaca4390c5268c4")
+	hasher.Workers(2)
+
+	res, err := hasher.FindCombinator(
+		strings.NewReader("correct\nhorse\r\n\nbattery\n"),
+		strings.NewReader("staple\nhorse\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "batterystaple", res)
+}
+
+func TestFindCombinatorSeparator(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("15613f70cc59048688bdec1ee938e171")
+	hasher.Separator(" ")
+	hasher.Suffix("!")
+
+	res, err := hasher.FindCombinator(
+		strings.NewReader("correct\nhorse\n"),
+		strings.NewReader("battery\nstaple\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "horse staple!", res)
+}
+
+func TestFindCombinatorNotFound(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+
+	_, err := hasher.FindCombinator(strings.NewReader("a\nb\n"), strings.NewReader("c\nd\n"))
+	assert.Equal(t, "no match found", err.Error())
+
+	_, err = hasher.FindCombinator(strings.NewReader("a\nb\n"), failingReader{})
+	assert.Equal(t, errors.New("read failed"), err)
+}
@@ -0,0 +1,95 @@
+package gohash
+
+import (
+	"fmt"
+)
+
+// decodeHexConstantTime decodes hex in either case without branching on,
+// or indexing tables by, the input, as sodium_hex2bin
+func decodeHexConstantTime(src []byte) ([]byte, error) {
+
+	if len(src)%2 != 0 {
+		return nil, fmt.Errorf("hex: odd length %d", len(src))
+	}
+
+	res := make([]byte, len(src)/2)
+	invalid := uint32(0)
+	for i := range res {
+		hi := hexValueConstantTime(src[i*2])
+		lo := hexValueConstantTime(src[i*2+1])
+		invalid |= (hi | lo) >> 4
+		res[i] = byte(hi<<4 | lo&0xf)
+	}
+	if invalid != 0 {
+		return nil, fmt.Errorf("hex: invalid input")
+	}
+	return res, nil
+}
+
+// decodeBase64ConstantTime decodes standard base64, with or without
+// padding, in constant time. Only the length of src affects timing
+func decodeBase64ConstantTime(src []byte) ([]byte, error) {
+
+	if len(src)%4 == 0 && len(src) > 0 && src[len(src)-1] == '=' {
+		src = src[:len(src)-1]
+		if src[len(src)-1] == '=' {
+			src = src[:len(src)-1]
+		}
+	}
+	if len(src)%4 == 1 {
+		return nil, fmt.Errorf("base64: invalid length %d", len(src))
+	}
+
+	res := make([]byte, 0, len(src)*6/8)
+	invalid := uint32(0)
+	acc, bits := uint32(0), uint(0)
+	for _, c := range src {
+		v := base64ValueConstantTime(c)
+		invalid |= v >> 6
+		acc = acc<<6 | v&0x3f
+		bits += 6
+		if bits >= 8 {
+			bits -= 8
+			res = append(res, byte(acc>>bits))
+		}
+	}
+	if invalid != 0 {
+		return nil, fmt.Errorf("base64: invalid input")
+	}
+	return res, nil
+}
+
+// hexValueConstantTime returns the value of a hex digit, or 0xff
+func hexValueConstantTime(c byte) uint32 {
+
+	num := uint32(c) ^ '0'
+	numMask := (num - 10) >> 8 & 0xff
+	alpha := uint32(c)&^0x20 - 55
+	alphaMask := ((alpha - 10) ^ (alpha - 16)) >> 8 & 0xff
+	invalid := (numMask | alphaMask) ^ 0xff
+	return numMask&num | alphaMask&alpha | invalid
+}
+
+// base64ValueConstantTime returns the value of a standard base64
+// character, or 0xff
+func base64ValueConstantTime(c byte) uint32 {
+
+	x := uint32(c)
+	v := ctInRange(x, 'A', 'Z')&(x-'A') |
+		ctInRange(x, 'a', 'z')&(x-'a'+26) |
+		ctInRange(x, '0', '9')&(x-'0'+52) |
+		ctEqual(x, '+')&62 |
+		ctEqual(x, '/')&63
+	invalid := ctEqual(v, 0) & (ctEqual(x, 'A') ^ 0xff)
+	return v | invalid
+}
+
+// ctEqual returns 0xff if x == y, else 0
+func ctEqual(x, y uint32) uint32 {
+	return (0-(x^y))>>8&0xff ^ 0xff
+}
+
+// ctInRange returns 0xff if lo <= x <= hi, else 0
+func ctInRange(x, lo, hi uint32) uint32 {
+	return ((x-lo)>>8|(hi-x)>>8)&0xff ^ 0xff
+}
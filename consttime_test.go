@@ -0,0 +1,68 @@
+package gohash
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeHexConstantTime(t *testing.T) {
+
+	for n := 0; n < 64; n++ {
+		src := make([]byte, n)
+		rand.Read(src)
+		res, err := decodeHexConstantTime([]byte(hex.EncodeToString(src)))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, res)
+	}
+
+	res, err := decodeHexConstantTime([]byte("DEADbeef09"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef, 0x09}, res)
+
+	for _, s := range []string{"0", "0g", "g0", "/0", ":0", "@0", "0G", "`0", " 0"} {
+		_, err := decodeHexConstantTime([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
+
+func TestDecodeBase64ConstantTime(t *testing.T) {
+
+	for n := 0; n < 64; n++ {
+		src := make([]byte, n)
+		rand.Read(src)
+		res, err := decodeBase64ConstantTime([]byte(base64.StdEncoding.EncodeToString(src)))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, res)
+
+		res, err = decodeBase64ConstantTime([]byte(base64.RawStdEncoding.EncodeToString(src)))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, src, res)
+	}
+
+	for _, s := range []string{"A", "AAA-", "AA_A", "AA A", "A===", "=AAA", "AA\x00A"} {
+		_, err := decodeBase64ConstantTime([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
+
+func TestCoderConstantTime(t *testing.T) {
+
+	coder := NewCoder("hex")
+	coder.ConstantTime(true)
+	res, err := coder.Decode([]byte("00ff"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0x00, 0xff}, res)
+
+	_, err = coder.Decode([]byte("00 fff"))
+	assert.Equal(t, "hex: invalid input", err.Error())
+
+	coder = NewCoder("base64")
+	coder.ConstantTime(true)
+	res, err = coder.Decode([]byte(base64.StdEncoding.EncodeToString([]byte(fox))))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(res))
+}
@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// extra symbols only used for the check symbol
+	crockfordCheckAlphabet = crockfordAlphabet + "*~$=U"
+)
+
+var (
+	crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+	// substitutions for ambiguous characters accepted on decode
+	crockfordReplacer = strings.NewReplacer(
+		"-", "",
+		"I", "1",
+		"L", "1",
+		"O", "0",
+	)
+)
+
+func encodeCrockford(src []byte) ([]byte, error) {
+	return []byte(crockfordEncoding.EncodeToString(src)), nil
+}
+
+func decodeCrockford(src []byte) ([]byte, error) {
+	return crockfordEncoding.DecodeString(normalizeCrockford(string(src)))
+}
+
+func encodeCrockfordCheck(src []byte) ([]byte, error) {
+	s := crockfordEncoding.EncodeToString(src)
+	return []byte(s + string(crockfordCheckSymbol(s))), nil
+}
+
+func decodeCrockfordCheck(src []byte) ([]byte, error) {
+
+	s := normalizeCrockford(string(src))
+	if len(s) == 0 {
+		return nil, fmt.Errorf("crockford: missing check symbol")
+	}
+
+	check := s[len(s)-1]
+	s = s[0 : len(s)-1]
+
+	res, err := crockfordEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if strings.IndexByte(crockfordCheckAlphabet, check) == -1 {
+		return nil, fmt.Errorf("crockford: invalid check symbol %q", check)
+	}
+	if expected := crockfordCheckSymbol(s); check != expected {
+		return nil, fmt.Errorf("crockford: check symbol is %q, expected %q", check, expected)
+	}
+	return res, nil
+}
+
+// upper cases and resolves the ambiguous characters, as per spec
+func normalizeCrockford(s string) string {
+	return crockfordReplacer.Replace(strings.ToUpper(s))
+}
+
+// the check symbol encodes the value of the encoded number modulo 37
+func crockfordCheckSymbol(s string) byte {
+
+	mod := 0
+	for i := 0; i < len(s); i++ {
+		mod = (mod*32 + strings.IndexByte(crockfordAlphabet, s[i])) % 37
+	}
+	return crockfordCheckAlphabet[mod]
+}
@@ -0,0 +1,36 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCrockfordAmbiguous(t *testing.T) {
+
+	res, err := decodeCrockford([]byte("ahm6-a83h-enmp"))
+	assert.Equal(t, nil, err)
+
+	res2, err := decodeCrockford([]byte("AHM6A83HENMP"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, res2, res)
+
+	res, err = decodeCrockford([]byte("oIl"))
+	assert.Equal(t, nil, err)
+	res2, err = decodeCrockford([]byte("011"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, res2, res)
+}
+
+func TestDecodeCrockfordCheck(t *testing.T) {
+
+	_, err := decodeCrockfordCheck([]byte("AHM6A83HENMP6TS0C9S6YXVE41K6YY10D9TPTW3K41QQCSBJ41T6GS90DHGQMY90CHQPEY"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeCrockfordCheck([]byte(""))
+	assert.NotEqual(t, nil, err)
+
+	res, err := decodeCrockfordCheck([]byte("0="))
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, []byte(nil), res)
+}
@@ -0,0 +1,326 @@
+package gohash
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+const (
+	cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// rounds of sha-crypt when not given in the hash
+	shaCryptDefaultRounds = 5000
+)
+
+// cryptScheme is a crypt(3) format such as "$6$"
+type cryptScheme struct {
+	algo    string
+	newHash func() hash.Hash
+	maxSalt int
+
+	// groups of digest bytes encoded together, with -1 as a zero byte,
+	// and the number of characters of each group
+	groups [][4]int
+}
+
+var (
+	cryptSchemes = map[string]*cryptScheme{
+		"1": {"md5", md5.New, 8, [][4]int{
+			{0, 6, 12, 4}, {1, 7, 13, 4}, {2, 8, 14, 4}, {3, 9, 15, 4}, {4, 10, 5, 4},
+			{-1, -1, 11, 2},
+		}},
+		"5": {"sha256", sha256.New, 16, [][4]int{
+			{0, 10, 20, 4}, {21, 1, 11, 4}, {12, 22, 2, 4}, {3, 13, 23, 4}, {24, 4, 14, 4},
+			{15, 25, 5, 4}, {6, 16, 26, 4}, {27, 7, 17, 4}, {18, 28, 8, 4}, {9, 19, 29, 4},
+			{-1, 31, 30, 3},
+		}},
+		"6": {"sha512", sha512.New, 16, [][4]int{
+			{0, 21, 42, 4}, {22, 43, 1, 4}, {44, 2, 23, 4}, {3, 24, 45, 4}, {25, 46, 4, 4},
+			{47, 5, 26, 4}, {6, 27, 48, 4}, {28, 49, 7, 4}, {50, 8, 29, 4}, {9, 30, 51, 4},
+			{31, 52, 10, 4}, {53, 11, 32, 4}, {12, 33, 54, 4}, {34, 55, 13, 4}, {56, 14, 35, 4},
+			{15, 36, 57, 4}, {37, 58, 16, 4}, {59, 17, 38, 4}, {18, 39, 60, 4}, {40, 61, 19, 4},
+			{62, 20, 41, 4}, {-1, -1, 63, 2},
+		}},
+	}
+)
+
+// cryptHash is a parsed crypt(3) hash, such as "$1$salt$hash"
+type cryptHash struct {
+	scheme *cryptScheme
+	id     string
+	salt   []byte
+	rounds int
+
+	// rounds was given in the hash
+	customRounds bool
+	digest       []byte
+}
+
+// isCryptHash returns true if s looks like a crypt(3) hash, or a shadow
+// file line holding one
+func isCryptHash(s string) bool {
+	return strings.HasPrefix(s, "$") || strings.Contains(s, ":$")
+}
+
+// parseCryptHash parses a "$1$", "$5$" or "$6$" crypt(3) hash. A line of
+// /etc/shadow is also accepted
+func parseCryptHash(s string) (*cryptHash, error) {
+
+	s = strings.TrimSpace(s)
+	if fields := strings.Split(s, ":"); len(fields) > 1 {
+		s = fields[1]
+	}
+
+	parts := strings.Split(s, "$")
+	if len(parts) < 4 || parts[0] != "" {
+		return nil, fmt.Errorf("invalid crypt hash %q", s)
+	}
+	scheme, ok := cryptSchemes[parts[1]]
+	if !ok {
+		return nil, fmt.Errorf("unsupported crypt hash $%s$", parts[1])
+	}
+
+	res := &cryptHash{scheme: scheme, id: parts[1]}
+	parts = parts[2:]
+	if res.id != "1" {
+		res.rounds = shaCryptDefaultRounds
+		if strings.HasPrefix(parts[0], "rounds=") {
+			if len(parts) < 3 {
+				return nil, fmt.Errorf("invalid crypt hash %q", s)
+			}
+			n, err := strconv.Atoi(strings.TrimPrefix(parts[0], "rounds="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid crypt rounds %q", parts[0])
+			}
+			res.rounds = clampInt(n, 1000, 999999999)
+			res.customRounds = true
+			parts = parts[1:]
+		}
+	}
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid crypt hash %q", s)
+	}
+
+	res.salt = []byte(parts[0])
+	if len(res.salt) > scheme.maxSalt {
+		res.salt = res.salt[:scheme.maxSalt]
+	}
+
+	digest, err := scheme.decode(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	res.digest = digest
+	return res, nil
+}
+
+// String returns the hash in crypt(3) format
+func (c *cryptHash) String() string {
+
+	res := "$" + c.id + "$"
+	if c.customRounds {
+		res += "rounds=" + strconv.Itoa(c.rounds) + "$"
+	}
+	return res + string(c.salt) + "$" + c.scheme.encode(c.digest)
+}
+
+// sum returns the raw digest of key with the salt and rounds of c
+func (c *cryptHash) sum(key []byte) []byte {
+
+	if c.id == "1" {
+		return md5Crypt(key, c.salt)
+	}
+	return shaCrypt(c.scheme.newHash, key, c.salt, c.rounds)
+}
+
+// md5Crypt is the md5 based crypt(3) of poul-henning kamp
+func md5Crypt(key, salt []byte) []byte {
+
+	alt := md5.New()
+	alt.Write(key)
+	alt.Write(salt)
+	alt.Write(key)
+	altSum := alt.Sum(nil)
+
+	ctx := md5.New()
+	ctx.Write(key)
+	ctx.Write([]byte("$1$"))
+	ctx.Write(salt)
+	for n := len(key); n > 0; n -= 16 {
+		if n > 16 {
+			ctx.Write(altSum)
+		} else {
+			ctx.Write(altSum[:n])
+		}
+	}
+	for i := len(key); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(key[:1])
+		}
+	}
+	final := ctx.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		ctx := md5.New()
+		if i&1 == 1 {
+			ctx.Write(key)
+		} else {
+			ctx.Write(final)
+		}
+		if i%3 != 0 {
+			ctx.Write(salt)
+		}
+		if i%7 != 0 {
+			ctx.Write(key)
+		}
+		if i&1 == 1 {
+			ctx.Write(final)
+		} else {
+			ctx.Write(key)
+		}
+		final = ctx.Sum(final[:0])
+	}
+	return final
+}
+
+// shaCrypt is the sha256 and sha512 based crypt(3) of ulrich drepper
+func shaCrypt(newHash func() hash.Hash, key, salt []byte, rounds int) []byte {
+
+	alt := newHash()
+	alt.Write(key)
+	alt.Write(salt)
+	alt.Write(key)
+	altSum := alt.Sum(nil)
+	size := len(altSum)
+
+	ctx := newHash()
+	ctx.Write(key)
+	ctx.Write(salt)
+	n := len(key)
+	for ; n > size; n -= size {
+		ctx.Write(altSum)
+	}
+	ctx.Write(altSum[:n])
+	for i := len(key); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			ctx.Write(altSum)
+		} else {
+			ctx.Write(key)
+		}
+	}
+	sum := ctx.Sum(nil)
+
+	dp := newHash()
+	for i := 0; i < len(key); i++ {
+		dp.Write(key)
+	}
+	p := repeatBytes(dp.Sum(nil), len(key))
+
+	ds := newHash()
+	for i := 0; i < 16+int(sum[0]); i++ {
+		ds.Write(salt)
+	}
+	s := repeatBytes(ds.Sum(nil), len(salt))
+
+	for i := 0; i < rounds; i++ {
+		ctx := newHash()
+		if i&1 == 1 {
+			ctx.Write(p)
+		} else {
+			ctx.Write(sum)
+		}
+		if i%3 != 0 {
+			ctx.Write(s)
+		}
+		if i%7 != 0 {
+			ctx.Write(p)
+		}
+		if i&1 == 1 {
+			ctx.Write(sum)
+		} else {
+			ctx.Write(p)
+		}
+		sum = ctx.Sum(sum[:0])
+	}
+	return sum
+}
+
+// repeatBytes returns b repeated to n bytes
+func repeatBytes(b []byte, n int) []byte {
+
+	res := make([]byte, 0, n)
+	for len(res) < n {
+		res = append(res, b...)
+	}
+	return res[:n]
+}
+
+// encode encodes a raw digest with the crypt alphabet and byte order
+func (s *cryptScheme) encode(digest []byte) string {
+
+	res := []byte{}
+	for _, g := range s.groups {
+		w := 0
+		for _, i := range g[:3] {
+			w <<= 8
+			if i >= 0 {
+				w |= int(digest[i])
+			}
+		}
+		for n := 0; n < g[3]; n++ {
+			res = append(res, cryptAlphabet[w&0x3f])
+			w >>= 6
+		}
+	}
+	return string(res)
+}
+
+// decode decodes a digest encoded by encode
+func (s *cryptScheme) decode(src string) ([]byte, error) {
+
+	size := 0
+	for _, g := range s.groups {
+		size += g[3]
+	}
+	if len(src) != size {
+		return nil, fmt.Errorf("crypt hash should be %d characters, is %d", size, len(src))
+	}
+
+	res := make([]byte, algos[s.algo]/8)
+	for _, g := range s.groups {
+		w := 0
+		for n := g[3] - 1; n >= 0; n-- {
+			v := strings.IndexByte(cryptAlphabet, src[n])
+			if v == -1 {
+				return nil, fmt.Errorf("invalid crypt hash character %q", src[n])
+			}
+			w = w<<6 | v
+		}
+		src = src[g[3]:]
+		for n := 2; n >= 0; n-- {
+			if g[n] >= 0 {
+				res[g[n]] = byte(w)
+			}
+			w >>= 8
+		}
+	}
+	return res, nil
+}
+
+func clampInt(n, min, max int) int {
+
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
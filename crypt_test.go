@@ -0,0 +1,76 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cryptTests = []struct {
+		key  string
+		hash string
+	}{
+		{"hej", "$1$saltsalt$9YgbXqhgsf/F0P3PoZnVh1"},
+		{"", "$1$abc$Or2rbeUYTvt12aiVzMuS/."},
+		{"holejholejholejholej", "$1$12345678$ClRmaAiOZJyTynl8ZhTpy/"},
+		{"hej", "$5$saltsalt$trXRwVaKNpyKisKKHfikeRjvXPaWWbGC7MlPYzPqSY3"},
+		{"hej", "$5$rounds=1000$saltsalt$zRZKDUzWAa7ND1DxC05du/skF/d6N.ufxSfL.v2EbC."},
+		{"a very long password that is longer than thirty two", "$5$rounds=1000$ab$SV4SoKVfDg7RhT9ktLjkqNlnSDbVVeMVp5r9jVd4jS7"},
+		{"hej", "$6$saltsalt$pYUBc7iAF8HU6KTJqhuUaWxGAiNvPW1L/9fbANRLbiMASAzUNqxZlz9txi8mrhEpYIFb8QV5U9/Na2oKCUIqL."},
+		{"hej", "$6$rounds=1000$saltsalt$AWbwEUhHSNhfzoP5SL.ddQ4wJB41xmciS2lCK9VH0ySRHsop8t4w7dt65B90l.vFQOhe7VxvyQjrPbS9wZtdE/"},
+		{"a very long password that is longer than sixty four bytes in total, yes", "$6$rounds=1000$0123456789abcdef$NAF3aImMQYGgCn4ALRDCblnlk/fzDB9ddbPdh26caGNKayRwbfamDUDEYiC2PXqbSJIkKoCn8f5e8vVtxl2441"},
+	}
+)
+
+func TestCryptHash(t *testing.T) {
+
+	for _, test := range cryptTests {
+		c, err := parseCryptHash(test.hash)
+		assert.Equal(t, nil, err, test.hash)
+		assert.Equal(t, test.hash, c.String())
+		assert.Equal(t, c.digest, c.sum([]byte(test.key)), test.hash)
+	}
+}
+
+func TestParseCryptHashShadow(t *testing.T) {
+
+	c, err := parseCryptHash("root:$1$saltsalt$9YgbXqhgsf/F0P3PoZnVh1:18000:0:99999:7:::\n")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "$1$saltsalt$9YgbXqhgsf/F0P3PoZnVh1", c.String())
+}
+
+func TestParseCryptHashInvalid(t *testing.T) {
+
+	tests := map[string]string{
+		"$2a$10$abc":                        "unsupported crypt hash $2a$",
+		"$1$salt":                           `invalid crypt hash "$1$salt"`,
+		"$1$salt$abc":                       "crypt hash should be 22 characters, is 3",
+		"$1$salt$9YgbXqhgsf/F0P3PoZnV!1":    `invalid crypt hash character '!'`,
+		"$5$rounds=x$salt$abc":              `invalid crypt rounds "rounds=x"`,
+		"$6$rounds=1000$saltsalt":           `invalid crypt hash "$6$rounds=1000$saltsalt"`,
+		"$6$rounds=1000$saltsalt$abc$extra": `invalid crypt hash "$6$rounds=1000$saltsalt$abc$extra"`,
+	}
+	for hash, expected := range tests {
+		_, err := parseCryptHash(hash)
+		assert.Equal(t, expected, err.Error(), hash)
+	}
+}
+
+func TestHasherCrypt(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.Workers(2)
+	hasher.ExpectedHash("$5$rounds=1000$saltsalt$zRZKDUzWAa7ND1DxC05du/skF/d6N.ufxSfL.v2EbC.")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+	assert.Equal(t, "sha256", hasher.algo)
+
+	hasher.Template("md5($pass)")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "crypt hash and template or hmac dont mix", err.Error())
+}
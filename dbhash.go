@@ -0,0 +1,69 @@
+package gohash
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// the template of postgres md5 password hashes, salted by user name
+	postgresTemplate = "md5($pass.$salt)"
+)
+
+// PostgresMD5 searches for the password of a postgres "md5" hash, which is
+// md5 of the password followed by the user name
+func (h *Hasher) PostgresMD5(user string) {
+	h.Template(postgresTemplate)
+	h.Salt(user)
+}
+
+// mysql323Sum is the OLD_PASSWORD() hash of mysql before 4.1. Spaces and
+// tabs in the password are ignored
+func mysql323Sum(b *[]byte) *[]byte {
+
+	nr, nr2, add := uint32(1345345333), uint32(0x12345671), uint32(7)
+	for _, c := range *b {
+		if c == ' ' || c == '\t' {
+			continue
+		}
+		tmp := uint32(c)
+		nr ^= ((nr&63)+add)*tmp + nr<<8
+		nr2 += nr2<<8 ^ nr
+		add += tmp
+	}
+	res := make([]byte, 8)
+	binary.BigEndian.PutUint32(res, nr&0x7fffffff)
+	binary.BigEndian.PutUint32(res[4:], nr2&0x7fffffff)
+	return &res
+}
+
+// mysql41Sum is the PASSWORD() hash of mysql 4.1 and later, sha1 of the
+// sha1 digest, written as "*" and upper case hex
+func mysql41Sum(b *[]byte) *[]byte {
+
+	first := sha1.Sum(*b)
+	second := sha1.Sum(first[:])
+	res := second[:]
+	return &res
+}
+
+// databaseFormat returns the algo and the hex digest of hashes in the
+// formats of mysql ("*" and 40 hex digits) and postgres ("md5" and 32 hex
+// digits), or "" if s is neither
+func databaseFormat(s string) (string, string) {
+
+	s = strings.TrimSpace(s)
+	isHex := func(s string, n int) bool {
+		_, err := hex.DecodeString(s)
+		return len(s) == n && err == nil
+	}
+	if strings.HasPrefix(s, "*") && isHex(s[1:], 40) {
+		return "mysql41", s[1:]
+	}
+	if strings.HasPrefix(s, "md5") && isHex(s[3:], 32) {
+		return "postgres", s[3:]
+	}
+	return "", ""
+}
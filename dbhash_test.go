@@ -0,0 +1,93 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMysqlSums(t *testing.T) {
+
+	b := []byte("password")
+	assert.Equal(t, "5d2e19393cc5ef67", hex.EncodeToString(*mysql323Sum(&b)))
+	assert.Equal(t, "2470c0c06dee42fd1618bb99005adca2ec9d1e19", hex.EncodeToString(*mysql41Sum(&b)))
+
+	// spaces and tabs are ignored by mysql323
+	spaced := []byte("pass word\t")
+	assert.Equal(t, *mysql323Sum(&b), *mysql323Sum(&spaced))
+}
+
+func TestDatabaseFormat(t *testing.T) {
+
+	algo, digest := databaseFormat("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	assert.Equal(t, "mysql41", algo)
+	assert.Equal(t, "2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19", digest)
+
+	algo, digest = databaseFormat(" md532e12f215ba27cb750c9e093ce4b5127\n")
+	assert.Equal(t, "postgres", algo)
+	assert.Equal(t, "32e12f215ba27cb750c9e093ce4b5127", digest)
+
+	algo, _ = databaseFormat("*2470")
+	assert.Equal(t, "", algo)
+	algo, _ = databaseFormat(md5Hej)
+	assert.Equal(t, "", algo)
+}
+
+func TestHasherMysql41(t *testing.T) {
+
+	// the algo is implied by the format
+	hasher := NewHasher()
+	hasher.ExpectedHash("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	hasher.AllowedKeys("adoprsw")
+	hasher.Prefix("pass")
+	hasher.Length(4)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "password", res)
+	assert.Equal(t, "mysql41", hasher.algo)
+}
+
+func TestHasherMysql323(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("mysql323")
+	hasher.ExpectedHash("5d2e19393cc5ef67")
+	hasher.AllowedKeys("adoprsw")
+	hasher.Prefix("pass")
+	hasher.Length(4)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "password", res)
+}
+
+func TestHasherPostgresMD5(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.ExpectedHash("md532e12f215ba27cb750c9e093ce4b5127")
+	hasher.AllowedKeys("adoprsw")
+	hasher.Prefix("pass")
+	hasher.Length(4)
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "algo unset, postgres md5 hashes need the user name, see PostgresMD5", err.Error())
+
+	hasher.PostgresMD5("postgres")
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "password", res)
+}
+
+func TestAlgosForMysqlHash(t *testing.T) {
+
+	res, err := AlgosForHash("*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"mysql41"}, res)
+
+	res, err = AlgosForHash("2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19")
+	assert.Equal(t, nil, err)
+	assert.Contains(t, res, "sha1")
+	assert.Contains(t, res, "mysql41")
+}
@@ -0,0 +1,105 @@
+package gohash
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// DecodeError is returned when decoding fails at a known position, so the
+// offending character can be pointed out
+type DecodeError struct {
+	Encoding string
+	// Offset is the byte offset of Char in the input
+	Offset int
+	Char   rune
+	Reason string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: %s %q at offset %d", e.Encoding, e.Reason, e.Char, e.Offset)
+}
+
+func newDecodeError(encoding string, src []byte, offset int, reason string) *DecodeError {
+
+	char := rune(0)
+	if offset < len(src) {
+		char = []rune(string(src[offset:]))[0]
+	}
+	return &DecodeError{
+		Encoding: encoding,
+		Offset:   offset,
+		Char:     char,
+		Reason:   reason,
+	}
+}
+
+var (
+	// alphabets used to locate invalid characters when a decoder doesn't
+	// report them itself. White space and padding are skipped
+	decodeAlphabets = map[string]string{
+		"base32":    "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567",
+		"base32hex": "0123456789ABCDEFGHIJKLMNOPQRSTUV",
+		"base64":    "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/",
+		"crockford": "0123456789ABCDEFGHJKMNPQRSTVWXYZabcdefghjkmnpqrstvwxyzIiLlOo-",
+		"hex":       "0123456789abcdefABCDEF",
+		"hexup":     "0123456789abcdefABCDEF",
+		"z85":       "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#",
+		"zbase32":   "ybndrfg8ejkmcpqxot1uwisza345h769",
+	}
+)
+
+// splitOffsets splits s at each sep, or around white space if sep is
+// empty, returning the parts and the byte offset of each in s
+func splitOffsets(s string, sep string) ([]string, []int) {
+
+	parts, offsets := []string{}, []int{}
+	if sep == "" {
+		start := -1
+		for i, r := range s {
+			if !unicode.IsSpace(r) {
+				if start == -1 {
+					start = i
+				}
+				continue
+			}
+			if start != -1 {
+				parts, offsets = append(parts, s[start:i]), append(offsets, start)
+				start = -1
+			}
+		}
+		if start != -1 {
+			parts, offsets = append(parts, s[start:]), append(offsets, start)
+		}
+		return parts, offsets
+	}
+
+	for off := 0; ; {
+		i := strings.Index(s[off:], sep)
+		if i == -1 {
+			return append(parts, s[off:]), append(offsets, off)
+		}
+		parts, offsets = append(parts, s[off:off+i]), append(offsets, off)
+		off += i + len(sep)
+	}
+}
+
+// locateDecodeError turns err into a *DecodeError if the first invalid
+// character of src can be found
+func locateDecodeError(encoding string, src []byte, err error) error {
+
+	if _, ok := err.(*DecodeError); ok {
+		return err
+	}
+	alphabet, ok := decodeAlphabets[encoding]
+	if !ok {
+		return err
+	}
+	for i, r := range string(src) {
+		if unicode.IsSpace(r) || r == '=' || strings.ContainsRune(alphabet, r) {
+			continue
+		}
+		return newDecodeError(encoding, src, i, "invalid character")
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package gohash
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeErrorPosition(t *testing.T) {
+
+	for _, tc := range []struct {
+		encoding string
+		input    string
+		offset   int
+		char     rune
+	}{
+		{"hex", "d41d8cd9X8f00", 8, 'X'},
+		{"hex", "d4 1d 8c d9 ! 8f", 12, '!'},
+		{"base64", "aGVs#bG8=", 4, '#'},
+		{"base32", "NBSWY3D1", 7, '1'},
+		{"z85", "Hello~orld", 5, '~'},
+		{"base45", "BB8ab", 3, 'a'},
+		{"rfc1924", "4)+k&C#VzJ4br>0wv%Y\"", 19, '"'},
+		{"bech32", "a12ueb5l", 5, 'b'},
+		{"base100", "👋👋xxxx", 8, 'x'},
+		{"base122", "ab\xc2", 2, utf8.RuneError},
+		{"base122", "ab\xdb\x80", 2, '\u06c0'},
+		{"base45", "BB8GGW", 3, 'G'},
+		{"base65536", "驨\xff", 3, utf8.RuneError},
+		{"base65536", "ᕤ驨", 3, '驨'},
+		{"decimal", "84 104 256", 7, '2'},
+		{"octal", "0124\t0150  09", 11, '0'},
+		{"binary", "01010100 0110x000", 9, '0'},
+		{"fingerprint", " SHA256 Fingerprint=AA:BX:CC", 23, 'B'},
+		{"fingerprint", "aa::cc", 3, ':'},
+		{"pgpwords", "topmost  nope", 9, 'n'},
+		{"uuencode", "begin 644 f\r\n#8a)C\r\n", 15, 'a'},
+		{"xxencode", "+\n1~", 3, '~'},
+	} {
+		_, err := NewCoder(tc.encoding).Decode([]byte(tc.input))
+		decodeErr, ok := err.(*DecodeError)
+		if assert.Equal(t, true, ok, tc.encoding+" "+tc.input) {
+			assert.Equal(t, tc.encoding, decodeErr.Encoding)
+			assert.Equal(t, tc.offset, decodeErr.Offset, tc.input)
+			assert.Equal(t, tc.char, decodeErr.Char, tc.input)
+		}
+	}
+}
+
+func TestDecodeErrorPositionStrict(t *testing.T) {
+
+	for _, tc := range []struct {
+		encoding string
+		input    string
+		offset   int
+		char     rune
+	}{
+		{"percent", "50% off", 2, '%'},
+		{"percent", "off%2", 3, '%'},
+		{"hex", "abCD", 2, 'C'},
+		{"hex", "ABcd", 2, 'c'},
+		{"octal", "0124 124", 5, '1'},
+		{"decimal", "84  104", 3, ' '},
+	} {
+		coder := NewCoder(tc.encoding)
+		coder.Strict(true)
+		_, err := coder.Decode([]byte(tc.input))
+		decodeErr, ok := err.(*DecodeError)
+		if assert.Equal(t, true, ok, tc.encoding+" "+tc.input) {
+			assert.Equal(t, tc.encoding, decodeErr.Encoding)
+			assert.Equal(t, tc.offset, decodeErr.Offset, tc.input)
+			assert.Equal(t, tc.char, decodeErr.Char, tc.input)
+		}
+	}
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+
+	_, err := NewCoder("hex").Decode([]byte("d4x1"))
+	assert.Equal(t, `hex: invalid character 'x' at offset 2`, err.Error())
+}
+
+func TestDecodeErrorStrictWhiteSpace(t *testing.T) {
+
+	coder := NewCoder("base64")
+	coder.Strict(true)
+	_, err := coder.Decode([]byte("aGVs bG8="))
+	decodeErr, ok := err.(*DecodeError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 4, decodeErr.Offset)
+	assert.Equal(t, ' ', decodeErr.Char)
+}
+
+func TestDecodeErrorUnknownPosition(t *testing.T) {
+
+	// odd length, every character is valid
+	_, err := NewCoder("hex").Decode([]byte("d41"))
+	assert.NotEqual(t, nil, err)
+	_, ok := err.(*DecodeError)
+	assert.Equal(t, false, ok)
+}
+
+func TestCustomAlphabetDecodeErrorOffset(t *testing.T) {
+
+	coder, _ := NewCoderWithAlphabet(3, "äöü")
+	_, err := coder.Decode([]byte("äöx"))
+	decodeErr, ok := err.(*DecodeError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 4, decodeErr.Offset)
+	assert.Equal(t, 'x', decodeErr.Char)
+}
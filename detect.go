@@ -0,0 +1,212 @@
+package gohash
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// scores used to rank detected encodings
+	scoreChecksum    = 2000
+	scoreStructured  = 1500
+	scoreAlphabet    = 1000
+	scoreCaseChanged = -100
+	scoreDigestLen   = 50
+	scorePrintable   = 25
+)
+
+var (
+	// encodings whose checksum passing is strong evidence
+	checksumEncodings = map[string]bool{
+		"base58check":   true,
+		"bech32":        true,
+		"bech32m":       true,
+		"bip39":         true,
+		"crockford-chk": true,
+		"ihex":          true,
+		"srec":          true,
+	}
+
+	// encodings with a distinct structure, such as words or separators
+	structuredEncodings = map[string]bool{
+		"bubblebabble": true,
+		"c-array":      true,
+		"fingerprint":  true,
+		"go-bytes":     true,
+		"hex-colons":   true,
+		"hexdump":      true,
+		"morse":        true,
+		"pgpwords":     true,
+		"proquint":     true,
+		"python-bytes": true,
+		"uuencode":     true,
+		"xxencode":     true,
+	}
+
+	// alphabet sizes, a match in a smaller alphabet is more specific.
+	// Encodings not listed are ranked last
+	alphabetSizes = map[string]int{
+		"binary":    2,
+		"octal":     8,
+		"decimal":   10,
+		"hex":       16,
+		"hexup":     16,
+		"base32":    32,
+		"base32hex": 32,
+		"crockford": 32,
+		"zbase32":   32,
+		"base36":    36,
+		"base45":    45,
+		"base58":    58,
+		"base64":    64,
+		"ascii85":   85,
+		"rfc1924":   85,
+		"z85":       85,
+		"base91":    91,
+		"base122":   122,
+		"base100":   256,
+		"uu":        64,
+	}
+
+	// byte lengths of common digests
+	digestLengths = map[int]bool{
+		4: true, 8: true, 16: true, 20: true, 28: true, 32: true, 48: true, 64: true,
+	}
+
+	// escapes required for encodings that pass plain text through as is
+	requiredEscapes = map[string]string{
+		"percent":         "%",
+		"quotedprintable": "=",
+	}
+)
+
+// Candidate is a successful decoding of a string
+type Candidate struct {
+	Encoding string
+	Data     []byte
+	// Score ranks how plausible the encoding is, higher is better
+	Score int
+}
+
+// DecodeAny decodes s with every encoding it plausibly is in, most likely
+// first
+func DecodeAny(s string) []Candidate {
+
+	s = strings.TrimSpace(s)
+	res := []Candidate{}
+	for _, encoding := range AvailableEncodings() {
+		if score, data, ok := detectScore(encoding, s); ok {
+			res = append(res, Candidate{encoding, data, score})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].Encoding < res[j].Encoding
+	})
+	return res
+}
+
+// DetectEncoding returns the encodings s plausibly is in, most likely first
+func DetectEncoding(s string) []string {
+
+	candidates := DecodeAny(s)
+	res := make([]string, len(candidates))
+	for i, c := range candidates {
+		res[i] = c.Encoding
+	}
+	return res
+}
+
+// detectScore decodes s and encodes the result again. Only encodings
+// where this round-trips, ignoring case, are plausible
+func detectScore(encoding string, s string) (score int, decoded []byte, ok bool) {
+
+	if s == "" {
+		return 0, nil, false
+	}
+	if esc, found := requiredEscapes[encoding]; found && !strings.Contains(s, esc) {
+		return 0, nil, false
+	}
+
+	// third-party decoders are not all robust against garbage
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if decoded, ok := detectSegwit(encoding, s); ok {
+		return scoreChecksum, decoded, true
+	}
+
+	coder := NewCoder(encoding)
+	decoded, err := coder.Decode([]byte(s))
+	if err != nil || len(decoded) == 0 {
+		return 0, nil, false
+	}
+	encoded, err := coder.Encode(decoded)
+	if err != nil {
+		return 0, nil, false
+	}
+
+	encoded = bytes.TrimSpace(encoded)
+	switch {
+	case string(encoded) == s:
+	case strings.EqualFold(string(encoded), s):
+		score += scoreCaseChanged
+	default:
+		return 0, nil, false
+	}
+
+	switch {
+	case checksumEncodings[encoding]:
+		score += scoreChecksum
+	case structuredEncodings[encoding]:
+		score += scoreStructured
+	default:
+		if size, found := alphabetSizes[encoding]; found {
+			score += scoreAlphabet - size
+		}
+	}
+	if digestLengths[len(decoded)] {
+		score += scoreDigestLen
+	}
+	if isPrintableText(decoded) {
+		score += scorePrintable
+	}
+	return score, decoded, true
+}
+
+// detectSegwit detects segwit addresses, which the bech32 coders can't
+// decode as the witness version isn't byte aligned with the program
+func detectSegwit(encoding string, s string) ([]byte, bool) {
+
+	if encoding != "bech32" && encoding != "bech32m" {
+		return nil, false
+	}
+	_, version, program, err := DecodeSegwitAddress(s)
+	if err != nil || (version == 0) != (encoding == "bech32") {
+		return nil, false
+	}
+	return program, true
+}
+
+// isPrintableText returns wether b is utf8 text without control characters
+func isPrintableText(b []byte) bool {
+
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectEncoding(t *testing.T) {
+
+	for s, expected := range map[string]string{
+		"d41d8cd98f00b204e9800998ecf8427e":                "hex",
+		"D41D8CD98F00B204E9800998ECF8427E":                "hexup",
+		"1B2M2Y8AsgTpgAmY7PhCfg==":                        "base64",
+		"2QOYZWMPACZAJ2MABGMOZ6CCPY======":                "base32",
+		"d4:1d:8c:d9:8f:00:b2:04:e9:80:09:98:ec:f8:42:7e": "hex-colons",
+		"1111111111111111111114oLvT2":                     "base58check",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4":      "bech32",
+		"01110100 01100101":                               "binary",
+		"lusab-babad":                                     "proquint",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about": "bip39",
+	} {
+		res := DetectEncoding(s)
+		if assert.NotEqual(t, 0, len(res), s) {
+			assert.Equal(t, expected, res[0], s)
+		}
+	}
+}
+
+func TestDetectEncodingRanksAlternatives(t *testing.T) {
+
+	res := DetectEncoding("deadbeef")
+	assert.Equal(t, "hex", res[0])
+	assert.Contains(t, res, "base64")
+}
+
+func TestDetectEncodingNone(t *testing.T) {
+
+	assert.Equal(t, []string{}, DetectEncoding(""))
+	assert.Equal(t, []string{}, DetectEncoding("\x00\x01"))
+}
+
+func TestDecodeAny(t *testing.T) {
+
+	res := DecodeAny("aGVsbG8gd29ybGQ=")
+	if assert.NotEqual(t, 0, len(res)) {
+		assert.Equal(t, "base64", res[0].Encoding)
+		assert.Equal(t, []byte("hello world"), res[0].Data)
+	}
+	for i := 1; i < len(res); i++ {
+		assert.True(t, res[i-1].Score >= res[i].Score)
+	}
+}
+
+func TestDecodeAnySegwit(t *testing.T) {
+
+	res := DecodeAny("bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0")
+	if assert.NotEqual(t, 0, len(res)) {
+		assert.Equal(t, "bech32m", res[0].Encoding)
+		assert.Equal(t, 32, len(res[0].Data))
+	}
+}
@@ -2,8 +2,9 @@ package gohash
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
-	"sort"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ type Dictionary struct {
 	possibleAlgos []string
 	prefix        string
 	suffix        string
+	progress      io.Writer
 
 	// runtime stats
 	try    uint64
@@ -43,6 +45,7 @@ func NewDictionary(dictFileName string) (*Dictionary, error) {
 	return &Dictionary{
 		dictFileName: dictFileName,
 		lines:        lines,
+		progress:     os.Stdout,
 	}, nil
 }
 
@@ -52,10 +55,13 @@ func (d *Dictionary) Prefix(s string) { d.prefix = s }
 // Suffix sets a fixed suffix
 func (d *Dictionary) Suffix(s string) { d.suffix = s }
 
-// ExpectedHash sets the expected hash
+// ProgressWriter sets where the progress is written every second, default
+// os.Stdout. Nil disables it
+func (d *Dictionary) ProgressWriter(w io.Writer) { d.progress = w }
+
+// ExpectedHash sets the expected hash, in hex or base64
 func (d *Dictionary) ExpectedHash(expected string) {
-	tmp, _ := decodeHex([]byte(expected))
-	d.expected = tmp[:]
+	d.expected, _ = decodeDigest(expected)
 }
 
 // Find searches for a cleartext for expected hash
@@ -70,7 +76,18 @@ func (d *Dictionary) Find() (string, string, error) {
 
 	buf := make([]byte, 256)
 
-	go d.statusReport()
+	if d.progress != nil {
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			d.statusReport(done)
+		}()
+		defer func() {
+			close(done)
+			<-stopped
+		}()
+	}
 
 	for _, line := range d.lines {
 		if line == "" {
@@ -103,15 +120,22 @@ func (d *Dictionary) Find() (string, string, error) {
 	return "", "", nil
 }
 
-func (d *Dictionary) statusReport() {
+// statusReport writes the progress every second until done is closed
+func (d *Dictionary) statusReport(done <-chan struct{}) {
 
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
 	for {
-		time.Sleep(1 * time.Second)
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
 
 		mutex.Lock()
 		d.tick++
 		avg := d.try / d.tick
-		fmt.Printf("%s ~%d/s %s\n", d.algo, avg, string(d.buffer))
+		fmt.Fprintf(d.progress, "%s ~%d/s %s\n", d.algo, avg, string(d.buffer))
 		mutex.Unlock()
 	}
 }
@@ -138,18 +162,9 @@ func (d *Dictionary) equals(algo string, buffer *[]byte) bool {
 func (d *Dictionary) decidePossibleAlgos() error {
 
 	bitSize := len(d.expected) * 8
-
-	for algo, algoBitSize := range algos {
-		if algoBitSize == bitSize {
-			d.possibleAlgos = append(d.possibleAlgos, algo)
-		}
-	}
-
+	d.possibleAlgos = algosOfSize(bitSize)
 	if len(d.possibleAlgos) == 0 {
 		return fmt.Errorf("No known hashes uses a bitsize of %d", bitSize)
 	}
-
-	sort.Strings(d.possibleAlgos)
-
 	return nil
 }
@@ -0,0 +1,65 @@
+package gohash
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// AllowedBytes sets the allowed keys to any byte values, such as for
+// binary preimages
+func (h *Hasher) AllowedBytes(b []byte) {
+	h.AllowedKeys(string(b))
+}
+
+// Unescape decodes the escapes \xNN, \0, \t, \n, \r and \\ in s, so keys,
+// prefixes and suffixes with any byte can be typed
+func Unescape(s string) ([]byte, error) {
+
+	res := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			res = append(res, s[i])
+			continue
+		}
+		if i+1 == len(s) {
+			return nil, fmt.Errorf("incomplete escape at end of %q", s)
+		}
+		i++
+		switch s[i] {
+		case '\\':
+			res = append(res, '\\')
+		case '0':
+			res = append(res, 0)
+		case 't':
+			res = append(res, '\t')
+		case 'n':
+			res = append(res, '\n')
+		case 'r':
+			res = append(res, '\r')
+		case 'x':
+			if i+2 >= len(s) {
+				return nil, fmt.Errorf("incomplete escape \\x at %d of %q", i-1, s)
+			}
+			b, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape \\x%s", s[i+1:i+3])
+			}
+			res = append(res, byte(b))
+			i += 2
+		default:
+			return nil, fmt.Errorf("unknown escape \\%c", s[i])
+		}
+	}
+	return res, nil
+}
+
+// textOrBytes returns b as text when it is valid utf-8, and else as bytes,
+// which encoding/json keeps as is
+func textOrBytes(b []byte) (string, []byte) {
+
+	if utf8.Valid(b) {
+		return string(b), nil
+	}
+	return "", b
+}
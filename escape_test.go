@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnescape(t *testing.T) {
+
+	tests := []struct {
+		in  string
+		out []byte
+	}{
+		{"abc", []byte("abc")},
+		{`\x00\xff\xAB`, []byte{0, 0xff, 0xab}},
+		{`a\\b\0\t\n\r`, []byte("a\\b\x00\t\n\r")},
+		{"", []byte{}},
+	}
+	for _, test := range tests {
+		res, err := Unescape(test.in)
+		assert.Equal(t, nil, err, test.in)
+		assert.Equal(t, test.out, res, test.in)
+	}
+
+	for in, msg := range map[string]string{
+		`abc\`: `incomplete escape at end of "abc\\"`,
+		`\x1`:  `incomplete escape \x at 0 of "\\x1"`,
+		`\xzz`: `invalid escape \xzz`,
+		`a\qb`: `unknown escape \q`,
+	} {
+		_, err := Unescape(in)
+		if assert.NotEqual(t, nil, err, in) {
+			assert.Equal(t, msg, err.Error(), in)
+		}
+	}
+}
+
+func TestHasherAllowedBytes(t *testing.T) {
+
+	key := []byte{0xff, 0x00, 0x7f}
+	sum := md5.Sum(append([]byte{0x01}, key...))
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(hex.EncodeToString(sum[:]))
+	hasher.AllowedBytes([]byte{0x00, 0x7f, 0xff})
+	hasher.Prefix("\x01")
+	hasher.Length(3)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, string(append([]byte{0x01}, key...)), res)
+}
+
+func TestHasherSaveStateBinary(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	hasher.AllowedBytes([]byte{0x00, 0x80, 0xff})
+	hasher.Prefix("\xfe")
+	hasher.Suffix("end")
+	hasher.Length(3)
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveState(&buf))
+
+	loaded := NewHasher()
+	assert.Equal(t, nil, loaded.LoadState(&buf))
+	assert.Equal(t, hasher.allowedKeys, loaded.allowedKeys)
+	assert.Equal(t, "\xfe", loaded.prefix)
+	assert.Equal(t, "end", loaded.suffix)
+}
@@ -0,0 +1,146 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// FindFromChannel tries each candidate received from ch, with any prefix
+// and suffix, until ch is closed. Candidates are shared between the
+// workers, so external generators can use the verification of the Hasher
+func (h *Hasher) FindFromChannel(ch <-chan []byte) (string, error) {
+	return h.FindFromChannelContext(context.Background(), ch)
+}
+
+// FindFromChannelContext is like FindFromChannel, but stops with the error
+// of ctx when it is done
+func (h *Hasher) FindFromChannelContext(ctx context.Context, ch <-chan []byte) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findFromChannel(ctx, ch)
+	})
+}
+
+func (h *Hasher) findFromChannel(ctx context.Context, ch <-chan []byte) (string, error) {
+
+	if err := h.verifyTarget(); err != nil {
+		return "", err
+	}
+
+	h.started = time.Now()
+	defer h.startStatusReport()()
+
+	workers := h.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+	found := make(chan string, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if res, ok := h.searchChannel(search, w, ch); ok {
+				found <- res
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	res, ok := <-found
+	cancel()
+	for range found {
+		// wait for the other workers to stop
+	}
+	if ok {
+		return res, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return "", h.notFound()
+}
+
+// searchChannel tries candidates from ch as worker w, until it is closed
+// or ctx is done
+func (h *Hasher) searchChannel(ctx context.Context, w int, ch <-chan []byte) (string, bool) {
+
+	buf := []byte(h.prefix)
+	batch := h.newBatch(w)
+	tries := uint64(0)
+	defer func() {
+		h.reportProgress(buf, tries%statusInterval)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", false
+		case candidate, ok := <-ch:
+			if !ok {
+				return "", false
+			}
+			buf = append(append(buf[:len(h.prefix)], candidate...), h.suffix...)
+			if batch.equals(buf) {
+				return string(buf), true
+			}
+			tries++
+			if tries%statusInterval == 0 {
+				h.reportProgress(buf, statusInterval)
+			}
+		}
+	}
+}
+
+// FindFromReader tries each line of r as candidate, such as the output of
+// "hashcat --stdout". Unlike FindFromWordlist, no rules are applied and the
+// candidates are shared between the workers
+func (h *Hasher) FindFromReader(r io.Reader) (string, error) {
+	return h.FindFromReaderContext(context.Background(), r)
+}
+
+// FindFromReaderContext is like FindFromReader, but stops with the error
+// of ctx when it is done
+func (h *Hasher) FindFromReaderContext(ctx context.Context, r io.Reader) (string, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan []byte, statusInterval)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+			select {
+			case ch <- append([]byte{}, line...):
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	res, err := h.FindFromChannelContext(ctx, ch)
+	if err != nil {
+		select {
+		case e := <-readErr:
+			if e != nil {
+				// the whole input was not searched
+				return "", e
+			}
+		default:
+		}
+	}
+	return res, err
+}
@@ -0,0 +1,89 @@
+package gohash
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFromChannel(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Workers(4)
+	hasher.ExpectedHash(md5Hej)
+
+	ch := make(chan []byte)
+	go func() {
+		for _, s := range []string{"foo", "bar", "hej", "baz"} {
+			ch <- []byte(s)
+		}
+		close(ch)
+	}()
+
+	res, err := hasher.FindFromChannel(ch)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestFindFromChannelPrefix(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Prefix("pre-")
+	hasher.ExpectedHash("9acd325de8475df7c84664e1f2240e13")
+
+	ch := make(chan []byte, 2)
+	ch <- []byte("hej")
+	ch <- []byte("tex")
+	close(ch)
+
+	res, err := hasher.FindFromChannel(ch)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "pre-tex", res)
+}
+
+func TestFindFromChannelContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := hasher.FindFromChannelContext(ctx, make(chan []byte))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestFindFromReader(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Workers(2)
+	hasher.ExpectedHash(md5Hej)
+
+	res, err := hasher.FindFromReader(strings.NewReader("foo\r\nbar\r\nhej\r\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+
+	_, err = hasher.FindFromReader(strings.NewReader("foo\nbar"))
+	assert.Equal(t, "no match found", err.Error())
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestFindFromReaderError(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+
+	_, err := hasher.FindFromReader(io.MultiReader(strings.NewReader("foo\n"), failingReader{}))
+	assert.Equal(t, "read failed", err.Error())
+}
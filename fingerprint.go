@@ -0,0 +1,59 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// encodeHexColons encodes src as colon separated lowercase hex, as shown
+// by ssh-keygen: "aa:bb:cc"
+func encodeHexColons(src []byte) ([]byte, error) {
+	return []byte(strings.Join(hexPairs(src), ":")), nil
+}
+
+// encodeFingerprint encodes src as colon separated uppercase hex, as shown
+// by certificate viewers: "AA:BB:CC"
+func encodeFingerprint(src []byte) ([]byte, error) {
+	return []byte(strings.ToUpper(strings.Join(hexPairs(src), ":"))), nil
+}
+
+// decodeHexColons decodes colon separated hex in either case. A leading
+// algorithm label, as in "MD5:aa:bb" or "SHA256 Fingerprint=AA:BB", is
+// ignored
+func decodeHexColons(src []byte) ([]byte, error) {
+
+	s := strings.TrimLeftFunc(string(src), unicode.IsSpace)
+	start := len(src) - len(s)
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	if i := strings.LastIndexByte(s, '='); i != -1 {
+		s = s[i+1:]
+		start += i + 1
+	}
+	parts, offsets := splitOffsets(s, ":")
+	if len(parts) > 1 && len(parts[0]) != 2 {
+		parts, offsets = parts[1:], offsets[1:]
+	}
+	if len(parts) == 1 && parts[0] == "" {
+		return []byte{}, nil
+	}
+
+	res := make([]byte, len(parts))
+	for i, part := range parts {
+		b, err := hex.DecodeString(part)
+		if len(part) != 2 || err != nil {
+			return nil, newDecodeError("fingerprint", src, start+offsets[i], "invalid byte")
+		}
+		res[i] = b[0]
+	}
+	return res, nil
+}
+
+func hexPairs(src []byte) []string {
+
+	res := make([]string, len(src))
+	for i, b := range src {
+		res[i] = hex.EncodeToString([]byte{b})
+	}
+	return res
+}
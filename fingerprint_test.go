@@ -0,0 +1,31 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeHexColons(t *testing.T) {
+
+	for s, expected := range map[string][]byte{
+		"de:ad:be:ef":                    {0xde, 0xad, 0xbe, 0xef},
+		"DE:AD:be:EF\n":                  {0xde, 0xad, 0xbe, 0xef},
+		"MD5:de:ad:be:ef":                {0xde, 0xad, 0xbe, 0xef},
+		"SHA256 Fingerprint=DE:AD:BE:EF": {0xde, 0xad, 0xbe, 0xef},
+		"sha1 Fingerprint=01":            {0x01},
+		"":                               {},
+	} {
+		res, err := decodeHexColons([]byte(s))
+		assert.Equal(t, nil, err, s)
+		assert.Equal(t, expected, res, s)
+	}
+}
+
+func TestDecodeHexColonsInvalid(t *testing.T) {
+
+	for _, s := range []string{"de:a:be", "de:ad:", "de:xx", "dead:beef"} {
+		_, err := decodeHexColons([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
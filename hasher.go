@@ -1,27 +1,156 @@
 package gohash
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
+	"io"
+	"math/big"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	// tries between updates of the shared status
+	statusInterval = 1024
+)
+
+var (
+	// sums of the most common algos, appending to dst, avoiding the
+	// allocations of the generic hashers
+	fastSums = map[string]func(dst, buf []byte) []byte{
+		"md5": func(dst, buf []byte) []byte {
+			sum := md5.Sum(buf)
+			return append(dst, sum[:]...)
+		},
+		"sha1": func(dst, buf []byte) []byte {
+			sum := sha1.Sum(buf)
+			return append(dst, sum[:]...)
+		},
+		"sha256": func(dst, buf []byte) []byte {
+			sum := sha256.Sum256(buf)
+			return append(dst, sum[:]...)
+		},
+		"sha512": func(dst, buf []byte) []byte {
+			sum := sha512.Sum512(buf)
+			return append(dst, sum[:]...)
+		},
+	}
+)
+
 // Hasher is used to find cleartext for checksum in `expected`, using algorithm in `algo`
 type Hasher struct {
 	algo        string
 	prefix      string
 	suffix      string
 	expected    []byte
+	expectedErr error
 	minLength   int
 	maxLength   int
 	allowedKeys []byte
 	reverse     bool
+	workers     int
+	template    string
+	salt        string
+	iterations  int
+	iterateHex  bool
+	cryptHash   string
+	hmacKey     *string
+	hmacMessage *string
+	sum         func(*[]byte) *[]byte
+
+	// how candidates are hashed, see batchHasher
+	stateHash      func() hash.Hash
+	fastSum        func(dst, buf []byte) []byte
+	midstate       bool
+	implementation string
+
+	// the algo implied by the format of the expected hash, such as
+	// "mysql41" for "*" and hex
+	expectedFormat string
+
+	// several expected hashes, see ExpectedHashes
+	targets map[string]bool
+	onMatch func(key, hash string)
+	found   map[string]string
+
+	// every match is collected by FindAll
+	findAll bool
+	all     []string
+
+	// digest patterns instead of expected hashes, see DigestPrefix
+	digestPrefix   string
+	zeroBits       int
+	digestRegexp   string
+	digestEncoding string
+	pattern        func([]byte) bool
+
+	mask           string
+	customCharsets map[int]string
+	positionKeys   map[int]string
+	rules          []*Rule
+
+	// key order trained by TrainMarkov, per position and previous key
+	markovCounts map[markovKey]int
+	markov       [][256][]byte
+
+	// keys allowed at each position, set by verify
+	positions [][]byte
+
+	// sequential search progress, see SaveState
+	length   int
+	progress []workerState
+	resume   *hasherState
+
+	// progress reporting, see OnProgress
+	onProgress       func(Stats)
+	progressInterval time.Duration
+	progressWriter   io.Writer
 
 	// runtime stats
-	try    uint64
-	tick   uint64
-	buffer []byte
+	started time.Time
+	try     uint64
+	buffer  []byte
+
+	// number of keys searched, nil if unknown
+	total *big.Int
+
+	// between the words of FindCombinator
+	separator string
+
+	// the match completing the last search
+	result *SearchResult
+
+	// hashes of other algos, see AlsoExpect
+	extra []extraTargets
+
+	// candidates tried by random searches, see TriedFilter
+	tried *bloomFilter
+
+	// throttling of the search, see MaxRate and Nice, and the tries of
+	// the search so far
+	maxRate   uint64
+	nice      time.Duration
+	throttled uint64
+
+	// search limits, and the cancel and done channel of the search they
+	// stop
+	maxAttempts uint64
+	maxDuration time.Duration
+	stop        func()
+	stopped     <-chan struct{}
+
+	// search started by Start, async while it is running
+	search *asyncSearch
+	async  *asyncSearch
 }
 
 // NewHasher returns a new Hasher
@@ -29,17 +158,33 @@ func NewHasher() *Hasher {
 	return &Hasher{}
 }
 
-// Algo sets the hash algorithm ("sha1", "sha512")
+// Algo sets the hash algorithm ("sha1", "sha512"), any of AvailableHashes
 func (h *Hasher) Algo(algo string) {
 	algo = strings.Replace(algo, "_", "-", -1)
 	algo = strings.ToLower(algo)
-	h.algo = algo
+	h.algo = resolveAlgoAliases(algo)
 }
 
-// ExpectedHash sets the expected hash
+// ExpectedHash sets the expected hash, in hex or as a "$1$", "$5$" or "$6$"
+// crypt(3) hash, which sets the algo, salt and rounds. White space and
+// colons in hex are ignored, and base64 is accepted too. Mysql hashes
+// ("*" and hex) and postgres hashes ("md5" and hex, see PostgresMD5) are
+// accepted as well. Without an algo, the search uses the algo of the
+// format, or the only algo with a digest of that size, if any
 func (h *Hasher) ExpectedHash(expected string) {
-	tmp, _ := decodeHex([]byte(expected))
-	h.expected = tmp[:]
+
+	h.targets = nil
+	h.cryptHash = ""
+	h.expected, h.expectedErr = nil, nil
+	h.expectedFormat = ""
+	if isCryptHash(expected) {
+		h.cryptHash = expected
+		return
+	}
+	h.expectedFormat, _ = databaseFormat(expected)
+	if strings.TrimSpace(expected) != "" {
+		h.expected, h.expectedErr = decodeDigest(expected)
+	}
 }
 
 // Length sets the length of key to find
@@ -53,12 +198,19 @@ func (h *Hasher) Reverse(b bool) {
 	h.reverse = b
 }
 
-// Prefix sets a fixed prefix
-func (h *Hasher) Prefix(s string) {
-	h.prefix = s
-	panic(fmt.Errorf("TODO impl Prefix for Hasher"))
+// Workers sets the number of goroutines used by FindSequential
+func (h *Hasher) Workers(n int) {
+	h.workers = n
 }
 
+// Rules sets the rules applied to each word by FindFromWordlist
+func (h *Hasher) Rules(rules []*Rule) {
+	h.rules = rules
+}
+
+// Prefix sets a fixed prefix
+func (h *Hasher) Prefix(s string) { h.prefix = s }
+
 // Suffix sets a fixed suffix
 func (h *Hasher) Suffix(s string) { h.suffix = s }
 
@@ -76,70 +228,260 @@ func (h *Hasher) AllowedKeys(s string) {
 // GetAllowedKeys returns the allowed keys
 func (h *Hasher) GetAllowedKeys() string { return string(h.allowedKeys) }
 
-// FindSequential calcs all possible combinations of keys of given length
+// FindSequential calcs all possible combinations of keys, for each length
+// from min to max length, or max to min in reverse. With more than one
+// worker, the keyspace is partitioned by the first key(s) and the first
+// match found by any worker is returned
 func (h *Hasher) FindSequential() (string, error) {
+	return h.FindSequentialContext(context.Background())
+}
+
+// FindSequentialContext is like FindSequential, but stops with the error
+// of ctx when it is done
+func (h *Hasher) FindSequentialContext(ctx context.Context) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findSequential(ctx)
+	})
+}
+
+func (h *Hasher) findSequential(ctx context.Context) (string, error) {
 
 	if err := h.verify(); err != nil {
 		return "", err
 	}
 
-	h.buffer = make([]byte, h.minLength)
+	h.setTotal(h.keyspace())
+	h.started = time.Now()
+	defer h.startStatusReport()()
 
-	firstAllowedKey := h.allowedKeys[0]
-	lastAllowedKey := h.allowedKeys[len(h.allowedKeys)-1]
+	for _, length := range h.lengths() {
+		if h.resume != nil && h.resume.Length != length {
+			// already searched before the state was saved
+			continue
+		}
+		if res, ok := h.findLength(ctx, length); ok {
+			return res, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+	}
+	return "", h.notFound()
+}
 
-	// create initial mutation
-	for x := 0; x < h.minLength; x++ {
+// lengths returns the key lengths to try, in order
+func (h *Hasher) lengths() []int {
+
+	res := []int{}
+	for n := h.minLength; n <= h.maxLength; n++ {
 		if h.reverse {
-			h.buffer[x] = lastAllowedKey
+			res = append([]int{n}, res...)
 		} else {
-			h.buffer[x] = firstAllowedKey
+			res = append(res, n)
 		}
 	}
+	return res
+}
 
-	h.buffer = append(h.buffer, h.suffix...)
+// findLength searches all keys of length with the configured workers
+func (h *Hasher) findLength(ctx context.Context, length int) (string, bool) {
 
-	go h.statusReport()
+	workers := h.workers
+	if workers < 1 {
+		workers = 1
+	}
+	prefixLen := h.partitionPrefixLen(workers, length)
+	progress := h.startLength(length, workers)
+
+	ctx, cancel := context.WithCancel(ctx)
+	found := make(chan string, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if res, ok := h.searchPartition(w, workers, length, prefixLen, progress[w], ctx.Done()); ok {
+				found <- res
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	res, ok := <-found
+	cancel()
+	for range found {
+		// wait for the other workers to stop
+	}
+	return res, ok
+}
 
-	buf := make([]byte, len(h.buffer))
-	copy(buf, h.buffer)
+// partitionPrefixLen returns the number of leading positions needed to
+// split the keyspace of length in at least one part per worker
+func (h *Hasher) partitionPrefixLen(workers, length int) int {
 
-	for {
+	res, parts := 1, len(h.positions[0])
+	for parts < workers && res < length {
+		parts *= len(h.positions[res])
+		res++
+	}
+	return res
+}
 
-		if h.equals() {
-			return string(buf), nil
+// searchPartition tries all keys of length starting with every workers'th
+// prefix of prefixLen keys, beginning with prefix number w or the key
+// saved in from
+func (h *Hasher) searchPartition(w, workers, length, prefixLen int, from workerState, stop <-chan struct{}) (string, bool) {
+
+	if from.Done {
+		return "", false
+	}
+
+	prefixes := 1
+	for _, keys := range h.positions[:prefixLen] {
+		prefixes *= len(keys)
+	}
+
+	// digits holds the index of each position's key, counting from the
+	// last key when in reverse
+	digits := make([]int, length)
+	buf := h.newCandidate(length)
+	key := buf[len(h.prefix) : len(h.prefix)+length]
+
+	p := w
+	if from.Key != nil {
+		p = 0
+		for i, b := range from.Key {
+			digits[i] = h.indexOfKey(from.Key, i, b)
+			if i < prefixLen {
+				p = p*len(h.positions[i]) + digits[i]
+			}
 		}
+	}
 
-		// update mutation
-		for roller := h.minLength - 1; roller >= 0; roller-- {
-			if h.reverse {
-				if buf[roller] == firstAllowedKey {
-					buf[roller] = lastAllowedKey
-					continue
-				} else {
-					buf[roller] = h.prevValueFor(buf[roller])
-					break
+	batch := h.newBatch(w)
+	tries := uint64(0)
+	for ; p < prefixes; p += workers {
+		if from.Key != nil {
+			from.Key = nil
+		} else {
+			for i, n := prefixLen-1, p; i >= 0; i-- {
+				digits[i] = n % len(h.positions[i])
+				n /= len(h.positions[i])
+			}
+			for i := prefixLen; i < length; i++ {
+				digits[i] = 0
+			}
+		}
+		for i, d := range digits {
+			key[i] = h.keyAt(key, i, d)
+		}
+
+		for {
+			if batch.equals(buf) {
+				return string(buf), true
+			}
+
+			tries++
+			if tries%statusInterval == 0 {
+				select {
+				case <-stop:
+					return "", false
+				default:
 				}
-			} else {
-				if buf[roller] == lastAllowedKey {
-					buf[roller] = firstAllowedKey
-					continue
-				} else {
-					buf[roller] = h.nextValueFor(buf[roller])
+				h.reportProgress(buf, statusInterval)
+				h.checkpoint(w, key, false)
+			}
+
+			// update mutation, leaving the prefix alone
+			roller := length - 1
+			for ; roller >= prefixLen; roller-- {
+				if digits[roller] < len(h.positions[roller])-1 {
+					digits[roller]++
+					key[roller] = h.keyAt(key, roller, digits[roller])
 					break
 				}
+				digits[roller] = 0
+				key[roller] = h.keyAt(key, roller, 0)
+			}
+			if roller < prefixLen {
+				break
+			}
+			if h.markov != nil {
+				// the order of the following keys depends on the changed key
+				for i := roller + 1; i < length; i++ {
+					key[i] = h.keyAt(key, i, 0)
+				}
 			}
 		}
+	}
+	h.reportProgress(buf, tries%statusInterval)
+	h.checkpoint(w, key, true)
+	return "", false
+}
 
-		mutex.Lock()
-		copy(h.buffer, buf)
-		h.try++
-		mutex.Unlock()
+// newCandidate returns a buffer holding the prefix, a key of length and
+// the suffix
+func (h *Hasher) newCandidate(length int) []byte {
+
+	res := make([]byte, 0, len(h.prefix)+length+len(h.suffix))
+	res = append(res, h.prefix...)
+	res = append(res, make([]byte, length)...)
+	return append(res, h.suffix...)
+}
+
+// keyAt returns the i'th allowed key at pos of key in search order
+func (h *Hasher) keyAt(key []byte, pos, i int) byte {
+
+	keys := h.positions[pos]
+	if h.markov != nil {
+		prev := byte(0)
+		if pos > 0 {
+			prev = key[pos-1]
+		}
+		keys = h.markov[pos][prev]
 	}
+	if h.reverse {
+		return keys[len(keys)-1-i]
+	}
+	return keys[i]
 }
 
-// FindRandom uses random brute force to attempt to find by luck
+// reportProgress adds tries to the stats shown by startStatusReport
+func (h *Hasher) reportProgress(buf []byte, tries uint64) {
+
+	mutex.Lock()
+	h.buffer = append(h.buffer[:0], buf...)
+	h.try += tries
+	h.checkAttempts()
+	h.throttled += tries
+	throttled, done := h.throttled, h.stopped
+	mutex.Unlock()
+
+	if h.maxRate != 0 || h.nice != 0 {
+		h.throttle(throttled, done)
+	}
+}
+
+// FindRandom uses random brute force to attempt to find by luck. When the
+// keyspace fits in an uint64, each key is tried at most once, and "no match
+// found" is returned when all were tried. Otherwise each try uses a random
+// length between min and max length, and keys may be tried again
 func (h *Hasher) FindRandom() (string, error) {
+	return h.FindRandomContext(context.Background())
+}
+
+// FindRandomContext is like FindRandom, but stops with the error of ctx
+// when it is done
+func (h *Hasher) FindRandomContext(ctx context.Context) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findRandom(ctx)
+	})
+}
+
+func (h *Hasher) findRandom(ctx context.Context) (string, error) {
 
 	if h.reverse {
 		return "", fmt.Errorf("reverse and random dont mix")
@@ -149,110 +491,276 @@ func (h *Hasher) FindRandom() (string, error) {
 		return "", err
 	}
 
-	h.buffer = make([]byte, h.minLength)
+	// one buffer per length, starting with the first allowed keys
+	bufs := make([][]byte, h.maxLength-h.minLength+1)
+	for i := range bufs {
+		bufs[i] = h.newCandidate(h.minLength + i)
+		for x := 0; x < h.minLength+i; x++ {
+			bufs[i][len(h.prefix)+x] = h.positions[x][0]
+		}
+	}
+	buf := bufs[0]
 
-	firstAllowedKey := h.allowedKeys[0]
-	allowedKeysLen := len(h.allowedKeys)
+	h.started = time.Now()
+	defer h.startStatusReport()()
 
-	// create initial mutation
-	for x := 0; x < h.minLength; x++ {
-		h.buffer[x] = firstAllowedKey
+	if n, ok := h.keyspaceSize(); ok {
+		h.setTotal(new(big.Int).SetUint64(n))
+		return h.findRandomUnique(ctx, n)
 	}
 
-	h.buffer = append(h.buffer, h.suffix...)
+	batch := h.newBatch(0)
+	for tries := uint64(1); ; tries++ {
+		if (h.tried == nil || !h.tried.add(buf)) && batch.equals(buf) {
+			return string(buf), nil
+		}
+		if tries%statusInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			h.reportProgress(buf, statusInterval)
+		}
 
-	buf := make([]byte, len(h.buffer))
-	copy(buf, h.buffer)
+		// update mutation, leaving prefix and suffix alone
+		if len(bufs) > 1 {
+			buf = bufs[rand.Intn(len(bufs))]
+		}
+		key := buf[len(h.prefix) : len(buf)-len(h.suffix)]
+		for roller := range key {
+			keys := h.positions[roller]
+			key[roller] = keys[rand.Intn(len(keys))]
+		}
+	}
+}
 
-	go h.statusReport()
+// FindFromWordlist tries each line of r as key, with any prefix and
+// suffix. With rules set, each rule is applied to the word instead
+func (h *Hasher) FindFromWordlist(r io.Reader) (string, error) {
+	return h.FindFromWordlistContext(context.Background(), r)
+}
 
-	for {
-		if h.equals() {
-			return string(buf), nil
+// FindFromWordlistContext is like FindFromWordlist, but stops with the
+// error of ctx when it is done
+func (h *Hasher) FindFromWordlistContext(ctx context.Context, r io.Reader) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findFromWordlist(ctx, r)
+	})
+}
+
+func (h *Hasher) findFromWordlist(ctx context.Context, r io.Reader) (string, error) {
+
+	if err := h.verifyTarget(); err != nil {
+		return "", err
+	}
+
+	h.started = time.Now()
+	defer h.startStatusReport()()
+
+	scanner := bufio.NewScanner(r)
+	buf := []byte(h.prefix)
+	batch := h.newBatch(0)
+
+	// candidates hashed per word, and since the last report
+	perWord := uint64(1)
+	if len(h.rules) > 0 {
+		perWord = uint64(len(h.rules))
+	}
+	tries := uint64(0)
+	for scanner.Scan() {
+		word := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		if len(word) == 0 {
+			continue
 		}
 
-		// update mutation of first letters
-		for roller := 0; roller < h.minLength; roller++ {
-			buf[roller] = h.allowedKeys[rand.Intn(allowedKeysLen)]
+		if len(h.rules) == 0 {
+			buf = append(append(buf[:len(h.prefix)], word...), h.suffix...)
+			if batch.equals(buf) {
+				return string(buf), nil
+			}
+		}
+		for _, rule := range h.rules {
+			buf = append(append(buf[:len(h.prefix)], rule.Apply(word)...), h.suffix...)
+			if batch.equals(buf) {
+				return string(buf), nil
+			}
 		}
 
-		mutex.Lock()
-		copy(h.buffer, buf)
-		h.try++
-		mutex.Unlock()
+		tries += perWord
+		if tries >= statusInterval {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			h.reportProgress(buf, tries)
+			tries = 0
+		}
 	}
+	h.reportProgress(buf, tries)
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", h.notFound()
 }
 
 func (h *Hasher) verify() error {
 
-	if len(h.allowedKeys) == 0 {
-		return fmt.Errorf("allowedKeys unset")
+	if err := h.verifyKeys(); err != nil {
+		return err
 	}
+	return h.verifyTarget()
+}
 
-	if h.minLength == 0 {
-		return fmt.Errorf("minLength unset")
+// verifyKeys sets the allowed keys of each position
+func (h *Hasher) verifyKeys() error {
+
+	if h.maxLength < h.minLength {
+		h.maxLength = h.minLength
 	}
 
-	if len(h.algo) == 0 {
-		return fmt.Errorf("algo unset")
+	if h.mask != "" {
+		positions, err := parseMask(h.mask, h.customCharsets)
+		if err != nil {
+			return err
+		}
+		if h.minLength == 0 {
+			h.minLength = len(positions)
+			h.maxLength = len(positions)
+		}
+		if h.maxLength > len(positions) {
+			return fmt.Errorf("mask has %d positions, length is %d", len(positions), h.maxLength)
+		}
+		h.positions = positions[:h.maxLength]
+		if err := h.applyPositionKeys(); err != nil {
+			return err
+		}
+		h.buildMarkov()
+		return nil
 	}
 
-	keyBitSize := len(h.expected) * 8
-	expectedBitSize := len(h.expected) * 8
+	if h.minLength == 0 {
+		return fmt.Errorf("minLength unset")
+	}
 
-	if requiredBitSize, ok := algos[h.algo]; ok {
-		if keyBitSize != requiredBitSize {
-			return fmt.Errorf("expectedHash is wrong size, should be %d bit, is %d",
-				requiredBitSize, expectedBitSize)
+	h.positions = make([][]byte, h.maxLength)
+	for i := range h.positions {
+		h.positions[i] = h.allowedKeys
+	}
+	if err := h.applyPositionKeys(); err != nil {
+		return err
+	}
+	for _, keys := range h.positions {
+		if len(keys) == 0 {
+			return fmt.Errorf("allowedKeys unset")
 		}
-	} else {
-		return fmt.Errorf("unknown algo %s", h.algo)
 	}
-
+	h.buildMarkov()
 	return nil
 }
 
-func (h *Hasher) equals() bool {
-
-	calc := NewCalculator(h.buffer)
-	return byteArrayEquals(*calc.Sum(h.algo), h.expected)
-}
+// verifyTarget checks the algo and the expected hash or digest pattern
+func (h *Hasher) verifyTarget() error {
 
-func (h *Hasher) statusReport() {
+	var err error
+	var tmpl *templateNode
+	if h.template != "" {
+		if tmpl, err = parseTemplate(h.template); err != nil {
+			return err
+		}
+		h.algo = tmpl.algo
+	}
 
-	for {
-		time.Sleep(1 * time.Second)
+	var crypt *cryptHash
+	if h.cryptHash != "" {
+		if crypt, err = parseCryptHash(h.cryptHash); err != nil {
+			return err
+		}
+		h.algo = crypt.scheme.algo
+		h.expected = crypt.digest
+	}
 
-		mutex.Lock()
-		h.tick++
-		avg := h.try / h.tick
-		fmt.Printf("%s ~%d/s %s\n", h.algo, avg, string(h.buffer))
-		mutex.Unlock()
+	if h.expectedErr != nil {
+		return h.expectedErr
+	}
+	if len(h.algo) == 0 {
+		if err := h.inferAlgo(); err != nil {
+			return err
+		}
 	}
-}
 
-func (h *Hasher) nextValueFor(b byte) byte {
+	sum, ok := hashers[h.algo]
+	if !ok {
+		return fmt.Errorf("unknown algo %s", h.algo)
+	}
+	first := sum
+	if tmpl != nil {
+		salt := []byte(h.salt)
+		first = func(buf *[]byte) *[]byte {
+			res := tmpl.digest(*buf, salt)
+			return &res
+		}
+	}
+	mac, err := h.hmacSum()
+	if err != nil {
+		return err
+	}
+	if mac != nil {
+		first = mac
+	}
+	if crypt != nil {
+		if tmpl != nil || mac != nil {
+			return fmt.Errorf("crypt hash and template or hmac dont mix")
+		}
+		first = func(buf *[]byte) *[]byte {
+			res := crypt.sum(*buf)
+			return &res
+		}
+	}
+	if h.iterations < 0 {
+		return fmt.Errorf("iterations must be at least 1")
+	}
+	h.sum = first
+	if h.iterations > 1 {
+		h.sum = iterate(first, sum, h.iterations, h.iterateHex)
+	}
 
-	next := false
-	for _, x := range h.allowedKeys {
-		if next == true {
-			return x
+	if err := h.verifyPattern(); err != nil {
+		return err
+	}
+	for digest := range h.targets {
+		if err := h.verifySize([]byte(digest)); err != nil {
+			return err
 		}
-		if x == b {
-			next = true
+	}
+	if h.targets == nil && h.pattern == nil {
+		if err := h.verifySize(h.expected); err != nil {
+			return err
 		}
 	}
-	return '0'
+	if h.extra != nil && h.pattern != nil {
+		return fmt.Errorf("other algos and digest patterns dont mix")
+	}
+
+	mutex.Lock()
+	h.total = nil
+	h.throttled = 0
+	h.result = nil
+	if h.resume == nil {
+		// keys found before a saved state are kept when resuming
+		h.found = make(map[string]string)
+		h.try = 0
+	}
+	mutex.Unlock()
+
+	h.verifyBatch(tmpl == nil && mac == nil && crypt == nil && h.iterations <= 1)
+	return nil
 }
 
-func (h *Hasher) prevValueFor(b byte) byte {
+// verifySize checks that expected is a digest of the algo
+func (h *Hasher) verifySize(expected []byte) error {
 
-	prev := h.allowedKeys[0]
-	for _, x := range h.allowedKeys {
-		if x == b {
-			return prev
-		}
-		prev = x
+	expectedBitSize := len(expected) * 8
+	if requiredBitSize := algos[h.algo]; expectedBitSize != requiredBitSize {
+		return fmt.Errorf("expectedHash is wrong size, should be %d bit, is %d",
+			requiredBitSize, expectedBitSize)
 	}
-	return prev
+	return nil
 }
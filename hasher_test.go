@@ -1,8 +1,11 @@
 package gohash
 
 import (
+	"context"
 	"math/rand"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,8 +27,13 @@ var (
 		"blake224":     {3, "holej", "c5d6e24c89a45385af97ae89c9edde904656d75e5a3582b1c9a390de", "hej"},
 		"blake256":     {3, "holej", "91bff832dc57e964a521c660b6500ad04d565536fc5ccd98032bdcb1ebc9402c", "hej"},
 		"blake384":     {3, "holej", "11a0ee2934bdd0f3c39ca0eee3b09287db24bc995df15d238da8d95f337ab39badcc6ca2dad0ba10cb49d32113f378b8", "hej"},
+		"blake2b-256":  {3, "holej", "63578e78700f2fb28ee94eb9e805a400813f849ddb80eec7d98644bd9874b6a9", "hej"},
+		"blake2b-512":  {4, "mota", "a276c9fd86b9abde5df05865e3db6e446d8c7db8c7c639a6cc106df2d94a72c00fd1e64a12d90d7fc33841b43b00b1f7957d8eafb9bae2f0e27642b7cbe48d98", "atom"},
 		"blake512":     {3, "holej", "3f0b354957782ac9f690683117d391bbd4d0b35061c21043e0915201a16fbf31a0dceac3d98b357a5624e93060df59e607b645a645f4bc944ef825aaf7022348", "hej"},
+		"crc32":        {3, "holej", "0c68542e", "hej"},
 		"crc32-ieee":   {3, "holej", "0c68542e", "hej"},
+		"fnv1a-32":     {3, "holej", "0cb4b354", "hej"},
+		"fnv1-64":      {3, "holej", "d8c4bd186b9b05da", "hej"},
 		"md2":          {3, "holej", "a8791e99e1a205db46c6cdef1f459108", "hej"},
 		"md4":          {3, "holej", "da3a901f9f5956d23553b9f00bc134a9", "hej"},
 		"md5":          {3, "holej", "541c57960bb997942655d14e3b9607f9", "hej"},
@@ -64,6 +72,95 @@ func TestSequentialHasher(t *testing.T) {
 	}
 }
 
+func TestSequentialHasherWorkers(t *testing.T) {
+
+	for algo, rev := range sequentialHasherTest {
+		for _, workers := range []int{2, 3, 8, 200} {
+			hasher := NewHasher()
+			hasher.Algo(algo)
+			hasher.Length(rev.length)
+			hasher.AllowedKeys(rev.allowedKeys)
+			hasher.ExpectedHash(rev.hash)
+			hasher.Workers(workers)
+
+			res, err := hasher.FindSequential()
+			assert.Equal(t, nil, err)
+			assert.Equal(t, rev.decoded, string(res))
+		}
+	}
+}
+
+func TestSequentialHasherNoMatch(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Length(2)
+	hasher.AllowedKeys("abc")
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+	hasher.Workers(4)
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+}
+
+func TestSequentialHasherContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Length(4)
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz0123456789")
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.Workers(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := hasher.FindSequentialContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestHashSequentialLengthRange(t *testing.T) {
+
+	for _, reverse := range []bool{false, true} {
+		for _, workers := range []int{1, 3} {
+			hasher := NewHasher()
+			hasher.Algo("md5")
+			hasher.AllowedKeys("holej")
+			hasher.MinLength(1)
+			hasher.MaxLength(4)
+			hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+			hasher.Reverse(reverse)
+			hasher.Workers(workers)
+
+			res, err := hasher.FindSequential()
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "hej", res)
+		}
+	}
+}
+
+func TestHasherLengths(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.MinLength(2)
+	hasher.MaxLength(4)
+	assert.Equal(t, []int{2, 3, 4}, hasher.lengths())
+
+	hasher.Reverse(true)
+	assert.Equal(t, []int{4, 3, 2}, hasher.lengths())
+}
+
+func TestHasherUnknownAlgo(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("nope")
+	hasher.Length(2)
+	hasher.AllowedKeys("abc")
+	hasher.ExpectedHash("0c68542e")
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "unknown algo nope", err.Error())
+}
+
 func TestHashSequential(t *testing.T) {
 
 	hasher := NewHasher()
@@ -78,6 +175,69 @@ func TestHashSequential(t *testing.T) {
 	assert.Equal(t, "222222222222222f.onion", string(res))
 }
 
+func TestHashSequentialWorkers(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("sha512")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Suffix(".onion")
+	hasher.ExpectedHash("4e73702fa409f71f7a564276998b5c663e0617d301dc2f6f79ee4b58d18794eea8449e3a385360e774be22f970a7127a4117ba41a576cab2f46704fd0b6b29e0")
+	hasher.Length(16)
+	hasher.Reverse(true)
+	hasher.Workers(4)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "zzzzzzzzzzzzzzww.onion", string(res))
+}
+
+func TestHashSequentialPrefix(t *testing.T) {
+
+	for _, workers := range []int{1, 4} {
+		hasher := NewHasher()
+		hasher.Algo("md5")
+		hasher.AllowedKeys("holej")
+		hasher.Prefix("pre-")
+		hasher.Suffix(".onion")
+		hasher.ExpectedHash("f5380f9d9bfdd9f7a211407e83172aa8")
+		hasher.Length(3)
+		hasher.Workers(workers)
+
+		res, err := hasher.FindSequential()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "pre-hej.onion", res)
+	}
+}
+
+func TestHashRandomPrefix(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ab")
+	hasher.Prefix("pre-")
+	hasher.Suffix(".x")
+	hasher.ExpectedHash("759a27f663ff69e66318e0ce99007749")
+	hasher.Length(2)
+
+	res, err := hasher.FindRandom()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "pre-ba.x", res)
+}
+
+func TestHashRandomLengthRange(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ab")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+	hasher.ExpectedHash("07159c47ee1b19ae4fb9c40d480856c4")
+
+	res, err := hasher.FindRandom()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ba", res)
+}
+
 func TestHashRandom(t *testing.T) {
 
 	rand.Seed(123)
@@ -94,6 +254,20 @@ func TestHashRandom(t *testing.T) {
 	assert.Equal(t, "aawiioowvgzolbqa.xxx", string(res))
 }
 
+func TestHashRandomContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.Length(16)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := hasher.FindRandomContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
 func TestHashReverse(t *testing.T) {
 
 	rand.Seed(123)
@@ -111,15 +285,70 @@ func TestHashReverse(t *testing.T) {
 	assert.Equal(t, "zzzzzzzzzzzzzzww.onion", string(res))
 }
 
-// benchmarks given key length and print a prediction based on it
-func BenchmarkSha1Speed(*testing.B) {
+func TestFindFromWordlist(t *testing.T) {
 
 	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("foo\r\n\nhej\r\nbar\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
 
+	_, err = hasher.FindFromWordlist(strings.NewReader("foo\nbar"))
+	assert.Equal(t, "no match found", err.Error())
+}
+
+func TestFindFromWordlistPrefix(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Prefix("pre-")
+	hasher.ExpectedHash("9acd325de8475df7c84664e1f2240e13")
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("hej\ntex\nbar"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "pre-tex", res)
+}
+
+func TestFindFromWordlistSuffix(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("sha1")
+	hasher.Suffix(".xxx")
+	hasher.ExpectedHash("3164610900459c1a781308fb166f2e94c5d745bd")
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("hej\ntex\nbar"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "tex.xxx", res)
+}
+
+func TestFindFromWordlistContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := hasher.FindFromWordlistContext(ctx, strings.NewReader(strings.Repeat("foo\n", 2*statusInterval)))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func BenchmarkHasherSha1(b *testing.B) {
+
+	hasher := NewHasher()
 	hasher.Algo("sha1")
-	hasher.AllowedKeys("580%(=QWI+qwi*Nn")
 	hasher.ExpectedHash("0000000000000000000000000000000000000000")
-	hasher.Length(5)
+	if err := hasher.verifyTarget(); err != nil {
+		b.Fatal(err)
+	}
 
-	hasher.FindSequential()
+	batch := hasher.newBatch(0)
+	buf := []byte("580%(")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf[i%len(buf)]++
+		batch.equals(buf)
+	}
 }
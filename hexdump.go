@@ -0,0 +1,88 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const hexdumpLineLen = 16
+
+// encodeHexdump produces a hex dump in the format of xxd:
+// "00000000: 5468 6520 7175 6963 6b20 6272 6f77 6e20  The quick brown "
+func encodeHexdump(src []byte) ([]byte, error) {
+
+	lines := []string{}
+	for pos := 0; pos < len(src); pos += hexdumpLineLen {
+		end := pos + hexdumpLineLen
+		if end > len(src) {
+			end = len(src)
+		}
+		line := src[pos:end]
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%08x:", pos)
+		for i := 0; i < hexdumpLineLen; i++ {
+			if i%2 == 0 {
+				sb.WriteByte(' ')
+			}
+			if i < len(line) {
+				fmt.Fprintf(&sb, "%02x", line[i])
+			} else {
+				sb.WriteString("  ")
+			}
+		}
+		sb.WriteString("  ")
+		for _, b := range line {
+			if b < 0x20 || b > 0x7e {
+				b = '.'
+			}
+			sb.WriteByte(b)
+		}
+		lines = append(lines, sb.String())
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// decodeHexdump reconstructs bytes from a hex dump in the format of xxd or
+// "hexdump -C", ignoring the offset and ASCII columns
+func decodeHexdump(src []byte) ([]byte, error) {
+
+	res := []byte{}
+	for n, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.TrimSpace(line) == "*" {
+			return nil, fmt.Errorf("hexdump: line %d: collapsed repeated lines are not supported", n+1)
+		}
+
+		var data string
+		if i := strings.IndexByte(line, ':'); i != -1 && !strings.ContainsAny(line[:i], " |") {
+			// xxd, the hex column ends with two spaces
+			data = strings.TrimPrefix(line[i+1:], " ")
+			if j := strings.Index(data, "  "); j != -1 {
+				data = data[:j]
+			}
+		} else {
+			// hexdump -C, the ascii column is within |...|
+			fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+			if len(fields) < 2 {
+				// final offset line
+				continue
+			}
+			data = fields[1]
+			if j := strings.IndexByte(data, '|'); j != -1 {
+				data = data[:j]
+			}
+		}
+
+		b, err := hex.DecodeString(stripSpaces(data))
+		if err != nil {
+			return nil, fmt.Errorf("hexdump: line %d: %v", n+1, err)
+		}
+		res = append(res, b...)
+	}
+	return res, nil
+}
@@ -0,0 +1,34 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeHexdumpCanonical(t *testing.T) {
+
+	dump := "00000000  54 68 65 20 71 75 69 63  6b 20 62 72 6f 77 6e 20  |The quick brown |\n" +
+		"00000010  66 6f 78 7c                                       |fox||\n" +
+		"00000014\n"
+
+	res, err := decodeHexdump([]byte(dump))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("The quick brown fox|"), res)
+}
+
+func TestDecodeHexdumpASCIILooksLikeHex(t *testing.T) {
+
+	res, err := decodeHexdump([]byte("00000000: 6465 6164 2062 6565 66                deed beef\r\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("dead beef"), res)
+}
+
+func TestDecodeHexdumpInvalid(t *testing.T) {
+
+	_, err := decodeHexdump([]byte("00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n*\n00000030\n"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeHexdump([]byte("00000000: 6g65  .e"))
+	assert.NotEqual(t, nil, err)
+}
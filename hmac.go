@@ -0,0 +1,100 @@
+package gohash
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"sort"
+)
+
+var (
+	// algos with a hash.Hash, usable with hmac and for hashing candidates
+	// with a reused state
+	hmacHashes = map[string]func() hash.Hash{
+		"md5":        md5.New,
+		"sha1":       sha1.New,
+		"sha224":     sha256.New224,
+		"sha256":     sha256.New,
+		"sha384":     sha512.New384,
+		"sha512":     sha512.New,
+		"sha512-224": sha512.New512_224,
+		"sha512-256": sha512.New512_256,
+	}
+)
+
+// HMAC returns the hmac of the data with key, using algo, or nil if algo
+// is not one of AvailableHMACs
+func (c *Calculator) HMAC(algo string, key []byte) *[]byte {
+
+	newHash, ok := hmacHashes[resolveAlgoAliases(algo)]
+	if !ok {
+		return nil
+	}
+	mac := hmac.New(newHash, key)
+	mac.Write(c.data)
+	res := mac.Sum(nil)
+	return &res
+}
+
+// AvailableHMACs returns the algos usable with hmac
+func AvailableHMACs() []string {
+
+	res := []string{}
+	for key := range hmacHashes {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// HMACMessage sets the known message of a hmac target, the candidates
+// are tried as the key
+func (h *Hasher) HMACMessage(msg string) {
+	h.hmacMessage = &msg
+	h.hmacKey = nil
+}
+
+// HMACKey sets the known key of a hmac target, the candidates are tried
+// as the message
+func (h *Hasher) HMACKey(key string) {
+	h.hmacKey = &key
+	h.hmacMessage = nil
+}
+
+// hmacSum returns a func calculating the hmac of candidates, or nil if
+// no hmac target is set
+func (h *Hasher) hmacSum() (func(*[]byte) *[]byte, error) {
+
+	if h.hmacMessage == nil && h.hmacKey == nil {
+		return nil, nil
+	}
+	if h.template != "" {
+		return nil, fmt.Errorf("hmac and template dont mix")
+	}
+	newHash, ok := hmacHashes[h.algo]
+	if !ok {
+		return nil, fmt.Errorf("hmac is not supported for %s", h.algo)
+	}
+
+	if h.hmacKey != nil {
+		key := []byte(*h.hmacKey)
+		return func(buf *[]byte) *[]byte {
+			mac := hmac.New(newHash, key)
+			mac.Write(*buf)
+			res := mac.Sum(nil)
+			return &res
+		}, nil
+	}
+
+	msg := []byte(*h.hmacMessage)
+	return func(buf *[]byte) *[]byte {
+		mac := hmac.New(newHash, *buf)
+		mac.Write(msg)
+		res := mac.Sum(nil)
+		return &res
+	}, nil
+}
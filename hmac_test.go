@@ -0,0 +1,57 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatorHMAC(t *testing.T) {
+
+	calc := NewCalculator([]byte("The quick brown fox jumps over the lazy dog"))
+	assert.Equal(t, "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9", hex.EncodeToString(*calc.HMAC("sha1", []byte("key"))))
+	assert.Equal(t, (*[]byte)(nil), calc.HMAC("crc32", []byte("key")))
+}
+
+func TestAvailableHMACs(t *testing.T) {
+	assert.Equal(t, []string{"md5", "sha1", "sha224", "sha256", "sha384", "sha512", "sha512-224", "sha512-256"}, AvailableHMACs())
+}
+
+func TestHasherHMACKey(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("sha256")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.Workers(2)
+	hasher.HMACMessage("known message")
+	hasher.ExpectedHash("d510c181f6e6cce0ecf5b863a095209dc34c3aba37dc65bd85b719742ade5138")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestHasherHMACMessage(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.HMACKey("secret")
+	hasher.ExpectedHash("f50fc5ff3e6c867ec47e79be92fe34ad")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+
+	hasher.Algo("crc32")
+	hasher.ExpectedHash("00000000")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "hmac is not supported for crc32-ieee", err.Error())
+
+	hasher.Template("md5($pass)")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "hmac and template dont mix", err.Error())
+}
@@ -0,0 +1,176 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// FindHybrid tries each line of r followed by every key of mask, such as
+// "?d?d?d?d", like the hybrid attacks of hashcat. With prepend, the mask
+// comes before the word instead. Custom charsets of the mask are set with
+// CustomCharset, and the words are shared between the workers
+func (h *Hasher) FindHybrid(r io.Reader, mask string, prepend bool) (string, error) {
+	return h.FindHybridContext(context.Background(), r, mask, prepend)
+}
+
+// FindHybridContext is like FindHybrid, but stops with the error of ctx
+// when it is done
+func (h *Hasher) FindHybridContext(ctx context.Context, r io.Reader, mask string, prepend bool) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findHybrid(ctx, r, mask, prepend)
+	})
+}
+
+func (h *Hasher) findHybrid(ctx context.Context, r io.Reader, mask string, prepend bool) (string, error) {
+
+	positions, err := parseMask(mask, h.customCharsets)
+	if err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "", fmt.Errorf("hybrid mask is empty")
+	}
+	if err := h.verifyTarget(); err != nil {
+		return "", err
+	}
+
+	h.started = time.Now()
+	defer h.startStatusReport()()
+
+	workers := h.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	words := make(chan []byte, workers)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(words)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			word := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+			if len(word) == 0 {
+				continue
+			}
+			select {
+			case words <- append([]byte{}, word...):
+			case <-search.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	found := make(chan string, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if res, ok := h.searchHybrid(search, w, words, positions, prepend); ok {
+				found <- res
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	res, ok := <-found
+	cancel()
+	for range found {
+		// wait for the other workers to stop
+	}
+	if ok {
+		return res, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	select {
+	case err := <-readErr:
+		if err != nil {
+			return "", err
+		}
+	default:
+	}
+	return "", h.notFound()
+}
+
+// searchHybrid tries every key of the mask positions with each word from
+// words as worker w, until it is closed or ctx is done
+func (h *Hasher) searchHybrid(ctx context.Context, w int, words <-chan []byte, positions [][]byte, prepend bool) (string, bool) {
+
+	buf := []byte(h.prefix)
+	batch := h.newBatch(w)
+	tries := uint64(0)
+	defer func() {
+		h.reportProgress(buf, tries%statusInterval)
+	}()
+
+	index := make([]int, len(positions))
+	for {
+		var word []byte
+		select {
+		case <-ctx.Done():
+			return "", false
+		case w, ok := <-words:
+			if !ok {
+				return "", false
+			}
+			word = w
+		}
+
+		buf = buf[:len(h.prefix)]
+		if !prepend {
+			buf = append(buf, word...)
+		}
+		start := len(buf)
+		for i, keys := range positions {
+			index[i] = 0
+			buf = append(buf, keys[0])
+		}
+		if prepend {
+			buf = append(buf, word...)
+		}
+		buf = append(buf, h.suffix...)
+		key := buf[start : start+len(positions)]
+
+		for {
+			if batch.equals(buf) {
+				return string(buf), true
+			}
+			tries++
+			if tries%statusInterval == 0 {
+				if ctx.Err() != nil {
+					return "", false
+				}
+				h.reportProgress(buf, statusInterval)
+			}
+
+			// next key of the mask, last position first
+			pos := len(positions) - 1
+			for ; pos >= 0; pos-- {
+				index[pos]++
+				if index[pos] < len(positions[pos]) {
+					key[pos] = positions[pos][index[pos]]
+					break
+				}
+				index[pos] = 0
+				key[pos] = positions[pos][0]
+			}
+			if pos < 0 {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package gohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindHybridAppend(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("48fb6c4d6d155a30c8ce3203246c9bae")
+	hasher.Workers(2)
+
+	res, err := hasher.FindHybrid(strings.NewReader("foo\r\n\nbar\nbaz\n"), "?d?d", false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bar42", res)
+}
+
+func TestFindHybridPrepend(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("ca10beb0dbc5ee0e45cdf144f6e7f44c")
+
+	res, err := hasher.FindHybrid(strings.NewReader("foo\nbar\n"), "?d?d", true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "42bar", res)
+}
+
+func TestFindHybridCustomCharset(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("813658d12d1540f7d7143373bfb85415")
+	hasher.CustomCharset(1, "xyz")
+	hasher.Prefix("x-")
+	hasher.Suffix("!")
+
+	res, err := hasher.FindHybrid(strings.NewReader("bar\nfoo\n"), "-?1?d", false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "x-foo-x7!", res)
+}
+
+func TestFindHybridErrors(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+
+	_, err := hasher.FindHybrid(strings.NewReader("foo\n"), "?d", false)
+	assert.Equal(t, "no match found", err.Error())
+
+	_, err = hasher.FindHybrid(strings.NewReader("foo\n"), "", false)
+	assert.Equal(t, "hybrid mask is empty", err.Error())
+
+	_, err = hasher.FindHybrid(strings.NewReader("foo\n"), "?x", false)
+	assert.Equal(t, "mask: unknown placeholder ?x", err.Error())
+
+	_, err = hasher.FindHybrid(failingReader{}, "?d", false)
+	assert.Equal(t, "read failed", err.Error())
+}
@@ -0,0 +1,170 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// data bytes per record, used by both ihex and srec
+	recordDataLen = 16
+
+	ihexData                  = 0x00
+	ihexEOF                   = 0x01
+	ihexExtendedSegment       = 0x02
+	ihexStartSegment          = 0x03
+	ihexExtendedLinearAddress = 0x04
+	ihexStartLinear           = 0x05
+
+	// largest span of addresses a decoded image may cover
+	maxMemoryImage = 64 * 1024 * 1024
+)
+
+// encodeIntelHex encodes src as Intel HEX records starting at address 0,
+// with extended linear address records past 64k
+func encodeIntelHex(src []byte) ([]byte, error) {
+
+	lines := []string{}
+	for pos := 0; pos < len(src); pos += recordDataLen {
+		if pos > 0 && pos%0x10000 == 0 {
+			lines = append(lines, ihexRecord(ihexExtendedLinearAddress, 0, []byte{byte(pos >> 24), byte(pos >> 16)}))
+		}
+		end := pos + recordDataLen
+		if end > len(src) {
+			end = len(src)
+		}
+		lines = append(lines, ihexRecord(ihexData, uint16(pos), src[pos:end]))
+	}
+	lines = append(lines, ihexRecord(ihexEOF, 0, nil))
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// decodeIntelHex decodes Intel HEX records, verifying their checksums.
+// The result starts at the lowest address, with gaps filled with 0xff
+func decodeIntelHex(src []byte) ([]byte, error) {
+
+	img := newMemoryImage()
+	base := 0
+	for n, line := range strings.Fields(string(src)) {
+		if line[0] != ':' {
+			return nil, fmt.Errorf("ihex: record %d does not start with ':'", n+1)
+		}
+		b, err := hex.DecodeString(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("ihex: record %d: %v", n+1, err)
+		}
+		if len(b) < 5 || len(b) != int(b[0])+5 {
+			return nil, fmt.Errorf("ihex: record %d has invalid length", n+1)
+		}
+		sum := byte(0)
+		for _, c := range b {
+			sum += c
+		}
+		if sum != 0 {
+			return nil, fmt.Errorf("ihex: record %d has invalid checksum", n+1)
+		}
+
+		addr := int(b[1])<<8 | int(b[2])
+		data := b[4 : len(b)-1]
+		switch b[3] {
+		case ihexData:
+			if err := img.write(base+addr, data); err != nil {
+				return nil, fmt.Errorf("ihex: record %d: %v", n+1, err)
+			}
+		case ihexEOF:
+			return img.bytes(), nil
+		case ihexExtendedSegment:
+			if len(data) != 2 {
+				return nil, fmt.Errorf("ihex: record %d has invalid segment address", n+1)
+			}
+			base = (int(data[0])<<8 | int(data[1])) << 4
+		case ihexExtendedLinearAddress:
+			if len(data) != 2 {
+				return nil, fmt.Errorf("ihex: record %d has invalid linear address", n+1)
+			}
+			base = (int(data[0])<<8 | int(data[1])) << 16
+		case ihexStartSegment, ihexStartLinear:
+			// start address, not part of the data
+		default:
+			return nil, fmt.Errorf("ihex: record %d has unknown type %02x", n+1, b[3])
+		}
+	}
+	return nil, fmt.Errorf("ihex: missing end of file record")
+}
+
+func ihexRecord(typ byte, addr uint16, data []byte) string {
+
+	b := []byte{byte(len(data)), byte(addr >> 8), byte(addr), typ}
+	b = append(b, data...)
+	sum := byte(0)
+	for _, c := range b {
+		sum += c
+	}
+	b = append(b, -sum)
+	return ":" + strings.ToUpper(hex.EncodeToString(b))
+}
+
+// memoryImage collects data records written at arbitrary addresses
+type memoryImage struct {
+	data     []byte
+	start    int
+	hasStart bool
+}
+
+func newMemoryImage() *memoryImage {
+	return &memoryImage{}
+}
+
+func (m *memoryImage) write(addr int, data []byte) error {
+
+	if len(data) == 0 {
+		return nil
+	}
+	if !m.hasStart {
+		m.start = addr
+		m.hasStart = true
+	}
+	lo, hi := m.start, m.start+len(m.data)
+	if addr < lo {
+		lo = addr
+	}
+	if addr+len(data) > hi {
+		hi = addr + len(data)
+	}
+	if hi-lo > maxMemoryImage {
+		return fmt.Errorf("image spans more than %d bytes", maxMemoryImage)
+	}
+
+	if addr < m.start {
+		grown := make([]byte, m.start-addr+len(m.data))
+		fillErased(grown[:m.start-addr])
+		copy(grown[m.start-addr:], m.data)
+		m.data = grown
+		m.start = addr
+	}
+	end := addr - m.start + len(data)
+	if end > len(m.data) {
+		grown := make([]byte, end)
+		copy(grown, m.data)
+		fillErased(grown[len(m.data):])
+		m.data = grown
+	}
+	copy(m.data[addr-m.start:], data)
+	return nil
+}
+
+func (m *memoryImage) bytes() []byte {
+
+	if m.data == nil {
+		return []byte{}
+	}
+	return m.data
+}
+
+// fillErased fills b with 0xff, the value of erased flash
+func fillErased(b []byte) {
+	for i := range b {
+		b[i] = 0xff
+	}
+}
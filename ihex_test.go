@@ -0,0 +1,58 @@
+package gohash
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntelHex(t *testing.T) {
+
+	res, err := encodeIntelHex([]byte("address gap"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ":0B0000006164647265737320676170B7\n:00000001FF", string(res))
+
+	dec, err := decodeIntelHex([]byte(":0B0010006164647265737320676170A7\n:00000001FF"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("address gap"), dec)
+}
+
+func TestIntelHexExtendedAddress(t *testing.T) {
+
+	src := bytes.Repeat([]byte{0x5a}, 0x10010)
+	res, err := encodeIntelHex(src)
+	assert.Equal(t, nil, err)
+	assert.Contains(t, string(res), "\n:020000040001F9\n")
+
+	dec, err := decodeIntelHex(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, dec)
+}
+
+func TestIntelHexGap(t *testing.T) {
+
+	dec, err := decodeIntelHex([]byte(":0100000001FE\n:0100020002FB\n:00000001FF"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0x01, 0xff, 0x02}, dec)
+}
+
+func TestIntelHexInvalid(t *testing.T) {
+
+	// bad checksum
+	_, err := decodeIntelHex([]byte(":0B0010006164647265737320676170A8\n:00000001FF"))
+	assert.NotEqual(t, nil, err)
+
+	// missing eof record
+	_, err = decodeIntelHex([]byte(":0B0010006164647265737320676170A7"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeIntelHex([]byte("0B0010006164647265737320676170A7"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestIntelHexImageTooLarge(t *testing.T) {
+
+	_, err := decodeIntelHex([]byte(":0100000001FE\n:02000004FFFFFC\n:0100000001FE\n:00000001FF"))
+	assert.NotEqual(t, nil, err)
+}
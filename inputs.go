@@ -0,0 +1,160 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Input is a named input to hash, opened when it is hashed
+type Input struct {
+	Name string
+	Open func() (io.ReadCloser, error)
+}
+
+// InputResult is the Result of hashing an Input, or the error doing so
+type InputResult struct {
+	Input  Input
+	Result *Result
+	Err    error
+}
+
+// FileInput returns the Input of a file, or of stdin for "-"
+func FileInput(name string) Input {
+
+	if name == "-" {
+		return Input{Name: name, Open: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(os.Stdin), nil
+		}}
+	}
+	return Input{Name: name, Open: func() (io.ReadCloser, error) {
+		return os.Open(name)
+	}}
+}
+
+// Input returns the Input of url, downloaded when it is hashed
+func (f *Fetcher) Input(url string) Input {
+
+	return Input{Name: url, Open: func() (io.ReadCloser, error) {
+		return f.Open(url)
+	}}
+}
+
+// ReadInputs returns the inputs of patterns, in order. Glob patterns such
+// as "*.iso" are expanded to the files matching them, sorted by name,
+// skipping directories. Other patterns are used as is, http and https
+// urls are downloaded by a default Fetcher, urls of the schemes of
+// RegisterScheme are opened by them and "-" is stdin
+func ReadInputs(patterns []string) ([]Input, error) {
+
+	res := []Input{}
+	var fetcher *Fetcher
+	for _, pattern := range patterns {
+		if in, ok := SchemeInput(pattern); ok {
+			res = append(res, in)
+			continue
+		}
+		if IsURL(pattern) {
+			if fetcher == nil {
+				fetcher = NewFetcher()
+			}
+			res = append(res, fetcher.Input(pattern))
+			continue
+		}
+		if !isGlob(pattern) {
+			res = append(res, FileInput(pattern))
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pattern, err)
+		}
+		found := false
+		for _, name := range matches {
+			if fi, err := os.Stat(name); err == nil && fi.IsDir() {
+				continue
+			}
+			res = append(res, FileInput(name))
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+	}
+	return res, nil
+}
+
+// ReadFiles0 returns the inputs of the NUL separated file names read from
+// r, as printed by "find -print0", so names may have any other byte. The
+// names are used as is, without expanding globs
+func ReadFiles0(r io.Reader) ([]Input, error) {
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	names := strings.Split(strings.TrimSuffix(string(b), "\x00"), "\x00")
+	res := []Input{}
+	for i, name := range names {
+		if name == "" {
+			if len(names) == 1 {
+				// no names at all
+				break
+			}
+			return nil, fmt.Errorf("file name %d of the list is empty", i+1)
+		}
+		res = append(res, FileInput(name))
+	}
+	return res, nil
+}
+
+// isGlob returns true if pattern has any glob meta characters
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// HashInputs hashes each input with algo, workers of them at once, and
+// returns their results in the order of inputs. An input that can not be
+// hashed gets an error, without stopping the others
+func HashInputs(inputs []Input, algo string, encoding string, workers int) []InputResult {
+
+	if workers < 1 {
+		workers = 1
+	}
+	res := make([]InputResult, len(inputs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				res[i] = hashInput(inputs[i], algo, encoding)
+			}
+		}()
+	}
+	for i := range inputs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return res
+}
+
+// hashInput returns the InputResult of input
+func hashInput(input Input, algo string, encoding string) InputResult {
+
+	res := InputResult{Input: input}
+	r, err := input.Open()
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer r.Close()
+	res.Result, res.Err = ReaderResult(algo, input.Name, encoding, r)
+	return res
+}
@@ -0,0 +1,100 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Equal(t, nil, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.Equal(t, nil, os.WriteFile(path, []byte(data), 0644))
+	}
+}
+
+func inputNames(inputs []Input) []string {
+
+	res := []string{}
+	for _, in := range inputs {
+		res = append(res, in.Name)
+	}
+	return res
+}
+
+func TestReadInputs(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"b.iso":     "b",
+		"a.iso":     "a",
+		"c.txt":     "c",
+		"dir.iso/d": "d",
+	})
+
+	inputs, err := ReadInputs([]string{
+		filepath.Join(dir, "*.iso"),
+		filepath.Join(dir, "c.txt"),
+		"-",
+		"https://example.com/e.iso",
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.iso"),
+		filepath.Join(dir, "b.iso"),
+		filepath.Join(dir, "c.txt"),
+		"-",
+		"https://example.com/e.iso",
+	}, inputNames(inputs))
+
+	_, err = ReadInputs([]string{filepath.Join(dir, "*.zip")})
+	assert.Equal(t, "no files match "+filepath.Join(dir, "*.zip"), err.Error())
+
+	_, err = ReadInputs([]string{"[a"})
+	assert.Equal(t, "[a: syntax error in pattern", err.Error())
+}
+
+func TestReadFiles0(t *testing.T) {
+
+	inputs, err := ReadFiles0(strings.NewReader("a b\x00new\nline\x00*.iso\x00"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"a b", "new\nline", "*.iso"}, inputNames(inputs))
+
+	inputs, err = ReadFiles0(strings.NewReader("last"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"last"}, inputNames(inputs))
+
+	inputs, err = ReadFiles0(strings.NewReader(""))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{}, inputNames(inputs))
+
+	_, err = ReadFiles0(strings.NewReader("a\x00\x00b"))
+	assert.Equal(t, "file name 2 of the list is empty", err.Error())
+}
+
+func TestHashInputs(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+
+	inputs, err := ReadInputs([]string{filepath.Join(dir, "*.txt"), filepath.Join(dir, "nope.txt")})
+	assert.Equal(t, nil, err)
+
+	res := HashInputs(inputs, "md5", "hex", 2)
+	assert.Equal(t, 4, len(res))
+	for i, data := range []string{"a", "b", "c"} {
+		assert.Equal(t, nil, res[i].Err)
+		assert.Equal(t, inputs[i].Name, res[i].Result.Name)
+		assert.Equal(t, *NewCalculator([]byte(data)).Sum("md5"), res[i].Result.Digest)
+		assert.Equal(t, int64(1), res[i].Result.Size)
+	}
+	assert.Equal(t, "0cc175b9c0f1b6a831c399e269772661", hex.EncodeToString(res[0].Result.Digest))
+	assert.Equal(t, true, os.IsNotExist(res[3].Err))
+	assert.Equal(t, (*Result)(nil), res[3].Result)
+}
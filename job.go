@@ -0,0 +1,143 @@
+package gohash
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Job is a search described in a job file, see LoadJob
+type Job struct {
+	Algo       string `json:"algo,omitempty" yaml:"algo,omitempty"`
+	Template   string `json:"template,omitempty" yaml:"template,omitempty"`
+	Salt       string `json:"salt,omitempty" yaml:"salt,omitempty"`
+	Iterations int    `json:"iterations,omitempty" yaml:"iterations,omitempty"`
+
+	// one expected hash or several, or a digest prefix
+	Hash         string   `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Hashes       []string `json:"hashes,omitempty" yaml:"hashes,omitempty"`
+	DigestPrefix string   `json:"digest_prefix,omitempty" yaml:"digest_prefix,omitempty"`
+
+	// hashes of other algos, by algo
+	Also map[string][]string `json:"also,omitempty" yaml:"also,omitempty"`
+
+	// the keys, from allowed keys or a mask
+	Allowed        string   `json:"allowed,omitempty" yaml:"allowed,omitempty"`
+	AllowedCharset string   `json:"allowed_charset,omitempty" yaml:"allowed_charset,omitempty"`
+	Mask           string   `json:"mask,omitempty" yaml:"mask,omitempty"`
+	Charsets       []string `json:"charsets,omitempty" yaml:"charsets,omitempty"`
+	Length         int      `json:"length,omitempty" yaml:"length,omitempty"`
+	MinLength      int      `json:"min_length,omitempty" yaml:"min_length,omitempty"`
+	MaxLength      int      `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	Prefix         string   `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Suffix         string   `json:"suffix,omitempty" yaml:"suffix,omitempty"`
+
+	// wordlists searched in order instead of the keys, with any rules file
+	Wordlists []string `json:"wordlists,omitempty" yaml:"wordlists,omitempty"`
+	Rules     string   `json:"rules,omitempty" yaml:"rules,omitempty"`
+
+	Workers int `json:"workers,omitempty" yaml:"workers,omitempty"`
+
+	hasher *Hasher
+}
+
+// LoadJob reads a job file, in yaml or json, and configures a Hasher from
+// it. Mistakes in the job are reported here, not when the search starts
+func LoadJob(r io.Reader) (*Job, error) {
+
+	job := &Job{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(job); err != nil {
+		return nil, fmt.Errorf("job: %v", err)
+	}
+
+	h, err := NewHasherWithOptions(job.options()...)
+	if err != nil {
+		return nil, fmt.Errorf("job: %v", err)
+	}
+	if job.Rules != "" {
+		f, err := os.Open(job.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("job: %v", err)
+		}
+		defer f.Close()
+		rules, err := ParseRules(f)
+		if err != nil {
+			return nil, fmt.Errorf("job: %v", err)
+		}
+		h.Rules(rules)
+	}
+	job.hasher = h
+	return job, nil
+}
+
+// options returns the settings of the job
+func (j *Job) options() []Option {
+
+	opts := []Option{}
+	add := func(set bool, opt Option) {
+		if set {
+			opts = append(opts, opt)
+		}
+	}
+	add(j.Algo != "", WithAlgo(j.Algo))
+	add(j.Template != "", WithTemplate(j.Template))
+	add(j.Salt != "", WithSalt(j.Salt))
+	add(j.Iterations != 0, WithIterations(j.Iterations))
+	add(j.Hash != "", WithExpectedHash(j.Hash))
+	add(j.Hashes != nil, WithExpectedHashes(j.Hashes))
+	add(j.DigestPrefix != "", WithDigestPrefix(j.DigestPrefix))
+	for algo, hashes := range j.Also {
+		opts = append(opts, WithAlsoExpect(algo, hashes))
+	}
+	add(j.Allowed != "", WithAllowedKeys(j.Allowed))
+	add(j.AllowedCharset != "", WithAllowedCharset(j.AllowedCharset))
+	add(j.Mask != "", WithMask(j.Mask))
+	for i, keys := range j.Charsets {
+		opts = append(opts, WithCustomCharset(i+1, keys))
+	}
+	add(j.Length != 0, WithLength(j.Length))
+	add(j.MinLength != 0, WithMinLength(j.MinLength))
+	add(j.MaxLength != 0, WithMaxLength(j.MaxLength))
+	add(j.Prefix != "", WithPrefix(j.Prefix))
+	add(j.Suffix != "", WithSuffix(j.Suffix))
+	add(j.Workers != 0, WithWorkers(j.Workers))
+	return opts
+}
+
+// Hasher returns the Hasher configured by the job, for further settings
+func (j *Job) Hasher() *Hasher {
+	return j.hasher
+}
+
+// Find runs the job: the wordlists in order if there are any, otherwise
+// FindSequential
+func (j *Job) Find() (string, error) {
+	return j.FindContext(context.Background())
+}
+
+// FindContext is like Find, but stops with the error of ctx when it is
+// done
+func (j *Job) FindContext(ctx context.Context) (string, error) {
+
+	if len(j.Wordlists) == 0 {
+		return j.hasher.FindSequentialContext(ctx)
+	}
+
+	// one search over all the wordlists, so every match is kept
+	readers := []io.Reader{}
+	for _, name := range j.Wordlists {
+		f, err := os.Open(name)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		readers = append(readers, f, strings.NewReader("\n"))
+	}
+	return j.hasher.FindFromWordlistContext(ctx, io.MultiReader(readers...))
+}
@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJobYAML(t *testing.T) {
+
+	job, err := LoadJob(strings.NewReader(`
+algo: md5
+hash: ` + md5Hej + `
+allowed: ehj
+length: 3
+workers: 2
+`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "md5", job.Algo)
+	assert.Equal(t, 2, job.Workers)
+
+	res, err := job.Find()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestLoadJobJSON(t *testing.T) {
+
+	job, err := LoadJob(strings.NewReader(`{
+		"algo": "md5",
+		"hashes": ["` + md5Hej + `", "` + md5Tex + `"],
+		"mask": "?1?1?1",
+		"charsets": ["ehjtx"]
+	}`))
+	assert.Equal(t, nil, err)
+
+	_, err = job.Find()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{md5Hej: "hej", md5Tex: "tex"}, job.Hasher().Found())
+}
+
+func TestLoadJobWordlists(t *testing.T) {
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+	assert.Equal(t, nil, os.WriteFile(first, []byte("foo\nbar\n"), 0644))
+	assert.Equal(t, nil, os.WriteFile(second, []byte("baz\nhej\n"), 0644))
+
+	job, err := LoadJob(strings.NewReader(`
+algo: md5
+hash: ` + md5Hej + `
+wordlists:
+  - ` + first + `
+  - ` + second + `
+`))
+	assert.Equal(t, nil, err)
+
+	res, err := job.Find()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestLoadJobErrors(t *testing.T) {
+
+	_, err := LoadJob(strings.NewReader("algo: nope\n"))
+	assert.Equal(t, "job: unknown algo nope", err.Error())
+
+	_, err = LoadJob(strings.NewReader("algo: md5\nlenght: 3\n"))
+	assert.Equal(t, "job: yaml: unmarshal errors:\n  line 2: field lenght not found in type gohash.Job", err.Error())
+
+	_, err = LoadJob(strings.NewReader("algo: md5\nrules: /nonexistent/rules.txt\n"))
+	assert.Equal(t, true, strings.HasPrefix(err.Error(), "job: open /nonexistent/rules.txt"))
+}
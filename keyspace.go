@@ -0,0 +1,41 @@
+package gohash
+
+import "math/big"
+
+// Keyspace returns the number of keys of all lengths between min and max
+// length, with the allowed keys, mask and position keys
+func (h *Hasher) Keyspace() (*big.Int, error) {
+
+	if err := h.verifyKeys(); err != nil {
+		return nil, err
+	}
+	return h.keyspace(), nil
+}
+
+// keyspace returns the number of keys of all lengths
+func (h *Hasher) keyspace() *big.Int {
+
+	res := new(big.Int)
+	for length := h.minLength; length <= h.maxLength; length++ {
+		res.Add(res, h.lengthKeyspace(length))
+	}
+	return res
+}
+
+// lengthKeyspace returns the number of keys of length
+func (h *Hasher) lengthKeyspace(length int) *big.Int {
+
+	res := big.NewInt(1)
+	for _, keys := range h.positions[:length] {
+		res.Mul(res, big.NewInt(int64(len(keys))))
+	}
+	return res
+}
+
+// keyspaceSize returns the number of keys of all lengths, or false if
+// it does not fit in an uint64
+func (h *Hasher) keyspaceSize() (uint64, bool) {
+
+	n := h.keyspace()
+	return n.Uint64(), n.IsUint64()
+}
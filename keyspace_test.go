@@ -0,0 +1,65 @@
+package gohash
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyspace(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.AllowedKeys("abc")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+
+	n, err := hasher.Keyspace()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(3+9+27), n)
+
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	n, err = hasher.Keyspace()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "1208925819614629174706176", n.String())
+}
+
+func TestKeyspaceMask(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Mask("?d?l")
+	hasher.PositionKeys(0, "123")
+
+	n, err := hasher.Keyspace()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, big.NewInt(3*26), n)
+}
+
+func TestKeyspaceUnset(t *testing.T) {
+
+	hasher := NewHasher()
+	_, err := hasher.Keyspace()
+	assert.Equal(t, "minLength unset", err.Error())
+}
+
+func TestKeyspaceSize(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abc")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+	hasher.ExpectedHash("07159c47ee1b19ae4fb9c40d480856c4")
+	assert.Equal(t, nil, hasher.verify())
+
+	n, ok := hasher.keyspaceSize()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(3+9+27), n)
+
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	assert.Equal(t, nil, hasher.verify())
+	_, ok = hasher.keyspaceSize()
+	assert.Equal(t, false, ok)
+}
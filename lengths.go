@@ -0,0 +1,228 @@
+package gohash
+
+import (
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+var (
+	// upper bounds of the encoded length of n bytes
+	encodedLens = map[string]func(c *Coder, n int) int{
+		"ascii85": func(c *Coder, n int) int {
+			if c.adobe {
+				return ascii85.MaxEncodedLen(n) + len(ascii85Start) + len(ascii85End)
+			}
+			return ascii85.MaxEncodedLen(n)
+		},
+		"base100":     func(c *Coder, n int) int { return 4 * n },
+		"base122":     func(c *Coder, n int) int { return n*8/7 + 2 },
+		"base2048":    func(c *Coder, n int) int { return (n*8 + 10) / 11 * 3 },
+		"base32":      func(c *Coder, n int) int { return base32.StdEncoding.EncodedLen(n) },
+		"base32hex":   func(c *Coder, n int) int { return base32.HexEncoding.EncodedLen(n) },
+		"base36":      func(c *Coder, n int) int { return bigRadixLen(n, 36) },
+		"base45":      func(c *Coder, n int) int { return n/2*3 + n%2*2 },
+		"base58":      func(c *Coder, n int) int { return bigRadixLen(n, 58) },
+		"base58check": func(c *Coder, n int) int { return bigRadixLen(n+5, 58) },
+		"base64":      func(c *Coder, n int) int { return base64.StdEncoding.EncodedLen(n) },
+		"base65536":   func(c *Coder, n int) int { return (n + 1) / 2 * 4 },
+		"base91":      func(c *Coder, n int) int { return n*16/13 + 2 },
+		"bech32": func(c *Coder, n int) int {
+			return len(c.bech32HRP()) + 1 + (n*8+4)/5 + 6
+		},
+		"bech32m": func(c *Coder, n int) int {
+			return len(c.bech32HRP()) + 1 + (n*8+4)/5 + 6
+		},
+		"binary":        func(c *Coder, n int) int { return separatedLen(n, 8) },
+		"bubblebabble":  func(c *Coder, n int) int { return 3*n + 5 },
+		"c-array":       func(c *Coder, n int) int { return 9*n + 10 },
+		"crockford":     func(c *Coder, n int) int { return crockfordEncoding.EncodedLen(n) },
+		"crockford-chk": func(c *Coder, n int) int { return crockfordEncoding.EncodedLen(n) + 1 },
+		"decimal":       func(c *Coder, n int) int { return separatedLen(n, 3) },
+		"fingerprint":   func(c *Coder, n int) int { return separatedLen(n, 2) },
+		"go-bytes":      func(c *Coder, n int) int { return 9*n + 10 },
+		"hex":           func(c *Coder, n int) int { return 2 * n },
+		"hex-colons":    func(c *Coder, n int) int { return separatedLen(n, 2) },
+		"hexdump":       func(c *Coder, n int) int { return (n + hexdumpLineLen - 1) / hexdumpLineLen * 68 },
+		"hexup":         func(c *Coder, n int) int { return 2 * n },
+		"ihex": func(c *Coder, n int) int {
+			records := (n+recordDataLen-1)/recordDataLen + n/0x10000
+			return records*(11+2*recordDataLen+1) + 11
+		},
+		"morse": func(c *Coder, n int) int {
+			// bytes are separated by " / "
+			return separatedLen(n, 11+2)
+		},
+		"octal":        func(c *Coder, n int) int { return separatedLen(n, 4) },
+		"percent":      func(c *Coder, n int) int { return 3 * n },
+		"pgpwords":     func(c *Coder, n int) int { return separatedLen(n, pgpMaxWordLen()) },
+		"proquint":     func(c *Coder, n int) int { return separatedLen(n/2, 5) },
+		"python-bytes": func(c *Coder, n int) int { return 9*n + 3 },
+		"quotedprintable": func(c *Coder, n int) int {
+			// soft line breaks keep lines within 76 characters
+			return 3*n + (3*n/73+1)*3
+		},
+		"rfc1924": func(c *Coder, n int) int { return rfc1924Width(n) },
+		"srec": func(c *Coder, n int) int {
+			records := (n+recordDataLen-1)/recordDataLen + 2
+			return records * (4 + 2*(4+recordDataLen+1) + 1)
+		},
+		"uu":       func(c *Coder, n int) int { return (n+2)/3*4 + 1 },
+		"uuencode": func(c *Coder, n int) int { return uuBlockLen(n) },
+		"xxencode": func(c *Coder, n int) int { return uuBlockLen(n) },
+		"z85": func(c *Coder, n int) int {
+			if c.padCount {
+				return (n+3)/4*5 + 1
+			}
+			return (n + 3) / 4 * 5
+		},
+		"zbase32": func(c *Coder, n int) int { return zbase32Encoding.EncodedLen(n) },
+	}
+
+	// upper bounds of the decoded length of n bytes, for encodings that
+	// may decode to more than n bytes or have a tighter bound
+	decodedLens = map[string]func(c *Coder, n int) int{
+		"ascii85":     func(c *Coder, n int) int { return 4 * n },
+		"base100":     func(c *Coder, n int) int { return n / 4 },
+		"base32":      func(c *Coder, n int) int { return (n + 7) / 8 * 5 },
+		"base32hex":   func(c *Coder, n int) int { return (n + 7) / 8 * 5 },
+		"base64":      func(c *Coder, n int) int { return (n + 3) / 4 * 3 },
+		"binary":      func(c *Coder, n int) int { return (n + 1) / 2 },
+		"decimal":     func(c *Coder, n int) int { return (n + 1) / 2 },
+		"fingerprint": func(c *Coder, n int) int { return (n + 1) / 3 },
+		"hex":         func(c *Coder, n int) int { return n / 2 },
+		"hex-colons":  func(c *Coder, n int) int { return (n + 1) / 3 },
+		"hexup":       func(c *Coder, n int) int { return n / 2 },
+		"octal":       func(c *Coder, n int) int { return (n + 1) / 2 },
+		"proquint":    func(c *Coder, n int) int { return (n + 1) / 6 * 2 },
+		"z85":         func(c *Coder, n int) int { return n / 5 * 4 },
+
+		// gaps between records are filled in
+		"ihex": func(c *Coder, n int) int { return maxMemoryImage },
+		"srec": func(c *Coder, n int) int { return maxMemoryImage },
+	}
+)
+
+// EncodedLen returns the maximum length of the encoding of n bytes, so
+// buffers can be allocated and size limits enforced before encoding
+func (c *Coder) EncodedLen(n int) (int, error) {
+
+	if c.stages != nil {
+		var err error
+		for _, stage := range c.stages {
+			if n, err = c.stage(stage).EncodedLen(n); err != nil {
+				return 0, err
+			}
+		}
+		return n, nil
+	}
+	if c.alphabet != nil {
+		return bigRadixLen(n, len(c.alphabet)) * maxRuneLen(c.alphabet), nil
+	}
+	if c.encoding == "bip39" {
+		return bip39EncodedLen(c, n)
+	}
+	fn, ok := encodedLens[c.encoding]
+	if !ok {
+		return 0, fmt.Errorf("unknown encoding: %s", c.encoding)
+	}
+	res := fn(c, n)
+	if c.wrap > 0 && wrappableEncodings[c.encoding] && res > 0 {
+		eol := 1
+		if c.crlf {
+			eol = 2
+		}
+		res += (res - 1) / c.wrap * eol
+	}
+	return res, nil
+}
+
+// DecodedLen returns the maximum length of the decoding of n bytes
+func (c *Coder) DecodedLen(n int) (int, error) {
+
+	if c.stages != nil {
+		var err error
+		for i := len(c.stages) - 1; i >= 0; i-- {
+			if n, err = c.stage(c.stages[i]).DecodedLen(n); err != nil {
+				return 0, err
+			}
+		}
+		return n, nil
+	}
+	if c.alphabet != nil {
+		return n, nil
+	}
+	if fn, ok := decodedLens[c.encoding]; ok {
+		return fn(c, n), nil
+	}
+	if _, ok := encodedLens[c.encoding]; !ok && c.encoding != "bip39" {
+		return 0, fmt.Errorf("unknown encoding: %s", c.encoding)
+	}
+	// all other encodings use at least one byte per decoded byte
+	return n, nil
+}
+
+// bigRadixLen returns the maximum number of digits when encoding n bytes
+// as one big number in base, with each leading zero byte as a zero digit
+func bigRadixLen(n int, base int) int {
+	return int(math.Ceil(float64(n*8)/math.Log2(float64(base)))) + 1
+}
+
+// separatedLen returns the length of n items of at most width bytes,
+// separated by a one byte separator
+func separatedLen(n int, width int) int {
+
+	if n == 0 {
+		return 0
+	}
+	return n*(width+len(separator)) - len(separator)
+}
+
+func uuBlockLen(n int) int {
+	lines := (n + uuLineLength - 1) / uuLineLength
+	return len("begin 644 data\n") + lines*(1+uuLineLength/3*4+1) + len("`\nend\n")
+}
+
+func bip39EncodedLen(c *Coder, n int) (int, error) {
+
+	words, err := bip39Words(c.bip39Wordlist())
+	if err != nil {
+		return 0, err
+	}
+	longest := 0
+	for _, word := range words {
+		if len(word) > longest {
+			longest = len(word)
+		}
+	}
+	// the ideographic space of the japanese wordlist is 3 bytes
+	return (n*8 + n/4) / 11 * (longest + 3), nil
+}
+
+func pgpMaxWordLen() int {
+
+	res := 0
+	for i := range pgpEvenWords {
+		if len(pgpEvenWords[i]) > res {
+			res = len(pgpEvenWords[i])
+		}
+		if len(pgpOddWords[i]) > res {
+			res = len(pgpOddWords[i])
+		}
+	}
+	return res
+}
+
+func maxRuneLen(runes []rune) int {
+
+	res := 0
+	for _, r := range runes {
+		if utf8.RuneLen(r) > res {
+			res = utf8.RuneLen(r)
+		}
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodedLenIsUpperBound(t *testing.T) {
+
+	for encoding := range expectedEncodings {
+		coder := NewCoder(encoding)
+		for n := 0; n <= 100; n++ {
+			src := make([]byte, n)
+			rand.Read(src)
+			if n%7 == 0 && n > 0 {
+				// leading zero bytes
+				src[0] = 0
+			}
+			enc, err := coder.Encode(src)
+			if err != nil {
+				continue
+			}
+			max, err := coder.EncodedLen(n)
+			assert.Equal(t, nil, err, encoding)
+			assert.True(t, len(enc) <= max, "%s: %d bytes encoded to %d, estimated %d", encoding, n, len(enc), max)
+
+			max, err = coder.DecodedLen(len(enc))
+			assert.Equal(t, nil, err, encoding)
+			assert.True(t, n <= max, "%s: %d bytes decoded to %d, estimated %d", encoding, len(enc), n, max)
+		}
+	}
+}
+
+func TestEncodedLenOptions(t *testing.T) {
+
+	coder := NewCoder("base64")
+	coder.Wrap(4)
+	coder.CRLF(true)
+	n, err := coder.EncodedLen(6)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 10, n)
+
+	coder = NewCoder("ascii85")
+	coder.AdobeFraming(true)
+	n, err = coder.EncodedLen(4)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 9, n)
+
+	coder = NewCoder("bech32")
+	coder.HRP("bc")
+	n, err = coder.EncodedLen(20)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 41, n)
+}
+
+func TestEncodedLenPipeline(t *testing.T) {
+
+	coder := NewCoder("hex|base64")
+	n, err := coder.EncodedLen(3)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 8, n)
+
+	n, err = coder.DecodedLen(8)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestEncodedLenUnknownEncoding(t *testing.T) {
+
+	_, err := NewCoder("nope").EncodedLen(1)
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoder("nope").DecodedLen(1)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,82 @@
+package gohash
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LimitError is returned when a search stops at MaxAttempts or MaxDuration
+// without finding a match
+type LimitError struct {
+	// Timeout is true when MaxDuration was reached, otherwise MaxAttempts
+	Timeout bool
+	Tries   uint64
+	Elapsed time.Duration
+}
+
+func (e *LimitError) Error() string {
+
+	if e.Timeout {
+		return fmt.Sprintf("timeout after %s, %d tries", e.Elapsed.Round(time.Millisecond), e.Tries)
+	}
+	return fmt.Sprintf("attempts exhausted after %d tries", e.Tries)
+}
+
+// MaxAttempts stops the search with a *LimitError after n tries. The
+// tries are counted in batches, so a few thousand more may be made
+func (h *Hasher) MaxAttempts(n uint64) {
+	h.maxAttempts = n
+}
+
+// MaxDuration stops the search with a *LimitError after d
+func (h *Hasher) MaxDuration(d time.Duration) {
+	h.maxDuration = d
+}
+
+// limited runs the search fn, stopping it when a limit is reached
+func (h *Hasher) limited(ctx context.Context, fn func(context.Context) (string, error)) (string, error) {
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if h.maxDuration > 0 {
+		search, cancel = context.WithTimeout(search, h.maxDuration)
+		defer cancel()
+	}
+
+	mutex.Lock()
+	h.stop = cancel
+	h.stopped = search.Done()
+	mutex.Unlock()
+	started := time.Now()
+
+	res, err := fn(search)
+
+	mutex.Lock()
+	h.stop = nil
+	h.stopped = nil
+	tries := h.try
+	if h.result != nil && err == nil {
+		// every try of the search, reported when the workers stopped
+		h.result.Tries = tries
+	}
+	mutex.Unlock()
+
+	if err == nil || err != search.Err() || ctx.Err() != nil {
+		return res, err
+	}
+	return "", &LimitError{
+		Timeout: h.maxAttempts == 0 || tries < h.maxAttempts,
+		Tries:   tries,
+		Elapsed: time.Since(started),
+	}
+}
+
+// checkAttempts stops the search when MaxAttempts is reached. The mutex
+// must be held
+func (h *Hasher) checkAttempts() {
+
+	if h.maxAttempts != 0 && h.try >= h.maxAttempts && h.stop != nil {
+		h.stop()
+	}
+}
@@ -0,0 +1,118 @@
+package gohash
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashMaxAttempts(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(6)
+	hasher.Workers(2)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxAttempts(10000)
+
+	_, err := hasher.FindSequential()
+	limitErr, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, false, limitErr.Timeout)
+	assert.Equal(t, true, limitErr.Tries >= 10000)
+	assert.Equal(t, true, strings.HasPrefix(err.Error(), "attempts exhausted after "))
+}
+
+func TestHashMaxAttemptsRandom(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxAttempts(5000)
+
+	_, err := hasher.FindRandom()
+	limitErr, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, false, limitErr.Timeout)
+}
+
+func TestHashMaxDuration(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxDuration(10 * time.Millisecond)
+
+	_, err := hasher.FindSequential()
+	limitErr, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, limitErr.Timeout)
+	assert.Equal(t, true, limitErr.Elapsed >= 10*time.Millisecond)
+	assert.Equal(t, true, strings.HasPrefix(err.Error(), "timeout after "))
+}
+
+func TestHashMaxDurationWordlist(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxDuration(time.Nanosecond)
+
+	words := strings.Repeat("word\n", 10000)
+	_, err := hasher.FindFromWordlist(strings.NewReader(words))
+	_, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+}
+
+func TestHashLimitNotReached(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abc")
+	hasher.Length(2)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxAttempts(1000)
+	hasher.MaxDuration(time.Minute)
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+}
+
+func TestHashLimitParentContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys(allowedOnion)
+	hasher.Length(16)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.MaxDuration(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := hasher.FindSequentialContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestHashAllMaxAttempts(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("crc32")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(5)
+	hasher.DigestPrefix("0")
+	hasher.Workers(1)
+	hasher.MaxAttempts(100000)
+
+	res, err := hasher.FindAll()
+	_, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+	assert.NotEqual(t, 0, len(res))
+}
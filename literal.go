@@ -0,0 +1,165 @@
+package gohash
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// encodeCArray renders src as a C array initializer, {0xde, 0xad}
+func encodeCArray(c *Coder, src []byte) ([]byte, error) {
+	return []byte(byteListLiteral("{", "}", "  ", false, c.width, src)), nil
+}
+
+// encodeGoBytes renders src as a Go byte slice literal, []byte{0xde, 0xad}
+func encodeGoBytes(c *Coder, src []byte) ([]byte, error) {
+	return []byte(byteListLiteral("[]byte{", "}", "\t", true, c.width, src)), nil
+}
+
+// encodePythonBytes renders src as a Python bytes literal, b"\xde\xad".
+// When wrapped, the lines are implicitly concatenated within parentheses
+func encodePythonBytes(c *Coder, src []byte) ([]byte, error) {
+
+	lines := []string{}
+	for _, chunk := range chunkBytes(src, c.width) {
+		var sb strings.Builder
+		sb.WriteString(`b"`)
+		for _, b := range chunk {
+			fmt.Fprintf(&sb, `\x%02x`, b)
+		}
+		sb.WriteString(`"`)
+		lines = append(lines, sb.String())
+	}
+	if len(lines) == 1 {
+		return []byte(lines[0]), nil
+	}
+	return []byte("(" + strings.Join(lines, "\n ") + ")"), nil
+}
+
+// decodeByteList decodes c-array and go-bytes literals. Anything outside
+// the braces, such as a declaration, is ignored
+func decodeByteList(c *Coder, src []byte) ([]byte, error) {
+
+	s := string(src)
+	start := strings.IndexByte(s, '{')
+	end := strings.LastIndexByte(s, '}')
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("literal: missing braces")
+	}
+
+	res := []byte{}
+	for _, tok := range strings.Split(s[start+1:end], ",") {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(tok, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("literal: invalid byte %q", tok)
+		}
+		res = append(res, byte(n))
+	}
+	return res, nil
+}
+
+// decodePythonBytes decodes one or more adjacent Python bytes literals,
+// optionally within parentheses
+func decodePythonBytes(c *Coder, src []byte) ([]byte, error) {
+
+	s := strings.TrimSpace(string(src))
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = s[1 : len(s)-1]
+	}
+
+	res := []byte{}
+	for {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return res, nil
+		}
+		if len(s) < 3 || (s[0] != 'b' && s[0] != 'B') || (s[1] != '"' && s[1] != '\'') {
+			return nil, fmt.Errorf("literal: expected bytes literal at %q", s)
+		}
+		quote := s[1]
+		i := 2
+		for ; i < len(s) && s[i] != quote; i++ {
+			if s[i] != '\\' {
+				res = append(res, s[i])
+				continue
+			}
+			if i+1 >= len(s) {
+				return nil, fmt.Errorf("literal: unterminated escape")
+			}
+			i++
+			switch s[i] {
+			case 'x':
+				if i+2 >= len(s) || !isHexByte(s[i+1]) || !isHexByte(s[i+2]) {
+					return nil, fmt.Errorf("literal: invalid \\x escape")
+				}
+				res = append(res, hexValue(s[i+1])<<4|hexValue(s[i+2]))
+				i += 2
+			case 'n':
+				res = append(res, '\n')
+			case 'r':
+				res = append(res, '\r')
+			case 't':
+				res = append(res, '\t')
+			case '0':
+				res = append(res, 0)
+			case '\\', '\'', '"':
+				res = append(res, s[i])
+			default:
+				return nil, fmt.Errorf("literal: unsupported escape \\%c", s[i])
+			}
+		}
+		if i >= len(s) {
+			return nil, fmt.Errorf("literal: unterminated bytes literal")
+		}
+		s = s[i+1:]
+	}
+}
+
+// byteListLiteral renders src as comma separated hex bytes between open
+// and close, with width bytes per indented line if width > 0
+func byteListLiteral(open, close, indent string, trailingComma bool, width int, src []byte) string {
+
+	chunks := chunkBytes(src, width)
+	lines := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		items := make([]string, len(chunk))
+		for j, b := range chunk {
+			items[j] = fmt.Sprintf("0x%02x", b)
+		}
+		lines[i] = strings.Join(items, ", ")
+	}
+	if width <= 0 || len(src) <= width {
+		return open + lines[0] + close
+	}
+
+	var sb strings.Builder
+	sb.WriteString(open + "\n")
+	for i, line := range lines {
+		sb.WriteString(indent + line)
+		if i < len(lines)-1 || trailingComma {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(close)
+	return sb.String()
+}
+
+// chunkBytes splits src in chunks of width bytes, or returns a single
+// chunk if width <= 0
+func chunkBytes(src []byte, width int) [][]byte {
+
+	if width <= 0 || len(src) <= width {
+		return [][]byte{src}
+	}
+	res := [][]byte{}
+	for len(src) > width {
+		res = append(res, src[:width])
+		src = src[width:]
+	}
+	return append(res, src)
+}
@@ -0,0 +1,54 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiteralWrapping(t *testing.T) {
+
+	src := []byte{0xde, 0xad, 0xbe, 0xef, 0x01}
+
+	for encoding, expected := range map[string]string{
+		"c-array":      "{\n  0xde, 0xad,\n  0xbe, 0xef,\n  0x01\n}",
+		"go-bytes":     "[]byte{\n\t0xde, 0xad,\n\t0xbe, 0xef,\n\t0x01,\n}",
+		"python-bytes": "(b\"\\xde\\xad\"\n b\"\\xbe\\xef\"\n b\"\\x01\")",
+	} {
+		coder := NewCoder(encoding)
+		coder.Width(2)
+		res, err := coder.Encode(src)
+		assert.Equal(t, nil, err, encoding)
+		assert.Equal(t, expected, string(res), encoding)
+
+		dec, err := coder.Decode(res)
+		assert.Equal(t, nil, err, encoding)
+		assert.Equal(t, src, dec, encoding)
+	}
+}
+
+func TestLiteralDecodeLenient(t *testing.T) {
+
+	res, err := NewCoder("c").Decode([]byte("static const unsigned char digest[] = { 0xDE, 173, 0276, };"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe}, res)
+
+	res, err = NewCoder("python").Decode([]byte(`b'ab\n\x00' B"\\"`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("ab\n\x00\\"), res)
+}
+
+func TestLiteralDecodeInvalid(t *testing.T) {
+
+	_, err := NewCoder("go-bytes").Decode([]byte("[]byte{0x100}"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoder("go-bytes").Decode([]byte("0xde, 0xad"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoder("python-bytes").Decode([]byte(`b"\xd"`))
+	assert.NotEqual(t, nil, err)
+
+	_, err = NewCoder("python-bytes").Decode([]byte(`b"abc`))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,219 @@
+package gohash
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// CheckOK is the status of a file matching its digest
+	CheckOK = "OK"
+
+	// CheckFailed is the status of a file not matching its digest, or that
+	// could not be read
+	CheckFailed = "FAILED"
+
+	// CheckMissing is the status of a file that does not exist
+	CheckMissing = "missing"
+)
+
+var (
+	// escapes of file names in coreutils manifests
+	coreutilsEscaper   = strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+	coreutilsUnescaper = strings.NewReplacer("\\\\", "\\", "\\n", "\n", "\\r", "\r")
+
+	// the algo of each digest size in coreutils manifests, when unset
+	manifestAlgos = map[int]string{
+		128: "md5",
+		160: "sha1",
+		224: "sha224",
+		256: "sha256",
+		384: "sha384",
+		512: "sha512",
+	}
+)
+
+// ManifestEntry is a file and its expected digest, read from a manifest
+type ManifestEntry struct {
+	Name   string
+	Algo   string
+	Digest []byte
+	Binary bool
+}
+
+// CheckResult is the outcome of checking a file of a manifest
+type CheckResult struct {
+	ManifestEntry
+	Status string
+
+	// why a file is missing or failed, nil for a digest mismatch
+	Err error
+}
+
+// CheckReport is the outcome of checking all files of a manifest
+type CheckReport struct {
+	Results []CheckResult
+
+	// number of files of each status
+	OK      int
+	Failed  int
+	Missing int
+
+	// number of lines that are not a digest and file, nor comments
+	Malformed int
+}
+
+// Passed returns true if every file of the manifest matched, and it had
+// at least one file
+func (r *CheckReport) Passed() bool {
+	return r.Failed == 0 && r.Missing == 0 && r.OK > 0
+}
+
+// FormatCoreutils renders a digest like md5sum and sha256sum, as
+// "<hex>  <file>", or "<hex> *<file>" in binary mode. Like coreutils, the
+// line starts with a "\" when the file name has a "\", newline or CR,
+// which are escaped
+func FormatCoreutils(fileName string, digest []byte, binary bool) string {
+
+	mode := " "
+	if binary {
+		mode = "*"
+	}
+	escaped := coreutilsEscaper.Replace(fileName)
+	prefix := ""
+	if escaped != fileName {
+		prefix = "\\"
+	}
+	return prefix + hex.EncodeToString(digest) + " " + mode + escaped
+}
+
+// WriteManifest writes a line of md5sum and sha256sum compatible output
+// for each of the results, see FormatCoreutils
+func WriteManifest(w io.Writer, results []Result, binary bool) error {
+
+	for _, res := range results {
+		if _, err := io.WriteString(w, FormatCoreutils(res.Name, res.Digest, binary)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseCoreutils parses a line of md5sum and sha256sum output, returning
+// file name, digest and wether the file was read in binary mode
+func ParseCoreutils(line string) (string, []byte, bool, error) {
+
+	line = strings.TrimRight(line, "\r\n")
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+
+	sep := strings.Index(line, " ")
+	if sep < 1 || sep+2 > len(line) || (line[sep+1] != ' ' && line[sep+1] != '*') {
+		return "", nil, false, fmt.Errorf("coreutils: malformed line %q", line)
+	}
+	digest, err := hex.DecodeString(line[:sep])
+	if err != nil {
+		return "", nil, false, fmt.Errorf("coreutils: bad digest: %v", err)
+	}
+	name := line[sep+2:]
+	if name == "" {
+		return "", nil, false, fmt.Errorf("coreutils: malformed line %q", line)
+	}
+	if escaped {
+		name = coreutilsUnescaper.Replace(name)
+	}
+	return name, digest, line[sep+1] == '*', nil
+}
+
+// ReadManifest reads the files and digests of a md5sum or sha256sum
+// manifest, or of bsd tag format lines, see ParseBSD, which may be mixed.
+// Without algo, it is detected by the tag or by the size of each digest,
+// as md5, sha1, sha224, sha256, sha384 or sha512. Lines of a tag other
+// than algo are malformed. Returns the entries and the number of malformed
+// lines, empty lines and # comments are skipped
+func ReadManifest(r io.Reader, algo string) ([]ManifestEntry, int, error) {
+
+	if algo != "" {
+		algo = resolveAlgoAliases(strings.ToLower(algo))
+		if _, ok := algos[algo]; !ok {
+			return nil, 0, fmt.Errorf("unknown algo %s", algo)
+		}
+	}
+
+	res := []ManifestEntry{}
+	malformed := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entryAlgo := algo
+		name, digest, binary, err := ParseCoreutils(line)
+		if err != nil {
+			var tag string
+			if tag, name, digest, err = ParseBSD(line); err != nil || (algo != "" && tag != algo) {
+				malformed++
+				continue
+			}
+			entryAlgo = tag
+		}
+		if entryAlgo == "" {
+			entryAlgo = manifestAlgos[len(digest)*8]
+		}
+		if entryAlgo == "" || algos[entryAlgo] != len(digest)*8 {
+			malformed++
+			continue
+		}
+		res = append(res, ManifestEntry{Name: name, Algo: entryAlgo, Digest: digest, Binary: binary})
+	}
+	return res, malformed, scanner.Err()
+}
+
+// CheckManifest hashes each file of a md5sum or sha256sum manifest, see
+// ReadManifest, and reports which match their digest. The files are
+// streamed, relative paths are from the current directory
+func CheckManifest(r io.Reader, algo string) (*CheckReport, error) {
+
+	entries, malformed, err := ReadManifest(r, algo)
+	if err != nil {
+		return nil, err
+	}
+	report := CheckEntries(entries)
+	report.Malformed = malformed
+	return report, nil
+}
+
+// CheckEntries hashes the file of each entry, and reports which match
+// their digest
+func CheckEntries(entries []ManifestEntry) *CheckReport {
+
+	report := &CheckReport{Results: []CheckResult{}}
+	for _, entry := range entries {
+		res := CheckResult{ManifestEntry: entry, Status: CheckOK}
+		hashed := hashInput(FileInput(entry.Name), entry.Algo, "")
+		switch {
+		case os.IsNotExist(hashed.Err):
+			res.Status = CheckMissing
+			res.Err = hashed.Err
+			report.Missing++
+		case hashed.Err != nil:
+			res.Status = CheckFailed
+			res.Err = hashed.Err
+			report.Failed++
+		case !byteArrayEquals(hashed.Result.Digest, entry.Digest):
+			res.Status = CheckFailed
+			report.Failed++
+		default:
+			report.OK++
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report
+}
@@ -0,0 +1,127 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCoreutils(t *testing.T) {
+
+	digest, _ := hex.DecodeString(md5Hej)
+	tests := []struct {
+		name   string
+		binary bool
+		line   string
+	}{
+		{"a.iso", false, md5Hej + "  a.iso"},
+		{"a.iso", true, md5Hej + " *a.iso"},
+		{"dir/with space.txt", false, md5Hej + "  dir/with space.txt"},
+		{"new\nline", false, "\\" + md5Hej + "  new\\nline"},
+		{`back\slash`, true, "\\" + md5Hej + ` *back\\slash`},
+		{"cr\r", false, "\\" + md5Hej + "  cr\\r"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.line, FormatCoreutils(test.name, digest, test.binary), test.name)
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+
+	results := []Result{
+		{Algo: "md5", Name: "a.txt", Digest: *NewCalculator([]byte("a")).Sum("md5")},
+		{Algo: "md5", Name: "b.txt", Digest: *NewCalculator([]byte("b")).Sum("md5")},
+	}
+	var buf bytes.Buffer
+	assert.Equal(t, nil, WriteManifest(&buf, results, false))
+	assert.Equal(t, "0cc175b9c0f1b6a831c399e269772661  a.txt\n"+
+		"92eb5ffee6ae2fec3ad71c777531578f  b.txt\n", buf.String())
+}
+
+func TestParseCoreutils(t *testing.T) {
+
+	for _, name := range []string{"a.iso", "with space", "new\nline", `back\slash`, "cr\r", " lead"} {
+		for _, binary := range []bool{false, true} {
+			digest, _ := hex.DecodeString(md5Hej)
+			parsed, parsedDigest, parsedBinary, err := ParseCoreutils(FormatCoreutils(name, digest, binary) + "\r\n")
+			assert.Equal(t, nil, err, name)
+			assert.Equal(t, name, parsed)
+			assert.Equal(t, digest, parsedDigest)
+			assert.Equal(t, binary, parsedBinary)
+		}
+	}
+
+	for _, line := range []string{"", md5Hej, md5Hej + " a", md5Hej + "  ", md5Hej + " -a"} {
+		_, _, _, err := ParseCoreutils(line)
+		assert.Equal(t, fmt.Sprintf("coreutils: malformed line %q", line), err.Error())
+	}
+	_, _, _, err := ParseCoreutils("xyz  a.iso")
+	assert.Equal(t, "coreutils: bad digest: encoding/hex: invalid byte: U+0078 'x'", err.Error())
+}
+
+func TestReadManifest(t *testing.T) {
+
+	sha1Hej, _ := hex.DecodeString("c412b37f8c0484e6db8bce177ae88c5443b26e92")
+	md5, _ := hex.DecodeString(md5Hej)
+	entries, malformed, err := ReadManifest(strings.NewReader("# comment\n\n"+
+		md5Hej+"  a.txt\r\n"+
+		"c412b37f8c0484e6db8bce177ae88c5443b26e92 *b.txt\n"+
+		"0102  crc16.txt\n"+
+		"garbage\n"), "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, malformed)
+	assert.Equal(t, []ManifestEntry{
+		{Name: "a.txt", Algo: "md5", Digest: md5},
+		{Name: "b.txt", Algo: "sha1", Digest: sha1Hej, Binary: true},
+	}, entries)
+
+	entries, malformed, err = ReadManifest(strings.NewReader(md5Hej+"  a.txt\n"), "MD4")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, malformed)
+	assert.Equal(t, "md4", entries[0].Algo)
+
+	_, _, err = ReadManifest(strings.NewReader(""), "nope")
+	assert.Equal(t, "unknown algo nope", err.Error())
+}
+
+func TestCheckManifest(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.txt": "a", "b.txt": "changed"})
+	unreadable := filepath.Join(dir, "dir.txt")
+	assert.Equal(t, nil, os.Mkdir(unreadable, 0755))
+
+	var manifest bytes.Buffer
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "dir.txt"} {
+		digest := *NewCalculator([]byte(name[:1])).Sum("sha256")
+		manifest.WriteString(FormatCoreutils(filepath.Join(dir, name), digest, false) + "\n")
+	}
+	manifest.WriteString("not a line\n")
+
+	report, err := CheckManifest(&manifest, "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, report.Passed())
+	assert.Equal(t, 1, report.OK)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 1, report.Missing)
+	assert.Equal(t, 1, report.Malformed)
+
+	statuses := []string{}
+	for _, res := range report.Results {
+		statuses = append(statuses, res.Status)
+		assert.Equal(t, "sha256", res.Algo)
+	}
+	assert.Equal(t, []string{CheckOK, CheckFailed, CheckMissing, CheckFailed}, statuses)
+	assert.Equal(t, nil, report.Results[1].Err)
+	assert.NotEqual(t, nil, report.Results[3].Err)
+
+	report = CheckEntries([]ManifestEntry{report.Results[0].ManifestEntry})
+	assert.Equal(t, true, report.Passed())
+	assert.Equal(t, false, CheckEntries(nil).Passed())
+}
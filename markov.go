@@ -0,0 +1,80 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sort"
+)
+
+// markovKey is key c following key prev at pos of a word, prev is 0 at the
+// first position
+type markovKey struct {
+	pos  int
+	prev byte
+	c    byte
+}
+
+// TrainMarkov counts which keys follow each other at each position of the
+// words in r, one per line. FindSequential then tries the keys most likely
+// to follow the previous key first, instead of in the allowed keys order.
+// It can be called several times to train from several wordlists
+func (h *Hasher) TrainMarkov(r io.Reader) error {
+
+	if h.markovCounts == nil {
+		h.markovCounts = make(map[markovKey]int)
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		prev := byte(0)
+		for i, c := range word {
+			h.markovCounts[markovKey{i, prev, c}]++
+			prev = c
+		}
+	}
+	return scanner.Err()
+}
+
+// buildMarkov orders the keys of each position by how often they followed
+// each possible previous key, most often first
+func (h *Hasher) buildMarkov() {
+
+	h.markov = nil
+	if len(h.markovCounts) == 0 {
+		return
+	}
+
+	h.markov = make([][256][]byte, len(h.positions))
+	for pos, keys := range h.positions {
+		prevs := []byte{0}
+		if pos > 0 {
+			prevs = h.positions[pos-1]
+		}
+		for _, prev := range prevs {
+			ordered := append([]byte{}, keys...)
+			sort.SliceStable(ordered, func(a, b int) bool {
+				return h.markovCounts[markovKey{pos, prev, ordered[a]}] > h.markovCounts[markovKey{pos, prev, ordered[b]}]
+			})
+			h.markov[pos][prev] = ordered
+		}
+	}
+}
+
+// markovState returns the trained counts as pos, prev, key and count
+func (h *Hasher) markovState() [][4]int {
+
+	res := [][4]int{}
+	for k, n := range h.markovCounts {
+		res = append(res, [4]int{k.pos, int(k.prev), int(k.c), n})
+	}
+	sort.Slice(res, func(a, b int) bool {
+		for i := 0; i < 3; i++ {
+			if res[a][i] != res[b][i] {
+				return res[a][i] < res[b][i]
+			}
+		}
+		return false
+	})
+	return res
+}
@@ -0,0 +1,72 @@
+package gohash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMarkovHasher(t *testing.T) *Hasher {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ehjlot")
+	hasher.Length(3)
+	hasher.ExpectedHash(md5Hej)
+	assert.Equal(t, nil, hasher.TrainMarkov(strings.NewReader("hej\r\nhej\nlot\ntex\nho")))
+	return hasher
+}
+
+func TestTrainMarkov(t *testing.T) {
+
+	hasher := newMarkovHasher(t)
+	assert.Equal(t, nil, hasher.verify())
+
+	key := []byte("   ")
+	for i := range key {
+		key[i] = hasher.keyAt(key, i, 0)
+	}
+	assert.Equal(t, "hej", string(key))
+
+	// after "t", "e" was seen
+	key = []byte("t  ")
+	assert.Equal(t, byte('e'), hasher.keyAt(key, 1, 0))
+
+	// untrained keys keep the allowed keys order
+	key = []byte("j  ")
+	assert.Equal(t, "ehjlot", string([]byte{
+		hasher.keyAt(key, 1, 0), hasher.keyAt(key, 1, 1), hasher.keyAt(key, 1, 2),
+		hasher.keyAt(key, 1, 3), hasher.keyAt(key, 1, 4), hasher.keyAt(key, 1, 5),
+	}))
+}
+
+func TestHasherMarkov(t *testing.T) {
+
+	for _, workers := range []int{1, 3} {
+		hasher := newMarkovHasher(t)
+		hasher.Workers(workers)
+		res, err := hasher.FindSequential()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "hej", res)
+	}
+
+	// all keys are still tried
+	hasher := newMarkovHasher(t)
+	hasher.ExpectedHash("7f94dd413148ff9ac9e9e4b6ff2b6ca9")
+	res, err := hasher.FindAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"ooo"}, res)
+}
+
+func TestMarkovState(t *testing.T) {
+
+	hasher := newMarkovHasher(t)
+	var buf bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveState(&buf))
+
+	resumed := NewHasher()
+	assert.Equal(t, nil, resumed.LoadState(&buf))
+	assert.Equal(t, hasher.markovCounts, resumed.markovCounts)
+}
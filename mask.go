@@ -0,0 +1,165 @@
+package gohash
+
+import (
+	"fmt"
+)
+
+const (
+	maskLower   = "abcdefghijklmnopqrstuvwxyz"
+	maskUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	maskDigits  = "0123456789"
+	maskSymbols = " !\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+var (
+	// built-in charsets of hashcat masks
+	maskCharsets = map[byte]string{
+		'l': maskLower,
+		'u': maskUpper,
+		'd': maskDigits,
+		's': maskSymbols,
+		'a': maskLower + maskUpper + maskDigits + maskSymbols,
+		'h': maskDigits + "abcdef",
+		'H': maskDigits + "ABCDEF",
+		'b': allBytes(),
+	}
+)
+
+// Mask sets a hashcat style mask, one charset per position, such as
+// "?u?l?l?l?d?d". ?l ?u ?d ?s ?a ?h ?H ?b are built in, ?1 to ?4 are set
+// with CustomCharset and ?? is a literal '?'. Named charsets are used as
+// ?{name}, such as ?{onion}. Any other character is used as is. Unless a
+// length is set, keys are as long as the mask
+func (h *Hasher) Mask(mask string) {
+	h.mask = mask
+}
+
+// CustomCharset sets the keys of mask charset ?1 to ?4, which may use the
+// built in charsets, as in "?l?d"
+func (h *Hasher) CustomCharset(n int, keys string) {
+
+	if h.customCharsets == nil {
+		h.customCharsets = make(map[int]string)
+	}
+	h.customCharsets[n] = keys
+}
+
+// PositionKeys sets the keys allowed at position pos of the key, counting
+// from 0, instead of the allowed keys or mask charset there. The keys may
+// use the built in charsets, as in "?u"
+func (h *Hasher) PositionKeys(pos int, keys string) {
+
+	if h.positionKeys == nil {
+		h.positionKeys = make(map[int]string)
+	}
+	h.positionKeys[pos] = keys
+}
+
+// applyPositionKeys replaces the keys of the positions set by PositionKeys
+func (h *Hasher) applyPositionKeys() error {
+
+	for pos, s := range h.positionKeys {
+		if pos < 0 || pos >= h.maxLength {
+			return fmt.Errorf("position %d is out of range, length is %d", pos, h.maxLength)
+		}
+		keys, err := expandCharset(s, nil)
+		if err != nil {
+			return err
+		}
+		h.positions[pos] = keys
+	}
+	return nil
+}
+
+// parseMask returns the keys allowed at each position of mask
+func parseMask(mask string, custom map[int]string) ([][]byte, error) {
+
+	for n := range custom {
+		if n < 1 || n > 4 {
+			return nil, fmt.Errorf("mask: custom charset %d is not 1-4", n)
+		}
+	}
+
+	res := [][]byte{}
+	for i := 0; i < len(mask); i++ {
+		if mask[i] != '?' {
+			res = append(res, []byte{mask[i]})
+			continue
+		}
+		if i+1 == len(mask) {
+			return nil, fmt.Errorf("mask: incomplete placeholder at end of %q", mask)
+		}
+		i++
+		var keys []byte
+		var err error
+		if mask[i] == '{' {
+			keys, i, err = namedPlaceholder(mask, i)
+		} else {
+			keys, err = maskPlaceholder(mask[i], custom, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, keys)
+	}
+	return res, nil
+}
+
+// maskPlaceholder returns the keys of placeholder ?c
+func maskPlaceholder(c byte, custom map[int]string, allowCustom bool) ([]byte, error) {
+
+	if c == '?' {
+		return []byte{'?'}, nil
+	}
+	if keys, ok := maskCharsets[c]; ok {
+		return []byte(keys), nil
+	}
+	if c >= '1' && c <= '4' && allowCustom {
+		keys, ok := custom[int(c-'0')]
+		if !ok {
+			return nil, fmt.Errorf("mask: custom charset ?%c is not set", c)
+		}
+		return expandCharset(keys, custom)
+	}
+	return nil, fmt.Errorf("mask: unknown placeholder ?%c", c)
+}
+
+// expandCharset expands the built in charsets used in a custom charset,
+// dropping duplicate keys
+func expandCharset(s string, custom map[int]string) ([]byte, error) {
+
+	res := []byte{}
+	for i := 0; i < len(s); i++ {
+		keys := []byte{s[i]}
+		if s[i] == '?' && i+1 < len(s) {
+			i++
+			var err error
+			if s[i] == '{' {
+				keys, i, err = namedPlaceholder(s, i)
+			} else {
+				keys, err = maskPlaceholder(s[i], custom, false)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, b := range keys {
+			if !isByteInSlice(b, res) {
+				res = append(res, b)
+			}
+		}
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("mask: empty custom charset")
+	}
+	return res, nil
+}
+
+func allBytes() string {
+
+	res := make([]byte, 256)
+	for i := range res {
+		res[i] = byte(i)
+	}
+	return string(res)
+}
@@ -0,0 +1,133 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMask(t *testing.T) {
+
+	res, err := parseMask("?d-??x?1", map[int]string{1: "?hxy"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][]byte{
+		[]byte(maskDigits),
+		[]byte("-"),
+		[]byte("?"),
+		[]byte("x"),
+		[]byte("0123456789abcdefxy"),
+	}, res)
+}
+
+func TestParseMaskErrors(t *testing.T) {
+
+	for mask, custom := range map[string]map[int]string{
+		"?":    nil,
+		"?d?":  nil,
+		"?x":   nil,
+		"?1":   nil,
+		"?2":   {1: "ab"},
+		"?d":   {5: "ab"},
+		"?1?d": {1: "?1"},
+	} {
+		_, err := parseMask(mask, custom)
+		assert.NotEqual(t, nil, err, mask)
+	}
+}
+
+func TestHasherMask(t *testing.T) {
+
+	for _, workers := range []int{1, 4} {
+		hasher := NewHasher()
+		hasher.Algo("md5")
+		hasher.Mask("?u?l?l?d")
+		hasher.ExpectedHash("7887b8f9dc39bba09eebd4c0993dc78e")
+		hasher.Workers(workers)
+
+		res, err := hasher.FindSequential()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "Hej7", res)
+	}
+}
+
+func TestHasherMaskCustomCharset(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Mask("?1?d")
+	hasher.CustomCharset(1, "xyz")
+	hasher.ExpectedHash("d0c2225b640deec861a1208f37a77c25")
+	hasher.Reverse(true)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "x9", res)
+}
+
+func TestHasherMaskLength(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Mask("?u?l?l?d")
+	hasher.Length(2)
+	hasher.ExpectedHash("a64cf5823262686e1a28b2245be34ce0")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "He", res)
+
+	hasher.Length(5)
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "mask has 4 positions, length is 5", err.Error())
+}
+
+func TestHasherPositionKeys(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ehj")
+	hasher.Length(5)
+	hasher.PositionKeys(0, "?u")
+	hasher.PositionKeys(3, "?d")
+	hasher.PositionKeys(4, "?d")
+	hasher.ExpectedHash("1d4401712f15f17acffc38a083539741")
+	hasher.Workers(3)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Hej42", res)
+
+	hasher.PositionKeys(5, "?d")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "position 5 is out of range, length is 5", err.Error())
+}
+
+func TestHasherPositionKeysOnly(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Length(2)
+	hasher.PositionKeys(0, "?u")
+	hasher.ExpectedHash("a64cf5823262686e1a28b2245be34ce0")
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "allowedKeys unset", err.Error())
+
+	hasher.PositionKeys(1, "?l")
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "He", res)
+}
+
+func TestHasherMaskPositionKeys(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Mask("?a?l?l?d")
+	hasher.PositionKeys(0, "HIJ")
+	hasher.ExpectedHash("7887b8f9dc39bba09eebd4c0993dc78e")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Hej7", res)
+}
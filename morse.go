@@ -0,0 +1,53 @@
+package gohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// morse code for the hex digits 0-9, a-f
+	morseHexDigits = []string{
+		"-----", ".----", "..---", "...--", "....-",
+		".....", "-....", "--...", "---..", "----.",
+		".-", "-...", "-.-.", "-..", ".", "..-.",
+	}
+)
+
+// encodeMorse renders each byte as its two lowercase hex digits in morse
+// code. Digits are separated by a space and bytes by " / ", the morse
+// word separator, so "\xfe" becomes "..-. ."
+func encodeMorse(src []byte) ([]byte, error) {
+
+	words := make([]string, len(src))
+	for i, b := range src {
+		words[i] = morseHexDigits[b>>4] + " " + morseHexDigits[b&0xf]
+	}
+	return []byte(strings.Join(words, " / ")), nil
+}
+
+// decodeMorse decodes morse coded hex digits. Word separators are
+// optional, but the number of digits must be even
+func decodeMorse(src []byte) ([]byte, error) {
+
+	digits := []byte{}
+	for _, code := range strings.Fields(string(src)) {
+		if code == "/" {
+			continue
+		}
+		idx := indexOfString(morseHexDigits, code)
+		if idx == -1 {
+			return nil, fmt.Errorf("morse: %q is not a hex digit", code)
+		}
+		digits = append(digits, byte(idx))
+	}
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("morse: odd number of hex digits")
+	}
+
+	res := make([]byte, len(digits)/2)
+	for i := range res {
+		res[i] = digits[i*2]<<4 | digits[i*2+1]
+	}
+	return res, nil
+}
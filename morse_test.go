@@ -0,0 +1,27 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMorse(t *testing.T) {
+
+	res, err := encodeMorse([]byte{0xfe, 0x01})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "..-. . / ----- .----", string(res))
+
+	dec, err := decodeMorse([]byte("..-.  .\n----- .----"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xfe, 0x01}, dec)
+}
+
+func TestMorseInvalid(t *testing.T) {
+
+	_, err := decodeMorse([]byte("..-. . / -----"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeMorse([]byte(".-.- ."))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,101 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// extraTargets are the expected hashes of another algo, see AlsoExpect
+type extraTargets struct {
+	algo    string
+	sum     func(*[]byte) *[]byte
+	targets map[string]bool
+}
+
+// AlsoExpect checks each candidate against hashes of another algo too, in
+// addition to the algo and expected hashes of the Hasher, such as a md5
+// and a sha1 leak of the same passwords. Matches are reported to OnMatch,
+// and the search goes on until all hashes are found. Templates, hmac and
+// iterations only apply to the algo of the Hasher
+func (h *Hasher) AlsoExpect(algo string, hashes []string) error {
+
+	algo = resolveAlgoAliases(strings.ToLower(strings.Replace(algo, "_", "-", -1)))
+	sum, ok := hashers[algo]
+	if !ok {
+		return fmt.Errorf("unknown algo %s", algo)
+	}
+
+	targets := make(map[string]bool, len(hashes))
+	for _, s := range hashes {
+		b, err := decodeDigest(s)
+		if err != nil {
+			return err
+		}
+		if len(b)*8 != algos[algo] {
+			return fmt.Errorf("%s hash %q is wrong size, should be %d bit, is %d", algo, s, algos[algo], len(b)*8)
+		}
+		targets[string(b)] = true
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no hashes")
+	}
+
+	for i := range h.extra {
+		if h.extra[i].algo == algo {
+			for digest := range targets {
+				h.extra[i].targets[digest] = true
+			}
+			return nil
+		}
+	}
+	h.extra = append(h.extra, extraTargets{algo: algo, sum: sum, targets: targets})
+	return nil
+}
+
+// LoadAlsoExpect reads hashes of another algo from r, one per line, see
+// AlsoExpect
+func (h *Hasher) LoadAlsoExpect(algo string, r io.Reader) error {
+
+	hashes, err := readHashes(r)
+	if err != nil {
+		return err
+	}
+	return h.AlsoExpect(algo, hashes)
+}
+
+// equalsExtra returns true when buf completes the search with the hash of
+// an algo of AlsoExpect
+func (b *batchHasher) equalsExtra(buf []byte) bool {
+
+	for i := range b.h.extra {
+		extra := &b.h.extra[i]
+		p := buf
+		digest := *extra.sum(&p)
+		if extra.targets[string(digest)] && b.h.matched(buf, digest, b.worker) {
+			return true
+		}
+	}
+	return false
+}
+
+// extraCount returns the number of hashes of AlsoExpect
+func (h *Hasher) extraCount() int {
+
+	n := 0
+	for _, extra := range h.extra {
+		n += len(extra.targets)
+	}
+	return n
+}
+
+// digestAlgo returns the algo of a digest that was found
+func (h *Hasher) digestAlgo(digest []byte) string {
+
+	for _, extra := range h.extra {
+		if extra.targets[string(digest)] {
+			return extra.algo
+		}
+	}
+	return h.algo
+}
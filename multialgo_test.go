@@ -0,0 +1,96 @@
+package gohash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	sha1Hoj = "d29be995c14569cfcb860a9bcc1ff22ce1061093"
+	sha1Lol = "403926033d001b5279df37cbbe5287b7c7c267fa"
+)
+
+func TestAlsoExpect(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hejlo")
+	hasher.Length(3)
+	hasher.Workers(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej}))
+	assert.Equal(t, nil, hasher.AlsoExpect("sha1", []string{sha1Hoj, sha1Lol}))
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{md5Hej: "hej", sha1Hoj: "hoj", sha1Lol: "lol"}, hasher.Found())
+}
+
+func TestAlsoExpectSingleHash(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	hasher.AllowedKeys("hejo")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.AlsoExpect("SHA1", []string{sha1Hoj}))
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]string{md5Hej: "hej", sha1Hoj: "hoj"}, hasher.Found())
+
+	res := hasher.LastResult()
+	assert.Equal(t, true, res != nil)
+	assert.Equal(t, map[string]string{md5Hej: "md5", sha1Hoj: "sha1"}[res.Hash], res.Algo)
+}
+
+func TestAlsoExpectPartial(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej}))
+	assert.Equal(t, nil, hasher.LoadAlsoExpect("sha1", strings.NewReader(sha1Hoj+"\n\n")))
+
+	_, err := hasher.FindFromWordlist(strings.NewReader("hej\nfoo"))
+	assert.Equal(t, "found 1 of 2 hashes", err.Error())
+}
+
+func TestAlsoExpectInvalid(t *testing.T) {
+
+	hasher := NewHasher()
+	assert.Equal(t, "unknown algo nope", hasher.AlsoExpect("nope", []string{sha1Hoj}).Error())
+	assert.Equal(t, "no hashes", hasher.AlsoExpect("sha1", nil).Error())
+	assert.Equal(t, `sha1 hash "`+md5Hej+`" is wrong size, should be 160 bit, is 128`,
+		hasher.AlsoExpect("sha1", []string{md5Hej}).Error())
+
+	hasher.Algo("md5")
+	hasher.DigestPrefix("00")
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.AlsoExpect("sha1", []string{sha1Hoj}))
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "other algos and digest patterns dont mix", err.Error())
+}
+
+func TestAlsoExpectState(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.AlsoExpect("sha1", []string{sha1Hoj}))
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveState(&buf))
+
+	loaded := NewHasher()
+	assert.Equal(t, nil, loaded.LoadState(&buf))
+	assert.Equal(t, 1, len(loaded.extra))
+	assert.Equal(t, "sha1", loaded.extra[0].algo)
+	assert.Equal(t, 2, loaded.targetCount())
+}
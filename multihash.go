@@ -0,0 +1,143 @@
+package gohash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MultihashDigest is a parsed multihash
+type MultihashDigest struct {
+	Algo   string
+	Code   uint64
+	Digest []byte
+}
+
+var (
+	// multihash codes, from the multicodec table
+	multihashCodes = map[string]uint64{
+		"blake2b-256":  0xb220,
+		"blake2b-512":  0xb240,
+		"blake2s-256":  0xb260,
+		"blake3-256":   0x1e,
+		"md4":          0xd4,
+		"md5":          0xd5,
+		"ripemd160":    0x1053,
+		"sha1":         0x11,
+		"sha224":       0x1013,
+		"sha256":       0x12,
+		"sha384":       0x20,
+		"sha512":       0x13,
+		"sha512-224":   0x1014,
+		"sha512-256":   0x1015,
+		"sha3-224":     0x17,
+		"sha3-256":     0x16,
+		"sha3-384":     0x15,
+		"sha3-512":     0x14,
+		"shake128-256": 0x18,
+		"shake256-512": 0x19,
+		"skein512-256": 0xb340,
+		"skein512-512": 0xb360,
+	}
+)
+
+// Multihash calculates the checksum of algo and returns it in the
+// multihash wire format
+func (c *Calculator) Multihash(algo string) ([]byte, error) {
+
+	algo = resolveAlgoAliases(algo)
+	sum := c.Sum(algo)
+	if sum == nil {
+		return nil, fmt.Errorf("unknown algo %s", algo)
+	}
+	return EncodeMultihash(algo, *sum)
+}
+
+// EncodeMultihash encodes digest as <varint code><varint length><digest>
+func EncodeMultihash(algo string, digest []byte) ([]byte, error) {
+
+	algo = resolveAlgoAliases(algo)
+	code, ok := multihashCodes[algo]
+	if !ok {
+		return nil, fmt.Errorf("no multihash code for %s", algo)
+	}
+
+	buf := make([]byte, 2*binary.MaxVarintLen64+len(digest))
+	n := binary.PutUvarint(buf, code)
+	n += binary.PutUvarint(buf[n:], uint64(len(digest)))
+	n += copy(buf[n:], digest)
+	return buf[0:n], nil
+}
+
+// ParseMultihash parses and validates a multihash in binary form
+func ParseMultihash(b []byte) (*MultihashDigest, error) {
+
+	code, n := binary.Uvarint(b)
+	if n <= 0 {
+		return nil, fmt.Errorf("multihash: invalid code varint")
+	}
+	b = b[n:]
+
+	length, n := binary.Uvarint(b)
+	if n <= 0 {
+		return nil, fmt.Errorf("multihash: invalid length varint")
+	}
+	b = b[n:]
+
+	if uint64(len(b)) != length {
+		return nil, fmt.Errorf("multihash: length is %d, but digest is %d bytes", length, len(b))
+	}
+
+	algo := multihashAlgo(code)
+	if algo == "" {
+		return nil, fmt.Errorf("multihash: unknown code 0x%x", code)
+	}
+
+	// truncated digests are allowed, longer ones are not
+	if int(length)*8 > algos[algo] {
+		return nil, fmt.Errorf("multihash: %s digest can't be %d bytes", algo, length)
+	}
+
+	return &MultihashDigest{
+		Algo:   algo,
+		Code:   code,
+		Digest: b,
+	}, nil
+}
+
+// ParseMultihashString parses a hex or base58 encoded multihash
+func ParseMultihashString(s string) (*MultihashDigest, error) {
+
+	s = strings.TrimSpace(s)
+
+	b, err := decodeHex([]byte(s))
+	if err != nil {
+		b, err = decodeBase58([]byte(s))
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("multihash: not hex or base58: %s", s)
+		}
+	}
+	return ParseMultihash(b)
+}
+
+// MultihashAlgos returns the algo id's with a multihash code
+func MultihashAlgos() []string {
+
+	res := []string{}
+	for key := range multihashCodes {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func multihashAlgo(code uint64) string {
+
+	for algo, c := range multihashCodes {
+		if c == code {
+			return algo
+		}
+	}
+	return ""
+}
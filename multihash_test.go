@@ -0,0 +1,70 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatorMultihash(t *testing.T) {
+
+	calc := NewCalculator([]byte("hello world"))
+	res, err := calc.Multihash("sha256")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "1220b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", hex.EncodeToString(res))
+
+	_, err = calc.Multihash("adler32")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestEncodeMultihashTwoByteCode(t *testing.T) {
+
+	res, err := EncodeMultihash("blake2b-256", make([]byte, 32))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xa0, 0xe4, 0x02, 0x20}, res[0:4])
+}
+
+func TestEncodeMultihashSkein(t *testing.T) {
+
+	// skein512-256 is 0xb340 and skein512-512 0xb360 in the multicodec table
+	res, err := EncodeMultihash("skein512-256", make([]byte, 32))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xc0, 0xe6, 0x02, 0x20}, res[0:4])
+
+	res, err = EncodeMultihash("skein512-512", make([]byte, 64))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xe0, 0xe6, 0x02, 0x40}, res[0:4])
+
+	mh, err := ParseMultihash(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "skein512-512", mh.Algo)
+	assert.Equal(t, uint64(0xb360), mh.Code)
+}
+
+func TestParseMultihash(t *testing.T) {
+
+	for _, s := range []string{
+		"1220b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		"QmaozNR7DZHQK1ZcU9p7QdrshMvXqWK6gpu5rmrkPdT3L4",
+	} {
+		mh, err := ParseMultihashString(s)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "sha256", mh.Algo)
+		assert.Equal(t, uint64(0x12), mh.Code)
+		assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", hex.EncodeToString(mh.Digest))
+	}
+}
+
+func TestParseMultihashInvalid(t *testing.T) {
+
+	for _, s := range []string{
+		"1221b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", // wrong length
+		"1341" + hex.EncodeToString(make([]byte, 65)),                          // too long for sha512
+		"0f02abcd", // unknown code
+		"",
+	} {
+		_, err := ParseMultihashString(s)
+		assert.NotEqual(t, nil, err, s)
+	}
+}
@@ -0,0 +1,98 @@
+package gohash
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// decodeDigest decodes an expected hash in hex, with any white space and
+// colons as in "d4:1d:8c", or in padded base64, or in a database format
+func decodeDigest(s string) ([]byte, error) {
+
+	text := s
+	if _, digest := databaseFormat(s); digest != "" {
+		text = digest
+	}
+	clean := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == ':' {
+			return -1
+		}
+		return r
+	}, text)
+
+	if b, err := hex.DecodeString(clean); err == nil {
+		return b, nil
+	}
+	// only padded base64, so short strings are not mistaken for it
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+		if b, err := enc.DecodeString(clean); err == nil {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("expected hash %q is not hex or base64", s)
+}
+
+// AlgosForHash returns the algos with a digest as long as hash, in hex or
+// base64, in sorted order, or the algo implied by the format of hash
+func AlgosForHash(hash string) ([]string, error) {
+
+	digest, err := decodeDigest(hash)
+	if err != nil {
+		return nil, err
+	}
+	if algo, _ := databaseFormat(hash); algo == "mysql41" {
+		return []string{algo}, nil
+	}
+	return algosOfSize(len(digest) * 8), nil
+}
+
+// algosOfSize returns the algos with a digest of bitSize bits, in sorted
+// order
+func algosOfSize(bitSize int) []string {
+
+	res := []string{}
+	for algo, size := range algos {
+		if size == bitSize {
+			res = append(res, algo)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// inferAlgo sets the algo from the size of the expected hashes, when only
+// one algo has that size
+func (h *Hasher) inferAlgo() error {
+
+	switch h.expectedFormat {
+	case "mysql41":
+		h.algo = h.expectedFormat
+		return nil
+	case "postgres":
+		return fmt.Errorf("algo unset, postgres md5 hashes need the user name, see PostgresMD5")
+	}
+
+	digest := h.expected
+	for target := range h.targets {
+		digest = []byte(target)
+		break
+	}
+	if len(digest) == 0 {
+		return fmt.Errorf("algo unset")
+	}
+
+	bitSize := len(digest) * 8
+	candidates := algosOfSize(bitSize)
+	switch len(candidates) {
+	case 0:
+		return fmt.Errorf("algo unset, and no algo has a %d bit digest", bitSize)
+	case 1:
+		h.algo = candidates[0]
+		return nil
+	}
+	return fmt.Errorf("algo unset, a %d bit digest could be %s", bitSize, strings.Join(candidates, ", "))
+}
@@ -0,0 +1,82 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeDigest(t *testing.T) {
+
+	for _, s := range []string{
+		md5Hej,
+		" 541C57960BB997942655D14E3B9607F9\n",
+		"54:1c:57:96:0b:b9:97:94:26:55:d1:4e:3b:96:07:f9",
+		"541c5796 0bb99794 2655d14e 3b9607f9",
+		"VBxXlgu5l5QmVdFOO5YH+Q==",
+		"VBxXlgu5l5QmVdFOO5YH-Q==",
+	} {
+		b, err := decodeDigest(s)
+		assert.Equal(t, nil, err, s)
+		assert.Equal(t, md5Hej, hex.EncodeToString(b), s)
+	}
+
+	_, err := decodeDigest("xyz")
+	assert.Equal(t, `expected hash "xyz" is not hex or base64`, err.Error())
+}
+
+func TestAlgosForHash(t *testing.T) {
+
+	res, err := AlgosForHash("VBxXlgu5l5QmVdFOO5YH+Q==")
+	assert.Equal(t, nil, err)
+	assert.Contains(t, res, "md5")
+	assert.Contains(t, res, "md4")
+	assert.NotContains(t, res, "sha1")
+
+	res, err = AlgosForHash("abcdef")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"crc24-openpgp"}, res)
+
+	_, err = AlgosForHash("xyz")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestHasherInferAlgo(t *testing.T) {
+
+	digest := *NewCalculator([]byte("hej")).Sum("tiger192")
+
+	hasher := NewHasher()
+	hasher.ExpectedHash(hex.EncodeToString(digest))
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+	assert.Equal(t, "tiger192", hasher.algo)
+}
+
+func TestHasherInferAlgoErrors(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+
+	hasher.ExpectedHash(md5Hej)
+	_, err := hasher.FindSequential()
+	assert.Contains(t, err.Error(), "algo unset, a 128 bit digest could be ")
+	assert.Contains(t, err.Error(), "md5")
+
+	hasher.ExpectedHash("abcdefabcdef")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "algo unset, and no algo has a 48 bit digest", err.Error())
+
+	hasher.ExpectedHash("xyz")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, `expected hash "xyz" is not hex or base64`, err.Error())
+
+	hasher.ExpectedHash("")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "algo unset", err.Error())
+}
@@ -0,0 +1,90 @@
+package gohash
+
+import (
+	"bytes"
+	"crypto/des"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+var (
+	// the text encrypted with each half of the lm key
+	lmMagic = []byte("KGS!@#$%")
+)
+
+// ntlmSum is the NT hash of windows, md4 of the utf-16le password
+func ntlmSum(b *[]byte) *[]byte {
+
+	units := utf16.Encode([]rune(string(*b)))
+	buf := make([]byte, len(units)*2)
+	for i, u := range units {
+		buf[i*2] = byte(u)
+		buf[i*2+1] = byte(u >> 8)
+	}
+	w := md4.New()
+	w.Write(buf)
+	res := w.Sum(nil)
+	return &res
+}
+
+// lmSum is the LM hash of windows. The password is upper cased and
+// truncated or padded to 14 bytes, and each 7 byte half is hashed on its
+// own, see lmHalfSum
+func lmSum(b *[]byte) *[]byte {
+
+	key := make([]byte, 14)
+	copy(key, bytes.ToUpper(*b))
+	res := make([]byte, 0, 16)
+	res = append(res, lmHalf(key[:7])...)
+	res = append(res, lmHalf(key[7:])...)
+	return &res
+}
+
+// lmHalfSum is the LM hash of one half of a password, up to 7 bytes, so
+// the halves of a LM hash can be searched for separately, see LMHalves
+func lmHalfSum(b *[]byte) *[]byte {
+
+	key := make([]byte, 7)
+	copy(key, bytes.ToUpper(*b))
+	res := lmHalf(key)
+	return &res
+}
+
+// lmHalf encrypts the magic text with a des key made of 7 bytes
+func lmHalf(half []byte) []byte {
+
+	key := make([]byte, 8)
+	var bits uint64
+	for _, c := range half {
+		bits = bits<<8 | uint64(c)
+	}
+	for i := range key {
+		// 7 bits of key in each byte, the lowest bit is parity
+		key[i] = byte(bits>>uint(49-7*i)) << 1
+	}
+	block, err := des.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	res := make([]byte, 8)
+	block.Encrypt(res, lmMagic)
+	return res
+}
+
+// LMHalves splits a LM hash, in hex, in the hashes of its two halves, to
+// be searched for at once with the "lm-half" algo and ExpectedHashes. The
+// key is the upper case first half followed by the second
+func LMHalves(hash string) ([]string, error) {
+
+	digest, err := decodeDigest(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != 16 {
+		return nil, fmt.Errorf("lm hash is %d bit, should be 128", len(digest)*8)
+	}
+	return []string{hex.EncodeToString(digest[:8]), hex.EncodeToString(digest[8:])}, nil
+}
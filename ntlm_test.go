@@ -0,0 +1,80 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherNTLM(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("ntlm")
+	hasher.ExpectedHash("8846f7eaee8fb117ad06bdd830b7586c")
+	hasher.AllowedKeys("adoprsw")
+	hasher.Prefix("pass")
+	hasher.Length(4)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "password", res)
+}
+
+func TestNTLMSumUnicode(t *testing.T) {
+
+	// utf-16le of "ü" is fc 00
+	b := []byte("ü")
+	assert.Equal(t, *md4Sum(&[]byte{0xfc, 0x00}), *ntlmSum(&b))
+}
+
+func TestHasherLM(t *testing.T) {
+
+	// upper cased, so any case of the key matches
+	hasher := NewHasher()
+	hasher.Algo("lm")
+	hasher.ExpectedHash("e52cac67419a9a224a3b108f3fa6cb6d")
+	hasher.AllowedKeys("dor")
+	hasher.Prefix("passw")
+	hasher.Length(3)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "password", res)
+
+	// truncated to 14 bytes
+	long := []byte("PASSWORDPASSWORD")
+	short := []byte("passwordpasswo")
+	assert.Equal(t, *lmSum(&short), *lmSum(&long))
+}
+
+func TestLMHalves(t *testing.T) {
+
+	halves, err := LMHalves("e52cac67419a9a224a3b108f3fa6cb6d")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"e52cac67419a9a22", "4a3b108f3fa6cb6d"}, halves)
+
+	_, err = LMHalves("e52cac67419a9a22")
+	assert.Equal(t, "lm hash is 64 bit, should be 128", err.Error())
+}
+
+func TestHasherLMHalves(t *testing.T) {
+
+	key := []byte("abcabcab")
+	halves, err := LMHalves(hex.EncodeToString(*lmSum(&key)))
+	assert.Equal(t, nil, err)
+
+	// both halves are searched for at once
+	hasher := NewHasher()
+	hasher.Algo("lm-half")
+	assert.Equal(t, nil, hasher.ExpectedHashes(halves))
+	hasher.AllowedKeys("ABC")
+	hasher.MinLength(1)
+	hasher.MaxLength(7)
+
+	_, err = hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	found := hasher.Found()
+	assert.Equal(t, "ABCABCA", found[halves[0]])
+	assert.Equal(t, "B", found[halves[1]])
+}
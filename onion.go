@@ -0,0 +1,243 @@
+package gohash
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// length of a v3 onion address, without ".onion"
+	onionLength = 56
+
+	onionVersion = 3
+
+	onionAlphabet = maskLower + "234567"
+)
+
+var (
+	onionEncoding = base32.NewEncoding(onionAlphabet).WithPadding(base32.NoPadding)
+)
+
+// OnionKey is the ed25519 key of a v3 onion service
+type OnionKey struct {
+	// Address is the onion address, without ".onion"
+	Address    string
+	PublicKey  ed25519.PublicKey
+	PrivateKey ed25519.PrivateKey
+}
+
+// Hostname returns the address as in the hostname file of tor
+func (k *OnionKey) Hostname() string {
+	return k.Address + ".onion"
+}
+
+// SecretKeyFile returns the hs_ed25519_secret_key file of tor, holding the
+// expanded private key
+func (k *OnionKey) SecretKeyFile() []byte {
+
+	expanded := sha512.Sum512(k.PrivateKey.Seed())
+	expanded[0] &= 248
+	expanded[31] &= 127
+	expanded[31] |= 64
+	return append(torKeyHeader("== ed25519v1-secret: type0 =="), expanded[:]...)
+}
+
+// PublicKeyFile returns the hs_ed25519_public_key file of tor
+func (k *OnionKey) PublicKeyFile() []byte {
+	return append(torKeyHeader("== ed25519v1-public: type0 =="), k.PublicKey...)
+}
+
+// torKeyHeader returns the 32 byte header of tor key files
+func torKeyHeader(s string) []byte {
+
+	res := make([]byte, 32)
+	copy(res, s)
+	return res
+}
+
+// OnionAddress returns the v3 onion address of an ed25519 public key,
+// without ".onion"
+func OnionAddress(pub ed25519.PublicKey) string {
+
+	buf := make([]byte, 0, 35)
+	buf = append(buf, pub...)
+	buf = append(buf, onionChecksum(pub)...)
+	buf = append(buf, onionVersion)
+	return onionEncoding.EncodeToString(buf)
+}
+
+// onionChecksum returns the two checksum bytes of an onion address
+func onionChecksum(pub ed25519.PublicKey) []byte {
+
+	h := sha3.New256()
+	h.Write([]byte(".onion checksum"))
+	h.Write(pub)
+	h.Write([]byte{onionVersion})
+	return h.Sum(nil)[:2]
+}
+
+// FindOnion generates ed25519 keys until the onion address of one starts
+// with prefix, such as "gohash". Each character takes about 32 times as
+// many tries, and the tries are shared between the workers
+func (h *Hasher) FindOnion(prefix string) (*OnionKey, error) {
+	return h.FindOnionContext(context.Background(), prefix)
+}
+
+// FindOnionContext is like FindOnion, but stops with the error of ctx when
+// it is done
+func (h *Hasher) FindOnionContext(ctx context.Context, prefix string) (*OnionKey, error) {
+
+	prefix = strings.ToLower(prefix)
+	if len(prefix) > onionLength {
+		return nil, fmt.Errorf("onion prefix is longer than %d characters", onionLength)
+	}
+	if strings.Trim(prefix, onionAlphabet) != "" {
+		return nil, fmt.Errorf("onion prefix %q may only use a-z and 2-7", prefix)
+	}
+	keyspace := new(big.Int).Lsh(big.NewInt(1), uint(5*len(prefix)))
+	return h.findOnionLimited(ctx, keyspace, func(addr string) bool {
+		return strings.HasPrefix(addr, prefix)
+	})
+}
+
+// FindOnionRegexp generates ed25519 keys until the onion address of one
+// matches expr, such as "^go.*hash"
+func (h *Hasher) FindOnionRegexp(expr string) (*OnionKey, error) {
+	return h.FindOnionRegexpContext(context.Background(), expr)
+}
+
+// FindOnionRegexpContext is like FindOnionRegexp, but stops with the error
+// of ctx when it is done
+func (h *Hasher) FindOnionRegexpContext(ctx context.Context, expr string) (*OnionKey, error) {
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid onion regexp: %v", err)
+	}
+	return h.findOnionLimited(ctx, nil, re.MatchString)
+}
+
+// findOnionLimited searches for an onion key with the limits of the Hasher
+func (h *Hasher) findOnionLimited(ctx context.Context, keyspace *big.Int, match func(string) bool) (*OnionKey, error) {
+
+	var key *OnionKey
+	_, err := h.limited(ctx, func(ctx context.Context) (string, error) {
+		var err error
+		if key, err = h.findOnion(ctx, keyspace, match); err != nil {
+			return "", err
+		}
+		return key.Address, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (h *Hasher) findOnion(ctx context.Context, keyspace *big.Int, match func(string) bool) (*OnionKey, error) {
+
+	mutex.Lock()
+	h.try = 0
+	h.total = keyspace
+	h.throttled = 0
+	h.result = nil
+	mutex.Unlock()
+
+	h.started = time.Now()
+	defer h.startStatusReport()()
+
+	workers := h.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	seeds := make([]byte, workers*ed25519.SeedSize)
+	if _, err := rand.Read(seeds); err != nil {
+		return nil, err
+	}
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+	found := make(chan *OnionKey, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			seed := seeds[worker*ed25519.SeedSize : (worker+1)*ed25519.SeedSize]
+			if key := h.searchOnion(search, seed, match); key != nil {
+				mutex.Lock()
+				if h.result == nil {
+					h.result = &SearchResult{
+						Key:     key.Address,
+						Hash:    hex.EncodeToString(key.PublicKey),
+						Algo:    "ed25519",
+						Tries:   h.try,
+						Elapsed: time.Since(h.started),
+						Length:  onionLength,
+						Worker:  worker,
+					}
+				}
+				mutex.Unlock()
+				found <- key
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	key, ok := <-found
+	cancel()
+	for range found {
+		// wait for the other workers to stop
+	}
+	if ok {
+		return key, nil
+	}
+	return nil, ctx.Err()
+}
+
+// searchOnion generates keys from a random seed, counting up, until the
+// address of one matches or ctx is done
+func (h *Hasher) searchOnion(ctx context.Context, seed []byte, match func(string) bool) *OnionKey {
+
+	var addr string
+	tries := uint64(0)
+	defer func() {
+		h.reportProgress([]byte(addr), tries%statusInterval)
+	}()
+
+	for {
+		// any seed is as good as a random one, after sha512
+		binary.LittleEndian.PutUint64(seed, binary.LittleEndian.Uint64(seed)+1)
+		priv := ed25519.NewKeyFromSeed(seed)
+		pub := priv.Public().(ed25519.PublicKey)
+		addr = OnionAddress(pub)
+		if match(addr) {
+			return &OnionKey{Address: addr, PublicKey: pub, PrivateKey: priv}
+		}
+
+		tries++
+		if tries%statusInterval == 0 {
+			if ctx.Err() != nil {
+				return nil
+			}
+			h.reportProgress([]byte(addr), statusInterval)
+		}
+	}
+}
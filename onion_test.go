@@ -0,0 +1,85 @@
+package gohash
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnionAddress(t *testing.T) {
+
+	seed := bytes.Repeat([]byte{1}, ed25519.SeedSize)
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	addr := OnionAddress(pub)
+	assert.Equal(t, 56, len(addr))
+
+	// public key, checksum and version
+	raw, err := onionEncoding.DecodeString(addr)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte(pub), raw[:32])
+	assert.Equal(t, onionChecksum(pub), raw[32:34])
+	assert.Equal(t, byte(3), raw[34])
+	assert.Equal(t, true, strings.HasSuffix(addr, "d"))
+}
+
+func TestHasherFindOnion(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Workers(2)
+
+	key, err := hasher.FindOnion("Go")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(key.Address, "go"))
+	assert.Equal(t, key.Address, OnionAddress(key.PublicKey))
+	assert.Equal(t, key.Address+".onion", key.Hostname())
+	assert.Equal(t, key.PublicKey, key.PrivateKey.Public())
+
+	result := hasher.LastResult()
+	assert.Equal(t, key.Address, result.Key)
+	assert.Equal(t, "ed25519", result.Algo)
+
+	secret := key.SecretKeyFile()
+	assert.Equal(t, 96, len(secret))
+	assert.Equal(t, "== ed25519v1-secret: type0 ==\x00\x00\x00", string(secret[:32]))
+	public := key.PublicKeyFile()
+	assert.Equal(t, "== ed25519v1-public: type0 ==\x00\x00\x00", string(public[:32]))
+	assert.Equal(t, []byte(key.PublicKey), public[32:])
+}
+
+func TestHasherFindOnionRegexp(t *testing.T) {
+
+	hasher := NewHasher()
+	key, err := hasher.FindOnionRegexp("^[a-d]7")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, regexp.MustCompile("^[a-d]7").MatchString(key.Address))
+
+	_, err = hasher.FindOnionRegexp("(")
+	assert.Equal(t, "invalid onion regexp: error parsing regexp: missing closing ): `(`", err.Error())
+}
+
+func TestHasherFindOnionErrors(t *testing.T) {
+
+	hasher := NewHasher()
+	_, err := hasher.FindOnion("g0")
+	assert.Equal(t, `onion prefix "g0" may only use a-z and 2-7`, err.Error())
+
+	_, err = hasher.FindOnion(strings.Repeat("a", 57))
+	assert.Equal(t, "onion prefix is longer than 56 characters", err.Error())
+
+	// not found before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = hasher.FindOnionContext(ctx, "abcdefghij")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	hasher.MaxAttempts(100)
+	_, err = hasher.FindOnion("abcdefghij")
+	_, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+}
@@ -0,0 +1,102 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+var (
+	// names used by `openssl dgst`
+	opensslNames = map[string]string{
+		"blake2b-512": "BLAKE2b512",
+		"blake2s-256": "BLAKE2s256",
+		"md2":         "MD2",
+		"md4":         "MD4",
+		"md5":         "MD5",
+		"ripemd160":   "RIPEMD160",
+		"sha1":        "SHA1",
+		"sha224":      "SHA224",
+		"sha256":      "SHA256",
+		"sha384":      "SHA384",
+		"sha512":      "SHA512",
+		"sha512-224":  "SHA512-224",
+		"sha512-256":  "SHA512-256",
+		"sha3-224":    "SHA3-224",
+		"sha3-256":    "SHA3-256",
+		"sha3-384":    "SHA3-384",
+		"sha3-512":    "SHA3-512",
+		"whirlpool":   "whirlpool",
+	}
+
+	// names used by OpenSSL 3. openssl shake digests are 128 and 256 bit
+	// unless -xoflen is given, only the 256 and 512 bit ones are read
+	opensslAliases = map[string]string{
+		"blake2b-512":  "blake2b-512",
+		"blake2s-256":  "blake2s-256",
+		"ripemd-160":   "ripemd160",
+		"shake-128":    "shake128-256",
+		"shake-256":    "shake256-512",
+		"sha2-224":     "sha224",
+		"sha2-256":     "sha256",
+		"sha2-384":     "sha384",
+		"sha2-512":     "sha512",
+		"sha2-512/224": "sha512-224",
+		"sha2-512/256": "sha512-256",
+	}
+)
+
+// FormatOpenSSL renders a digest like `openssl dgst`, "SHA256(file)= <hex>".
+// Piped input should use fileName "stdin"
+func FormatOpenSSL(algo string, fileName string, digest []byte) (string, error) {
+
+	algo = resolveAlgoAliases(algo)
+	name, ok := opensslNames[algo]
+	if !ok {
+		return "", fmt.Errorf("openssl: unsupported algo %s", algo)
+	}
+	return name + "(" + fileName + ")= " + hex.EncodeToString(digest), nil
+}
+
+// ParseOpenSSL parses a line of `openssl dgst` output, returning algo id,
+// file name and digest
+func ParseOpenSSL(line string) (string, string, []byte, error) {
+
+	line = strings.TrimRight(line, "\r\n")
+
+	open := strings.Index(line, "(")
+	end := strings.LastIndex(line, ")= ")
+	if open <= 0 || end < open {
+		return "", "", nil, fmt.Errorf("openssl: malformed line %q", line)
+	}
+
+	algo := opensslAlgo(line[0:open])
+	if algo == "" {
+		return "", "", nil, fmt.Errorf("openssl: unknown algo %s", line[0:open])
+	}
+
+	digest, err := hex.DecodeString(line[end+3:])
+	if err != nil {
+		return "", "", nil, fmt.Errorf("openssl: bad digest: %v", err)
+	}
+	if len(digest)*8 != algos[algo] {
+		return "", "", nil, fmt.Errorf("openssl: %s digest should be %d bit, is %d",
+			algo, algos[algo], len(digest)*8)
+	}
+
+	return algo, line[open+1 : end], digest, nil
+}
+
+func opensslAlgo(name string) string {
+
+	name = strings.ToLower(name)
+	if algo, ok := opensslAliases[name]; ok {
+		return algo
+	}
+	for algo, n := range opensslNames {
+		if strings.ToLower(n) == name {
+			return algo
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOpenSSL(t *testing.T) {
+
+	calc := NewCalculator([]byte(fox))
+	res, err := FormatOpenSSL("sha256", "fox.txt", *calc.Sum("sha256"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "SHA256(fox.txt)= d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592", res)
+
+	_, err = FormatOpenSSL("adler32", "fox.txt", *calc.Sum("adler32"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestParseOpenSSL(t *testing.T) {
+
+	algo, fileName, digest, err := ParseOpenSSL("SHA2-256(my (odd) file)= d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592\n")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sha256", algo)
+	assert.Equal(t, "my (odd) file", fileName)
+	assert.Equal(t, "d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592", hex.EncodeToString(digest))
+
+	algo, fileName, _, err = ParseOpenSSL("MD5(stdin)= 9e107d9d372bb6826bd81d3542a419d6")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "md5", algo)
+	assert.Equal(t, "stdin", fileName)
+}
+
+func TestParseOpenSSL3(t *testing.T) {
+
+	// output of openssl 3.0 dgst
+	tests := map[string]string{
+		"SHA2-512/256(fox.txt)= dd9d67b371519c339ed8dbd25af90e976a1eeefd4ad3d889005e532fc5bef04d":                                                                "sha512-256",
+		"RIPEMD-160(fox.txt)= 37f332f68db77bd9d7edd4969571ad671cf9dd3b":                                                                                          "ripemd160",
+		"BLAKE2B-512(fox.txt)= a8add4bdddfd93e4877d2746e62817b116364a1fa7bc148d95090bc7333b3673f82401cf7aa2e4cb1ecd90296e3f14cb5413f8ed77be73045b13914cdcd6a918": "blake2b-512",
+		"BLAKE2S-256(fox.txt)= 606beeec743ccbeff6cbcdf5d5302aa855c256c29b88c8ed331ea1a6bf3c8812":                                                                 "blake2s-256",
+		"SHAKE-128(fox.txt)= f4202e3c5852f9182a0430fd8144f0a74b95e7417ecae17db0f8cfeed0e3e66e":                                                                   "shake128-256",
+		"SHAKE-256(fox.txt)= 2f671343d9b2e1604dc9dcf0753e5fe15c7c64a0d283cbbf722d411a0e36f6ca1d01d1369a23539cd80f7c054b6e5daf9c962cad5b8ed5bd11998b40d5734442":   "shake256-512",
+	}
+	calc := NewCalculator([]byte(fox))
+	for line, expected := range tests {
+		algo, fileName, digest, err := ParseOpenSSL(line)
+		assert.Equal(t, nil, err, line)
+		assert.Equal(t, expected, algo)
+		assert.Equal(t, "fox.txt", fileName)
+		assert.Equal(t, line[len(line)-len(digest)*2:], hex.EncodeToString(digest))
+	}
+	_, _, digest, _ := ParseOpenSSL("SHAKE-128(fox.txt)= f4202e3c5852f9182a0430fd8144f0a74b95e7417ecae17db0f8cfeed0e3e66e")
+	assert.Equal(t, *calc.Sum("shake128-256"), digest)
+
+	// without -xoflen, the shake digests are shorter than the algos
+	_, _, _, err := ParseOpenSSL("SHAKE-128(fox.txt)= f4202e3c5852f9182a0430fd8144f0a7")
+	assert.Equal(t, "openssl: shake128-256 digest should be 256 bit, is 128", err.Error())
+
+	_, err = FormatOpenSSL("shake128-256", "fox.txt", *calc.Sum("shake128-256"))
+	assert.Equal(t, "openssl: unsupported algo shake128-256", err.Error())
+}
+
+func TestParseOpenSSLInvalid(t *testing.T) {
+
+	for _, s := range []string{
+		"d7a8fbb307d7809469ca9abcb0082e4f  fox.txt",
+		"FOO(x)= 00",
+		"MD5(x)= 00",
+		"MD5(x)= zz",
+	} {
+		_, _, _, err := ParseOpenSSL(s)
+		assert.NotEqual(t, nil, err, s)
+	}
+}
@@ -0,0 +1,333 @@
+package gohash
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Option configures a Hasher made by NewHasherWithOptions, returning an
+// error when the setting is invalid
+type Option func(*Hasher) error
+
+// NewHasherWithOptions returns a new Hasher configured by opts. Unlike the
+// setters, mistakes are reported here instead of when a search starts
+func NewHasherWithOptions(opts ...Option) (*Hasher, error) {
+
+	h := NewHasher()
+	for _, opt := range opts {
+		if err := opt(h); err != nil {
+			return nil, err
+		}
+	}
+	if err := h.verifyOptions(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// verifyOptions checks the settings that depend on each other
+func (h *Hasher) verifyOptions() error {
+
+	if h.minLength != 0 && h.maxLength != 0 && h.maxLength < h.minLength {
+		return fmt.Errorf("max length %d is less than min length %d", h.maxLength, h.minLength)
+	}
+	if len(h.allowedKeys) != 0 || h.mask != "" {
+		if err := h.verifyKeys(); err != nil {
+			return err
+		}
+	}
+
+	hasTarget := len(h.expected) != 0 || h.targets != nil || h.cryptHash != "" ||
+		h.digestPrefix != "" || h.zeroBits != 0 || h.digestRegexp != ""
+	hasAlgo := h.algo != "" || h.template != "" || h.cryptHash != ""
+	if hasTarget && hasAlgo {
+		return h.verifyTarget()
+	}
+	return nil
+}
+
+// WithAlgo sets the hash algorithm, any of AvailableHashes
+func WithAlgo(algo string) Option {
+	return func(h *Hasher) error {
+
+		h.Algo(algo)
+		if _, ok := hashers[h.algo]; !ok {
+			return fmt.Errorf("unknown algo %s", algo)
+		}
+		return nil
+	}
+}
+
+// WithTemplate sets a hash template, see Template
+func WithTemplate(s string) Option {
+	return func(h *Hasher) error {
+
+		if _, err := parseTemplate(s); err != nil {
+			return err
+		}
+		h.Template(s)
+		return nil
+	}
+}
+
+// WithSalt sets the salt of a template
+func WithSalt(s string) Option {
+	return func(h *Hasher) error {
+		h.Salt(s)
+		return nil
+	}
+}
+
+// WithIterations sets the number of times the hash is applied
+func WithIterations(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("iterations must be at least 1")
+		}
+		h.Iterations(n)
+		return nil
+	}
+}
+
+// WithExpectedHash sets the expected hash, see ExpectedHash
+func WithExpectedHash(expected string) Option {
+	return func(h *Hasher) error {
+
+		if isCryptHash(expected) {
+			if _, err := parseCryptHash(expected); err != nil {
+				return err
+			}
+		} else if b, err := decodeDigest(expected); err != nil {
+			return err
+		} else if len(b) == 0 {
+			return fmt.Errorf("expected hash is empty")
+		}
+		h.ExpectedHash(expected)
+		return nil
+	}
+}
+
+// WithExpectedHashes sets several expected hashes, see ExpectedHashes
+func WithExpectedHashes(hashes []string) Option {
+	return func(h *Hasher) error {
+		return h.ExpectedHashes(hashes)
+	}
+}
+
+// WithAlsoExpect sets expected hashes of another algo, see AlsoExpect
+func WithAlsoExpect(algo string, hashes []string) Option {
+	return func(h *Hasher) error {
+		return h.AlsoExpect(algo, hashes)
+	}
+}
+
+// WithDigestPrefix sets a hex prefix the digest must start with
+func WithDigestPrefix(s string) Option {
+	return func(h *Hasher) error {
+
+		if _, err := prefixMatcher(s); err != nil {
+			return err
+		}
+		h.DigestPrefix(s)
+		return nil
+	}
+}
+
+// WithLeadingZeroBits sets the number of leading zero bits of the digest
+func WithLeadingZeroBits(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("leading zero bits must be at least 1")
+		}
+		h.LeadingZeroBits(n)
+		return nil
+	}
+}
+
+// WithDigestRegexp sets a regular expression the encoded digest must match
+func WithDigestRegexp(expr string) Option {
+	return func(h *Hasher) error {
+
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid digest regexp: %v", err)
+		}
+		h.DigestRegexp(expr)
+		return nil
+	}
+}
+
+// WithAllowedKeys sets the allowed keys
+func WithAllowedKeys(s string) Option {
+	return func(h *Hasher) error {
+
+		if s == "" {
+			return fmt.Errorf("allowedKeys unset")
+		}
+		h.AllowedKeys(s)
+		return nil
+	}
+}
+
+// WithAllowedCharset sets the allowed keys to a named charset, see Charset
+func WithAllowedCharset(name string) Option {
+	return func(h *Hasher) error {
+		return h.AllowedCharset(name)
+	}
+}
+
+// WithMask sets a hashcat style mask, see Mask
+func WithMask(mask string) Option {
+	return func(h *Hasher) error {
+
+		if mask == "" {
+			return fmt.Errorf("mask is empty")
+		}
+		h.Mask(mask)
+		return nil
+	}
+}
+
+// WithCustomCharset sets the keys of mask charset ?1 to ?4
+func WithCustomCharset(n int, keys string) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 || n > 4 {
+			return fmt.Errorf("mask: custom charset %d is not 1-4", n)
+		}
+		if _, err := expandCharset(keys, nil); err != nil {
+			return err
+		}
+		h.CustomCharset(n, keys)
+		return nil
+	}
+}
+
+// WithPositionKeys sets the keys allowed at position pos of the key
+func WithPositionKeys(pos int, keys string) Option {
+	return func(h *Hasher) error {
+
+		if pos < 0 {
+			return fmt.Errorf("position %d is out of range", pos)
+		}
+		if _, err := expandCharset(keys, nil); err != nil {
+			return err
+		}
+		h.PositionKeys(pos, keys)
+		return nil
+	}
+}
+
+// WithLength sets the length of key to find
+func WithLength(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("length must be at least 1")
+		}
+		h.Length(n)
+		return nil
+	}
+}
+
+// WithMinLength sets min length of key to find
+func WithMinLength(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("min length must be at least 1")
+		}
+		h.MinLength(n)
+		return nil
+	}
+}
+
+// WithMaxLength sets max length of key to find
+func WithMaxLength(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("max length must be at least 1")
+		}
+		h.MaxLength(n)
+		return nil
+	}
+}
+
+// WithPrefix sets a fixed prefix
+func WithPrefix(s string) Option {
+	return func(h *Hasher) error {
+		h.Prefix(s)
+		return nil
+	}
+}
+
+// WithSuffix sets a fixed suffix
+func WithSuffix(s string) Option {
+	return func(h *Hasher) error {
+		h.Suffix(s)
+		return nil
+	}
+}
+
+// WithReverse sets wether to do sequential find in reverse order
+func WithReverse(b bool) Option {
+	return func(h *Hasher) error {
+		h.Reverse(b)
+		return nil
+	}
+}
+
+// WithWorkers sets the number of goroutines used by FindSequential
+func WithWorkers(n int) Option {
+	return func(h *Hasher) error {
+
+		if n < 1 {
+			return fmt.Errorf("workers must be at least 1")
+		}
+		h.Workers(n)
+		return nil
+	}
+}
+
+// WithMaxAttempts stops the search after n tries, see MaxAttempts
+func WithMaxAttempts(n uint64) Option {
+	return func(h *Hasher) error {
+		h.MaxAttempts(n)
+		return nil
+	}
+}
+
+// WithMaxRate caps the tries per second, see MaxRate
+func WithMaxRate(n uint64) Option {
+	return func(h *Hasher) error {
+		h.MaxRate(n)
+		return nil
+	}
+}
+
+// WithNice pauses each worker after every 1024 tries, see Nice
+func WithNice(d time.Duration) Option {
+	return func(h *Hasher) error {
+
+		if d < 0 {
+			return fmt.Errorf("nice must not be negative")
+		}
+		h.Nice(d)
+		return nil
+	}
+}
+
+// WithMaxDuration stops the search after d, see MaxDuration
+func WithMaxDuration(d time.Duration) Option {
+	return func(h *Hasher) error {
+
+		if d < 0 {
+			return fmt.Errorf("max duration must not be negative")
+		}
+		h.MaxDuration(d)
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHasherWithOptions(t *testing.T) {
+
+	hasher, err := NewHasherWithOptions(
+		WithAlgo("md5"),
+		WithAllowedKeys("ehj"),
+		WithLength(3),
+		WithExpectedHash(md5Hej),
+		WithWorkers(2),
+	)
+	assert.Equal(t, nil, err)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestNewHasherWithOptionsErrors(t *testing.T) {
+
+	tests := []struct {
+		opts []Option
+		err  string
+	}{
+		{[]Option{WithAlgo("nope")}, "unknown algo nope"},
+		{[]Option{WithLength(0)}, "length must be at least 1"},
+		{[]Option{WithAllowedKeys("")}, "allowedKeys unset"},
+		{[]Option{WithWorkers(0)}, "workers must be at least 1"},
+		{[]Option{WithExpectedHash("xyz")}, `expected hash "xyz" is not hex or base64`},
+		{[]Option{WithExpectedHash("$9$salt$hash")}, "unsupported crypt hash $9$"},
+		{[]Option{WithDigestRegexp("(")}, "invalid digest regexp: error parsing regexp: missing closing ): `(`"},
+		{[]Option{WithCustomCharset(5, "abc")}, "mask: custom charset 5 is not 1-4"},
+		{[]Option{WithTemplate("md5(")}, `template "md5(" at 4: expected $pass, $salt, text or hash function`},
+		{[]Option{WithMinLength(4), WithMaxLength(2)}, "max length 2 is less than min length 4"},
+		{[]Option{WithAllowedKeys("abc")}, "minLength unset"},
+		{[]Option{WithMask("?x")}, "mask: unknown placeholder ?x"},
+		{[]Option{WithAllowedKeys("abc"), WithLength(2), WithPositionKeys(3, "?d")}, "position 3 is out of range, length is 2"},
+		{[]Option{WithAlgo("md5"), WithExpectedHash("abcd")}, "expectedHash is wrong size, should be 128 bit, is 16"},
+		{[]Option{WithAlgo("md5"), WithExpectedHash(md5Hej), WithDigestPrefix("00")}, "expected hash and digest pattern dont mix"},
+	}
+	for _, test := range tests {
+		hasher, err := NewHasherWithOptions(test.opts...)
+		assert.Equal(t, (*Hasher)(nil), hasher)
+		if assert.NotEqual(t, nil, err) {
+			assert.Equal(t, test.err, err.Error())
+		}
+	}
+}
+
+func TestNewHasherWithOptionsPartial(t *testing.T) {
+
+	// settings given later with setters are not checked yet
+	hasher, err := NewHasherWithOptions(WithAlgo("sha1"), WithPrefix("pre-"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sha1", hasher.algo)
+}
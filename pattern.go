@@ -0,0 +1,145 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strings"
+)
+
+// DigestPrefix sets a hex prefix, of any number of digits, that the digest
+// must start with instead of an expected hash, for vanity searches
+func (h *Hasher) DigestPrefix(s string) {
+	h.digestPrefix = strings.ToLower(s)
+}
+
+// LeadingZeroBits sets the number of leading zero bits the digest must
+// have instead of an expected hash, for proof of work style searches
+func (h *Hasher) LeadingZeroBits(n int) {
+	h.zeroBits = n
+}
+
+// DigestRegexp sets a regular expression that the encoded digest must
+// match instead of an expected hash, such as "cafe$"
+func (h *Hasher) DigestRegexp(expr string) {
+	h.digestRegexp = expr
+}
+
+// DigestEncoding sets the encoding of the digest matched by DigestRegexp,
+// any of AvailableEncodings, default hex
+func (h *Hasher) DigestEncoding(encoding string) {
+	h.digestEncoding = encoding
+}
+
+// verifyPattern sets h.pattern from the digest patterns, if any
+func (h *Hasher) verifyPattern() error {
+
+	h.pattern = nil
+	patterns := []func([]byte) bool{}
+	if h.digestPrefix != "" {
+		if len(h.digestPrefix)*4 > algos[h.algo] {
+			return fmt.Errorf("digest prefix is longer than %d bit", algos[h.algo])
+		}
+		match, err := prefixMatcher(h.digestPrefix)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, match)
+	}
+	if h.zeroBits != 0 {
+		if h.zeroBits < 0 || h.zeroBits > algos[h.algo] {
+			return fmt.Errorf("leading zero bits must be 1 to %d", algos[h.algo])
+		}
+		n := h.zeroBits
+		patterns = append(patterns, func(digest []byte) bool {
+			return leadingZeroBits(digest) >= n
+		})
+	}
+	if h.digestRegexp != "" {
+		match, err := h.regexpMatcher()
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, match)
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+	if len(h.expected) != 0 || h.targets != nil {
+		return fmt.Errorf("expected hash and digest pattern dont mix")
+	}
+	h.pattern = func(digest []byte) bool {
+		for _, match := range patterns {
+			if !match(digest) {
+				return false
+			}
+		}
+		return true
+	}
+	return nil
+}
+
+// prefixMatcher returns a func matching digests starting with the hex
+// digits of s
+func prefixMatcher(s string) (func([]byte) bool, error) {
+
+	full, err := hex.DecodeString(s[:len(s)/2*2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest prefix %q", s)
+	}
+	if len(s)%2 == 0 {
+		return func(digest []byte) bool {
+			return bytes.HasPrefix(digest, full)
+		}, nil
+	}
+
+	last, err := hex.DecodeString(s[len(s)-1:] + "0")
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest prefix %q", s)
+	}
+	nibble := last[0]
+	return func(digest []byte) bool {
+		return len(digest) > len(full) && bytes.HasPrefix(digest, full) && digest[len(full)]&0xf0 == nibble
+	}, nil
+}
+
+// regexpMatcher returns a func matching digests whose encoding matches
+// the digest regexp
+func (h *Hasher) regexpMatcher() (func([]byte) bool, error) {
+
+	re, err := regexp.Compile(h.digestRegexp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest regexp: %v", err)
+	}
+
+	if (h.digestEncoding == "" || h.digestEncoding == "hex") && algos[h.algo] <= 512 {
+		return func(digest []byte) bool {
+			var tmp [2 * 64]byte
+			n := hex.Encode(tmp[:], digest)
+			return re.Match(tmp[:n])
+		}, nil
+	}
+
+	coder := NewCoder(h.digestEncoding)
+	if _, err := coder.Encode(make([]byte, algos[h.algo]/8)); err != nil {
+		return nil, err
+	}
+	return func(digest []byte) bool {
+		enc, err := coder.Encode(digest)
+		return err == nil && re.Match(enc)
+	}, nil
+}
+
+// leadingZeroBits returns the number of leading zero bits in b
+func leadingZeroBits(b []byte) int {
+
+	for i, c := range b {
+		if c != 0 {
+			return i*8 + bits.LeadingZeros8(c)
+		}
+	}
+	return len(b) * 8
+}
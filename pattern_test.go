@@ -0,0 +1,97 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPatternHasher() *Hasher {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abcdefgh")
+	hasher.Length(3)
+	return hasher
+}
+
+func TestDigestPrefix(t *testing.T) {
+
+	hasher := newPatternHasher()
+	hasher.DigestPrefix("00")
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bed", res)
+	assert.Equal(t, map[string]string{"001cbc059a402b3be7c99be558eaaf73": "bed"}, hasher.Found())
+
+	// odd number of digits
+	hasher.DigestPrefix("007")
+	res, err = hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "dhb", res)
+
+	hasher.DigestPrefix("0079A")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+
+	hasher := newPatternHasher()
+	hasher.LeadingZeroBits(10)
+	hasher.Reverse(true)
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bed", res)
+
+	hasher.LeadingZeroBits(129)
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "leading zero bits must be 1 to 128", err.Error())
+}
+
+func TestDigestPatternInvalid(t *testing.T) {
+
+	hasher := newPatternHasher()
+	hasher.DigestPrefix("0g")
+	_, err := hasher.FindSequential()
+	assert.Equal(t, `invalid digest prefix "0g"`, err.Error())
+
+	hasher.DigestPrefix("000000000000000000000000000000000")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "digest prefix is longer than 128 bit", err.Error())
+
+	hasher.DigestPrefix("00")
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "expected hash and digest pattern dont mix", err.Error())
+}
+
+func TestDigestRegexp(t *testing.T) {
+
+	hasher := newPatternHasher()
+	hasher.DigestRegexp("fe$")
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "dhc", res)
+
+	hasher.DigestRegexp("^AB")
+	hasher.DigestEncoding("base64")
+	res, err = hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "bed", res)
+
+	hasher.DigestEncoding("nope")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "unknown encoding: nope", err.Error())
+
+	hasher.DigestRegexp("(")
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "invalid digest regexp: error parsing regexp: missing closing ): `(`", err.Error())
+}
+
+func TestLeadingZeroBitsCount(t *testing.T) {
+
+	assert.Equal(t, 0, leadingZeroBits([]byte{0x80}))
+	assert.Equal(t, 11, leadingZeroBits([]byte{0x00, 0x1c}))
+	assert.Equal(t, 16, leadingZeroBits([]byte{0x00, 0x00}))
+}
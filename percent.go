@@ -0,0 +1,69 @@
+package gohash
+
+const (
+	upperHex = "0123456789ABCDEF"
+)
+
+// encodePercent escapes every byte except the RFC 3986 unreserved characters
+func encodePercent(src []byte) ([]byte, error) {
+
+	res := make([]byte, 0, len(src)*3)
+	for _, b := range src {
+		if isUnreservedURLByte(b) {
+			res = append(res, b)
+		} else {
+			res = append(res, '%', upperHex[b>>4], upperHex[b&0x0f])
+		}
+	}
+	return res, nil
+}
+
+// decodePercent decodes %XX sequences, leaving malformed escapes as is
+func decodePercent(src []byte) ([]byte, error) {
+	return percentDecode(src, false)
+}
+
+// decodePercentStrict decodes %XX sequences, rejecting malformed escapes
+func decodePercentStrict(src []byte) ([]byte, error) {
+	return percentDecode(src, true)
+}
+
+func percentDecode(src []byte, strict bool) ([]byte, error) {
+
+	res := make([]byte, 0, len(src))
+	for i := 0; i < len(src); i++ {
+		if src[i] != '%' {
+			res = append(res, src[i])
+			continue
+		}
+		if i+2 < len(src) && isHexByte(src[i+1]) && isHexByte(src[i+2]) {
+			res = append(res, hexValue(src[i+1])<<4|hexValue(src[i+2]))
+			i += 2
+			continue
+		}
+		if strict {
+			return nil, newDecodeError("percent", src, i, "malformed escape")
+		}
+		res = append(res, '%')
+	}
+	return res, nil
+}
+
+func isUnreservedURLByte(b byte) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+		b == '-' || b == '_' || b == '.' || b == '~'
+}
+
+func isHexByte(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+func hexValue(b byte) byte {
+	switch {
+	case b >= 'a':
+		return b - 'a' + 10
+	case b >= 'A':
+		return b - 'A' + 10
+	}
+	return b - '0'
+}
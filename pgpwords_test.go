@@ -0,0 +1,50 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPGPWords(t *testing.T) {
+
+	fingerprint, _ := hex.DecodeString("E58294F2E9A227486E8B061B31CC528FD7FA3F19")
+	words := "topmost Istanbul Pluto vagabond treadmill Pacific brackish dictator goldfish Medusa afflict bravado chatter revolver Dupont midsummer stopwatch whimsical cowbell bottomless"
+
+	res, err := encodePGPWords(fingerprint)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, words, string(res))
+
+	dec, err := decodePGPWords([]byte(words))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fingerprint, dec)
+}
+
+func TestPGPWordsLists(t *testing.T) {
+
+	assert.Equal(t, 256, len(pgpEvenWords))
+	assert.Equal(t, 256, len(pgpOddWords))
+	assert.Equal(t, "aardvark", pgpEvenWords[0])
+	assert.Equal(t, "Zulu", pgpEvenWords[255])
+	assert.Equal(t, "adroitness", pgpOddWords[0])
+	assert.Equal(t, "Yucatan", pgpOddWords[255])
+}
+
+func TestPGPWordsParity(t *testing.T) {
+
+	dec, err := decodePGPWords([]byte("TOPMOST istanbul"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xe5, 0x82}, dec)
+
+	// missing word shifts the parity
+	_, err = decodePGPWords([]byte("topmost Pluto vagabond"))
+	assert.NotEqual(t, nil, err)
+
+	// repeated word
+	_, err = decodePGPWords([]byte("topmost Istanbul Istanbul"))
+	assert.Equal(t, "pgpwords: word missing or repeated before 'I' at offset 17", err.Error())
+
+	_, err = decodePGPWords([]byte("topmost nope"))
+	assert.NotEqual(t, nil, err)
+}
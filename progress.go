@@ -0,0 +1,164 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	// default time between progress reports
+	defaultProgressInterval = 1 * time.Second
+)
+
+// Stats holds the progress of a search
+type Stats struct {
+	Algo    string
+	Tries   uint64
+	Elapsed time.Duration
+
+	// how candidates are hashed, such as "stdlib, prefix midstate"
+	Implementation string
+
+	// the most recently tried candidate
+	Current string
+
+	// number of keys to search, nil if unknown such as with a wordlist
+	Keyspace *big.Int
+}
+
+// Rate returns the average number of tries per second
+func (s Stats) Rate() uint64 {
+
+	if s.Elapsed < time.Second {
+		return s.Tries
+	}
+	return uint64(float64(s.Tries) / s.Elapsed.Seconds())
+}
+
+// Percent returns the percentage of the keyspace searched, or 0 if the
+// keyspace is unknown
+func (s Stats) Percent() float64 {
+
+	if s.Keyspace == nil || s.Keyspace.Sign() == 0 {
+		return 0
+	}
+	total, _ := new(big.Float).SetInt(s.Keyspace).Float64()
+	return math.Min(100, float64(s.Tries)*100/total)
+}
+
+// Remaining returns the estimated time left to search the rest of the
+// keyspace at the current rate, or 0 if it is unknown
+func (s Stats) Remaining() time.Duration {
+
+	rate := s.Rate()
+	if s.Keyspace == nil || rate == 0 {
+		return 0
+	}
+	left := new(big.Int).Sub(s.Keyspace, new(big.Int).SetUint64(s.Tries))
+	if left.Sign() <= 0 {
+		return 0
+	}
+	secs, _ := new(big.Float).SetInt(left).Float64()
+	secs /= float64(rate)
+	if secs >= math.MaxInt64/float64(time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// String returns the stats in the format written to the ProgressWriter
+func (s Stats) String() string {
+
+	if s.Keyspace == nil {
+		return fmt.Sprintf("%s ~%d/s %s", s.Algo, s.Rate(), s.Current)
+	}
+	return fmt.Sprintf("%s ~%d/s %.2f%% eta %s %s", s.Algo, s.Rate(), s.Percent(), s.Remaining().Round(time.Second), s.Current)
+}
+
+// OnProgress sets a func called with the stats every progress interval
+// during a search
+func (h *Hasher) OnProgress(fn func(Stats)) {
+	h.onProgress = fn
+}
+
+// ProgressInterval sets the time between progress reports, default 1s. A
+// negative interval disables the reports
+func (h *Hasher) ProgressInterval(d time.Duration) {
+	h.progressInterval = d
+}
+
+// ProgressWriter sets a writer that a line of stats is written to every
+// progress interval, such as os.Stdout
+func (h *Hasher) ProgressWriter(w io.Writer) {
+	h.progressWriter = w
+}
+
+// stats returns the current progress
+func (h *Hasher) stats() Stats {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	return Stats{
+		Algo:           h.algo,
+		Tries:          h.try,
+		Elapsed:        time.Since(h.started),
+		Current:        string(h.buffer),
+		Keyspace:       h.total,
+		Implementation: h.implementation,
+	}
+}
+
+// setTotal sets the number of keys of the search, for progress reports
+func (h *Hasher) setTotal(n *big.Int) {
+
+	mutex.Lock()
+	h.total = n
+	mutex.Unlock()
+}
+
+// startStatusReport reports the progress every interval, if anyone is
+// listening, until the returned func is called at the end of the search
+func (h *Hasher) startStatusReport() func() {
+
+	mutex.Lock()
+	async := h.async != nil
+	mutex.Unlock()
+	if h.onProgress == nil && h.progressWriter == nil && !async || h.progressInterval < 0 {
+		return func() {}
+	}
+
+	interval := h.progressInterval
+	if interval == 0 {
+		interval = defaultProgressInterval
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			stats := h.stats()
+			if h.onProgress != nil {
+				h.onProgress(stats)
+			}
+			if h.progressWriter != nil {
+				fmt.Fprintln(h.progressWriter, stats)
+			}
+			h.sendProgress(stats)
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
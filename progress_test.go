@@ -0,0 +1,143 @@
+package gohash
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsRate(t *testing.T) {
+
+	assert.Equal(t, uint64(500), Stats{Tries: 1000, Elapsed: 2 * time.Second}.Rate())
+	assert.Equal(t, uint64(10), Stats{Tries: 10, Elapsed: time.Millisecond}.Rate())
+	assert.Equal(t, "md5 ~500/s hej", Stats{Algo: "md5", Tries: 1000, Elapsed: 2 * time.Second, Current: "hej"}.String())
+}
+
+func TestStatsRateLargeTries(t *testing.T) {
+
+	s := Stats{Algo: "md5", Tries: 2e10, Elapsed: 100 * time.Second, Keyspace: big.NewInt(4e10)}
+	assert.Equal(t, uint64(2e8), s.Rate())
+	assert.Equal(t, 100*time.Second, s.Remaining())
+	assert.Equal(t, "md5 ~200000000/s 50.00% eta 1m40s ", s.String())
+}
+
+func TestStatsRemaining(t *testing.T) {
+
+	s := Stats{Algo: "md5", Tries: 1000, Elapsed: 2 * time.Second, Current: "hej", Keyspace: big.NewInt(4000)}
+	assert.Equal(t, 25.0, s.Percent())
+	assert.Equal(t, 6*time.Second, s.Remaining())
+	assert.Equal(t, "md5 ~500/s 25.00% eta 6s hej", s.String())
+
+	s.Keyspace = new(big.Int).Lsh(big.NewInt(1), 80)
+	assert.Equal(t, time.Duration(math.MaxInt64), s.Remaining())
+
+	s.Keyspace = nil
+	assert.Equal(t, 0.0, s.Percent())
+	assert.Equal(t, time.Duration(0), s.Remaining())
+}
+
+func TestHasherOnProgress(t *testing.T) {
+
+	stats := make(chan Stats, 1)
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz0123456789")
+	hasher.Length(4)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.ProgressInterval(time.Millisecond)
+	hasher.OnProgress(func(s Stats) {
+		if s.Tries == 0 {
+			return
+		}
+		select {
+		case stats <- s:
+		default:
+		}
+	})
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+
+	s := <-stats
+	assert.Equal(t, "md5", s.Algo)
+	assert.Equal(t, 4, len(s.Current))
+	assert.Equal(t, big.NewInt(36*36*36*36), s.Keyspace)
+}
+
+// syncBuffer is a bytes.Buffer safe for use by the status goroutine
+type syncBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
+
+func TestHasherProgressWriter(t *testing.T) {
+
+	var out syncBuffer
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz0123456789")
+	hasher.Length(4)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.ProgressInterval(time.Millisecond)
+	hasher.ProgressWriter(&out)
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+
+	assert.Equal(t, true, strings.HasPrefix(out.String(), "md5 ~"))
+}
+
+func TestHasherStatusReportStops(t *testing.T) {
+
+	var out syncBuffer
+	before := runtime.NumGoroutine()
+	for i := 0; i < 5; i++ {
+		hasher := NewHasher()
+		hasher.Algo("md5")
+		hasher.AllowedKeys("abc")
+		hasher.Length(3)
+		hasher.ExpectedHash(md5Zero)
+		hasher.ProgressWriter(&out)
+
+		_, err := hasher.FindSequential()
+		assert.Equal(t, "no match found", err.Error())
+	}
+	assert.Equal(t, before, runtime.NumGoroutine())
+}
+
+func TestHasherProgressDisabled(t *testing.T) {
+
+	var out syncBuffer
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz0123456789")
+	hasher.Length(4)
+	hasher.ExpectedHash(md5Zero)
+	hasher.ProgressWriter(&out)
+	hasher.ProgressInterval(-1)
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+	assert.Equal(t, "", out.String())
+}
@@ -0,0 +1,61 @@
+package gohash
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// encodeProquint encodes each 16 bits as a pronounceable quintet,
+// joined by "-". src must be a multiple of 2 bytes
+func encodeProquint(src []byte) ([]byte, error) {
+
+	if len(src)%2 != 0 {
+		return nil, fmt.Errorf("proquint: input must be a multiple of 2 bytes, got %d", len(src))
+	}
+
+	words := make([]string, 0, len(src)/2)
+	for i := 0; i < len(src); i += 2 {
+		n := uint16(src[i])<<8 | uint16(src[i+1])
+		words = append(words, string([]byte{
+			proquintConsonants[n>>12&0xf],
+			proquintVowels[n>>10&0x3],
+			proquintConsonants[n>>6&0xf],
+			proquintVowels[n>>4&0x3],
+			proquintConsonants[n&0xf],
+		}))
+	}
+	return []byte(strings.Join(words, "-")), nil
+}
+
+func decodeProquint(src []byte) ([]byte, error) {
+
+	fields := strings.FieldsFunc(strings.ToLower(string(src)), func(r rune) bool {
+		return r == '-' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+
+	res := make([]byte, 0, len(fields)*2)
+	for _, word := range fields {
+		if len(word) != 5 {
+			return nil, fmt.Errorf("proquint: invalid quintet %q", word)
+		}
+		n := uint16(0)
+		for i := 0; i < 5; i++ {
+			alphabet, bits := proquintConsonants, uint(4)
+			if i%2 == 1 {
+				alphabet, bits = proquintVowels, 2
+			}
+			idx := strings.IndexByte(alphabet, word[i])
+			if idx == -1 {
+				return nil, fmt.Errorf("proquint: invalid quintet %q", word)
+			}
+			n = n<<bits | uint16(idx)
+		}
+		res = append(res, byte(n>>8), byte(n))
+	}
+	return res, nil
+}
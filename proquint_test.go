@@ -0,0 +1,45 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProquint(t *testing.T) {
+
+	for quint, ip := range map[string][]byte{
+		"lusab-babad": {127, 0, 0, 1},
+		"gutih-tugad": {63, 84, 220, 193},
+		"babab-babab": {0, 0, 0, 0},
+		"zuzuz-zuzuz": {255, 255, 255, 255},
+		"":            {},
+	} {
+		res, err := encodeProquint(ip)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, quint, string(res))
+
+		dec, err := decodeProquint([]byte(quint))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, ip, dec)
+	}
+}
+
+func TestProquintInvalid(t *testing.T) {
+
+	_, err := encodeProquint([]byte{1, 2, 3})
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeProquint([]byte("lusab-babe"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeProquint([]byte("lusab-baaad"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestProquintLenientDecode(t *testing.T) {
+
+	res, err := NewCoder("proquint").Decode([]byte("LUSAB babad\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{127, 0, 0, 1}, res)
+}
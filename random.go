@@ -0,0 +1,115 @@
+package gohash
+
+import (
+	"context"
+	"math/bits"
+	"math/rand"
+)
+
+// permutation returns each number below n once, in a random order, by
+// cycle walking a full period generator mod a power of two
+type permutation struct {
+	n    uint64
+	left uint64
+
+	// lcg state and parameters, and the mixing of its output
+	x, a, c uint64
+	mul     uint64
+	mask    uint64
+	shift   uint
+}
+
+// newPermutation returns a permutation of the numbers below n, seeded
+// from math/rand
+func newPermutation(n uint64) *permutation {
+
+	size := uint(bits.Len64(n - 1))
+	p := &permutation{
+		n:     n,
+		left:  n,
+		x:     uint64(rand.Int63()),
+		a:     uint64(rand.Int63())<<2 | 1,
+		c:     uint64(rand.Int63()) | 1,
+		mul:   uint64(rand.Int63()) | 1,
+		mask:  1<<size - 1,
+		shift: (size + 1) / 2,
+	}
+	if size == 64 {
+		p.mask = ^uint64(0)
+	}
+	return p
+}
+
+// next returns the next number, or false when all have been returned
+func (p *permutation) next() (uint64, bool) {
+
+	if p.left == 0 {
+		return 0, false
+	}
+	for {
+		// a%4 == 1 and odd c gives a full period mod any power of two
+		p.x = (p.a*p.x + p.c) & p.mask
+		if v := p.mix(p.x); v < p.n {
+			p.left--
+			return v, true
+		}
+	}
+}
+
+// mix scrambles the sequential lcg output, as a bijection of the masked
+// bits
+func (p *permutation) mix(x uint64) uint64 {
+
+	x ^= x >> p.shift
+	x = x * p.mul & p.mask
+	x ^= x >> p.shift
+	return x
+}
+
+// findRandomUnique tries the n keys in a random order, each key once
+func (h *Hasher) findRandomUnique(ctx context.Context, n uint64) (string, error) {
+
+	bufs := make([][]byte, h.maxLength-h.minLength+1)
+	sizes := make([]uint64, len(bufs))
+	for i := range bufs {
+		bufs[i] = h.newCandidate(h.minLength + i)
+		sizes[i] = h.lengthKeyspace(h.minLength + i).Uint64()
+	}
+
+	perm := newPermutation(n)
+	batch := h.newBatch(0)
+	tries := uint64(0)
+	var buf []byte
+	for {
+		index, ok := perm.next()
+		if !ok {
+			break
+		}
+
+		// find the length of the index, and its keys
+		i := 0
+		for ; index >= sizes[i]; i++ {
+			index -= sizes[i]
+		}
+		buf = bufs[i]
+		key := buf[len(h.prefix) : len(buf)-len(h.suffix)]
+		for pos := len(key) - 1; pos >= 0; pos-- {
+			keys := h.positions[pos]
+			key[pos] = keys[index%uint64(len(keys))]
+			index /= uint64(len(keys))
+		}
+
+		if (h.tried == nil || !h.tried.add(buf)) && batch.equals(buf) {
+			return string(buf), nil
+		}
+		tries++
+		if tries%statusInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			h.reportProgress(buf, statusInterval)
+		}
+	}
+	h.reportProgress(buf, tries%statusInterval)
+	return "", h.notFound()
+}
@@ -0,0 +1,60 @@
+package gohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermutation(t *testing.T) {
+
+	for _, n := range []uint64{1, 2, 3, 7, 64, 100, 1000} {
+		seen := make(map[uint64]bool)
+		perm := newPermutation(n)
+		for {
+			v, ok := perm.next()
+			if !ok {
+				break
+			}
+			assert.True(t, v < n)
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+		assert.Equal(t, int(n), len(seen))
+	}
+}
+
+func TestHashRandomVisitsEachKeyOnce(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("abc")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+	hasher.DigestRegexp(".")
+	hasher.findAll = true
+
+	_, err := hasher.FindRandom()
+	assert.NotEqual(t, nil, err)
+
+	seen := make(map[string]int)
+	for _, key := range hasher.all {
+		seen[key]++
+	}
+	assert.Equal(t, 3+9+27, len(seen))
+	for key, n := range seen {
+		assert.Equal(t, 1, n, key)
+	}
+}
+
+func TestHashRandomExhausted(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ab")
+	hasher.Length(4)
+	hasher.ExpectedHash("00000000000000000000000000000000")
+
+	_, err := hasher.FindRandom()
+	assert.Equal(t, "no match found", err.Error())
+}
@@ -0,0 +1,165 @@
+package gohash
+
+import (
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+
+	"github.com/cxmcc/tiger"
+	"github.com/dchest/blake256"
+	"github.com/dchest/blake2b"
+	"github.com/dchest/blake2s"
+	"github.com/dchest/blake512"
+	"github.com/dchest/siphash"
+	"github.com/dchest/skein"
+	"github.com/htruong/go-md2"
+	"github.com/jzelinskie/whirlpool"
+	"github.com/martinlindhe/gogost/gost341194"
+	"golang.org/x/crypto/md4"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
+)
+
+var (
+	// algos that are hashed as they are read by SumReader, in addition to
+	// hmacHashes
+	streamHashes = map[string]func() hash.Hash{
+		"adler32": func() hash.Hash {
+			return adler32.New()
+		},
+		"blake224":    blake256.New224,
+		"blake256":    blake256.New,
+		"blake384":    blake512.New384,
+		"blake512":    blake512.New,
+		"blake2b-256": blake2b.New256,
+		"blake2b-512": blake2b.New512,
+		"blake2s-256": blake2s.New256,
+		"blake3-256": func() hash.Hash {
+			return blake3.New(32, nil)
+		},
+		"crc32-ieee": func() hash.Hash {
+			return crc32.NewIEEE()
+		},
+		"crc32-castagnoli": func() hash.Hash {
+			return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+		},
+		"crc32-koopman": func() hash.Hash {
+			return crc32.New(crc32.MakeTable(crc32.Koopman))
+		},
+		"crc64-iso": func() hash.Hash {
+			return crc64.New(crc64.MakeTable(crc64.ISO))
+		},
+		"crc64-ecma": func() hash.Hash {
+			return crc64.New(crc64.MakeTable(crc64.ECMA))
+		},
+		"fnv1-32": func() hash.Hash {
+			return fnv.New32()
+		},
+		"fnv1a-32": func() hash.Hash {
+			return fnv.New32a()
+		},
+		"fnv1-64": func() hash.Hash {
+			return fnv.New64()
+		},
+		"fnv1a-64": func() hash.Hash {
+			return fnv.New64a()
+		},
+		"gost": func() hash.Hash {
+			return gost341194.New(gost341194.SboxDefault)
+		},
+		"md2":       md2.New,
+		"md4":       md4.New,
+		"ripemd160": ripemd160.New,
+		"sha3-224":  sha3.New224,
+		"sha3-256":  sha3.New256,
+		"sha3-384":  sha3.New384,
+		"sha3-512":  sha3.New512,
+		"shake128-256": func() hash.Hash {
+			return &shakeHash{sha3.NewShake128(), 32}
+		},
+		"shake256-512": func() hash.Hash {
+			return &shakeHash{sha3.NewShake256(), 64}
+		},
+		"siphash-2-4": func() hash.Hash {
+			return siphash.New(make([]byte, 16))
+		},
+		"skein512-256": func() hash.Hash {
+			return skein.NewHash(32)
+		},
+		"skein512-512": func() hash.Hash {
+			return skein.NewHash(64)
+		},
+		"tiger192":  tiger.New,
+		"whirlpool": whirlpool.New,
+	}
+)
+
+// shakeHash is a hash.Hash of a shake, with a fixed output size
+type shakeHash struct {
+	sha3.ShakeHash
+	size int
+}
+
+func (s *shakeHash) Sum(b []byte) []byte {
+
+	res := make([]byte, s.size)
+	s.Clone().Read(res)
+	return append(b, res...)
+}
+
+func (s *shakeHash) Size() int {
+	return s.size
+}
+
+// newStreamHash returns a hash.Hash of algo, or nil if algo is only
+// calculated from the whole input
+func newStreamHash(algo string) hash.Hash {
+
+	if newHash, ok := hmacHashes[algo]; ok {
+		return newHash()
+	}
+	if newHash, ok := streamHashes[algo]; ok {
+		return newHash()
+	}
+	return nil
+}
+
+// SumReader returns the checksum of algo of everything read from r, and
+// the number of bytes read. The algos are hashed as r is read, except for
+// the crc8, crc16 and crc24 and password hashes, that need the whole input
+// in memory. Those fail on inputs larger than MaxInputMemory, if it is set
+func SumReader(algo string, r io.Reader) (*[]byte, int64, error) {
+
+	algo = resolveAlgoAliases(algo)
+	if state := newStreamHash(algo); state != nil {
+		n, err := io.Copy(state, r)
+		if err != nil {
+			return nil, n, err
+		}
+		res := state.Sum(nil)
+		return &res, n, nil
+	}
+
+	checksum, ok := hashers[algo]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown algo %s", algo)
+	}
+	if MaxInputMemory > 0 {
+		r = io.LimitReader(r, MaxInputMemory+1)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, int64(len(data)), err
+	}
+	if MaxInputMemory > 0 && int64(len(data)) > MaxInputMemory {
+		return nil, int64(len(data)), fmt.Errorf("%s is not streamed, and the input is more than max memory %d",
+			algo, MaxInputMemory)
+	}
+	return checksum(&data), int64(len(data)), nil
+}
@@ -0,0 +1,70 @@
+package gohash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumReader(t *testing.T) {
+
+	data := []byte(fox)
+	for _, algo := range AvailableHashes() {
+		sum, n, err := SumReader(algo, iotest.OneByteReader(strings.NewReader(fox)))
+		assert.Equal(t, nil, err, algo)
+		assert.Equal(t, int64(len(fox)), n, algo)
+		assert.Equal(t, *NewCalculator(data).Sum(algo), *sum, algo)
+	}
+}
+
+func TestSumReaderStreams(t *testing.T) {
+
+	whole := []string{}
+	for _, algo := range AvailableHashes() {
+		if newStreamHash(algo) == nil {
+			whole = append(whole, algo)
+		}
+	}
+	assert.Equal(t, []string{"crc16-ccitt", "crc16-ccitt-false", "crc16-ibm", "crc16-scsi",
+		"crc24-openpgp", "crc8-atm", "lm", "lm-half", "mysql323", "mysql41", "ntlm"}, whole)
+}
+
+func TestSumReaderErrors(t *testing.T) {
+
+	_, _, err := SumReader("nope", strings.NewReader(fox))
+	assert.Equal(t, "unknown algo nope", err.Error())
+
+	broken := errors.New("broken")
+	_, _, err = SumReader("md5", iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader(fox))))
+	assert.Equal(t, iotest.ErrTimeout, err)
+	_, _, err = SumReader("tiger192", iotest.DataErrReader(errReader{broken}))
+	assert.Equal(t, broken, err)
+}
+
+func TestSumReaderMaxInputMemory(t *testing.T) {
+
+	defer func(n int64) { MaxInputMemory = n }(MaxInputMemory)
+	MaxInputMemory = 10
+
+	sum, n, err := SumReader("blake2b-512", strings.NewReader(fox))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(len(fox)), n)
+	assert.Equal(t, *NewCalculator([]byte(fox)).Sum("blake2b-512"), *sum)
+
+	_, _, err = SumReader("crc16-ibm", strings.NewReader(fox))
+	assert.Equal(t, "crc16-ibm is not streamed, and the input is more than max memory 10", err.Error())
+
+	_, _, err = SumReader("crc16-ibm", strings.NewReader("0123456789"))
+	assert.Equal(t, nil, err)
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,202 @@
+package gohash
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Result is the outcome of a hash calculation, for machine consumption
+type Result struct {
+	Algo     string
+	Name     string
+	Digest   []byte
+	Encoding string
+	Duration time.Duration
+	Size     int64
+}
+
+// resultJSON is the serialized form of Result
+type resultJSON struct {
+	Algo     string `json:"algo"`
+	Name     string `json:"name"`
+	Digest   string `json:"digest"`
+	Encoding string `json:"encoding"`
+	Duration int64  `json:"duration_ns"`
+	Size     int64  `json:"size"`
+}
+
+var (
+	resultCSVHeader = []string{"algo", "name", "digest", "encoding", "duration_ns", "size"}
+)
+
+// Result calculates the checksum of algo and returns it as a Result,
+// with digest to be rendered in encoding
+func (c *Calculator) Result(algo string, name string, encoding string) (*Result, error) {
+
+	started := time.Now()
+	sum := c.Sum(algo)
+	if sum == nil {
+		return nil, fmt.Errorf("unknown algo %s", algo)
+	}
+
+	return &Result{
+		Algo:     resolveAlgoAliases(algo),
+		Name:     name,
+		Digest:   *sum,
+		Encoding: resolveEncodingAliases(encoding),
+		Duration: time.Since(started),
+		Size:     int64(len(c.data)),
+	}, nil
+}
+
+// ReaderResult calculates the checksum of algo of everything read from r,
+// see SumReader, and returns it as a Result
+func ReaderResult(algo string, name string, encoding string, r io.Reader) (*Result, error) {
+
+	started := time.Now()
+	sum, size, err := SumReader(algo, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Algo:     resolveAlgoAliases(algo),
+		Name:     name,
+		Digest:   *sum,
+		Encoding: resolveEncodingAliases(encoding),
+		Duration: time.Since(started),
+		Size:     size,
+	}, nil
+}
+
+// EncodedDigest returns the digest in the result encoding
+func (r *Result) EncodedDigest() (string, error) {
+
+	res, err := NewCoder(r.Encoding).Encode(r.Digest)
+	return string(res), err
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Result) MarshalJSON() ([]byte, error) {
+
+	digest, err := r.EncodedDigest()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resultJSON{
+		Algo:     r.Algo,
+		Name:     r.Name,
+		Digest:   digest,
+		Encoding: resolveEncodingAliases(r.Encoding),
+		Duration: int64(r.Duration),
+		Size:     r.Size,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *Result) UnmarshalJSON(b []byte) error {
+
+	var tmp resultJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	digest, err := NewCoder(tmp.Encoding).Decode([]byte(tmp.Digest))
+	if err != nil {
+		return err
+	}
+
+	r.Algo = tmp.Algo
+	r.Name = tmp.Name
+	r.Digest = digest
+	r.Encoding = resolveEncodingAliases(tmp.Encoding)
+	r.Duration = time.Duration(tmp.Duration)
+	r.Size = tmp.Size
+	return nil
+}
+
+// CSVRecord returns the result as a csv record, in the column order of
+// WriteResultsCSV
+func (r *Result) CSVRecord() ([]string, error) {
+
+	digest, err := r.EncodedDigest()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		r.Algo,
+		r.Name,
+		digest,
+		resolveEncodingAliases(r.Encoding),
+		strconv.FormatInt(int64(r.Duration), 10),
+		strconv.FormatInt(r.Size, 10),
+	}, nil
+}
+
+// WriteResultsCSV writes results to w as csv, with a header row
+func WriteResultsCSV(w io.Writer, results []Result) error {
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(resultCSVHeader); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		rec, err := r.CSVRecord()
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadResultsCSV parses csv as written by WriteResultsCSV
+func ReadResultsCSV(r io.Reader) ([]Result, error) {
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Result{}
+	for i, rec := range records {
+		if i == 0 && rec[0] == resultCSVHeader[0] {
+			continue
+		}
+		if len(rec) != len(resultCSVHeader) {
+			return nil, fmt.Errorf("csv: line %d has %d fields, expected %d", i+1, len(rec), len(resultCSVHeader))
+		}
+		digest, err := NewCoder(rec[3]).Decode([]byte(rec[2]))
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: %v", i+1, err)
+		}
+		duration, err := strconv.ParseInt(rec[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: %v", i+1, err)
+		}
+		size, err := strconv.ParseInt(rec[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("csv: line %d: %v", i+1, err)
+		}
+		res = append(res, Result{
+			Algo:     rec[0],
+			Name:     rec[1],
+			Digest:   digest,
+			Encoding: rec[3],
+			Duration: time.Duration(duration),
+			Size:     size,
+		})
+	}
+	return res, nil
+}
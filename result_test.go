@@ -0,0 +1,73 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatorResult(t *testing.T) {
+
+	calc := NewCalculator([]byte(fox))
+	res, err := calc.Result("crc32", "fox.txt", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "crc32-ieee", res.Algo)
+	assert.Equal(t, "hex", res.Encoding)
+	assert.Equal(t, int64(43), res.Size)
+
+	_, err = calc.Result("nope", "fox.txt", "")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestReaderResult(t *testing.T) {
+
+	res, err := ReaderResult("crc32", "fox.txt", "", bytes.NewReader([]byte(fox)))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "crc32-ieee", res.Algo)
+	assert.Equal(t, *NewCalculator([]byte(fox)).Sum("crc32"), res.Digest)
+	assert.Equal(t, int64(43), res.Size)
+
+	_, err = ReaderResult("nope", "fox.txt", "", bytes.NewReader([]byte(fox)))
+	assert.Equal(t, "unknown algo nope", err.Error())
+}
+
+func TestResultJSON(t *testing.T) {
+
+	res := Result{
+		Algo:     "md5",
+		Name:     "fox.txt",
+		Digest:   []byte{0x9e, 0x10, 0x7d, 0x9d},
+		Encoding: "base64",
+		Duration: 1500 * time.Nanosecond,
+		Size:     43,
+	}
+
+	b, err := json.Marshal(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"algo":"md5","name":"fox.txt","digest":"nhB9nQ==","encoding":"base64","duration_ns":1500,"size":43}`, string(b))
+
+	var back Result
+	assert.Equal(t, nil, json.Unmarshal(b, &back))
+	assert.Equal(t, res, back)
+}
+
+func TestResultsCSV(t *testing.T) {
+
+	results := []Result{
+		{Algo: "md5", Name: "a, b.txt", Digest: []byte{1, 2}, Encoding: "hex", Duration: 10, Size: 3},
+		{Algo: "sha1", Name: "c.txt", Digest: []byte{3, 4}, Encoding: "hex", Duration: 20, Size: 4},
+	}
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, WriteResultsCSV(&buf, results))
+	assert.Equal(t, "algo,name,digest,encoding,duration_ns,size\n"+
+		"md5,\"a, b.txt\",0102,hex,10,3\n"+
+		"sha1,c.txt,0304,hex,20,4\n", buf.String())
+
+	back, err := ReadResultsCSV(&buf)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, results, back)
+}
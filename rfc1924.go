@@ -0,0 +1,79 @@
+package gohash
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	rfc1924Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+)
+
+var (
+	big85 = big.NewInt(85)
+)
+
+// encodeRFC1924 encodes src as one big number in the RFC 1924 base85
+// alphabet, so a 16 byte IPv6 address becomes 20 characters. Output width
+// only depends on input length, which keeps leading zero bytes intact
+func encodeRFC1924(src []byte) ([]byte, error) {
+
+	width := rfc1924Width(len(src))
+	res := make([]byte, width)
+
+	n := new(big.Int).SetBytes(src)
+	mod := new(big.Int)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, big85, mod)
+		res[i] = rfc1924Alphabet[mod.Int64()]
+	}
+	return res, nil
+}
+
+func decodeRFC1924(src []byte) ([]byte, error) {
+
+	// widths grow by at least one per byte, so there is at most one match
+	length := int(float64(len(src)) * math.Log2(85) / 8)
+	if rfc1924Width(length) != len(src) {
+		return nil, fmt.Errorf("rfc1924: invalid length %d", len(src))
+	}
+
+	n := new(big.Int)
+	for i, c := range src {
+		idx := strings.IndexByte(rfc1924Alphabet, c)
+		if idx == -1 {
+			return nil, newDecodeError("rfc1924", src, i, "invalid character")
+		}
+		n.Mul(n, big85)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	if n.BitLen() > length*8 {
+		return nil, fmt.Errorf("rfc1924: value overflows %d bytes", length)
+	}
+
+	res := make([]byte, length)
+	b := n.Bytes()
+	copy(res[length-len(b):], b)
+	return res, nil
+}
+
+// returns the number of base85 digits needed for a byteLen number
+func rfc1924Width(byteLen int) int {
+
+	// 85^n is never a power of two, so the estimate only needs checking
+	// for floating point error
+	width := int(math.Ceil(float64(byteLen*8) / math.Log2(85)))
+
+	max := new(big.Int).Lsh(big.NewInt(1), uint(byteLen*8))
+	p := new(big.Int).Exp(big85, big.NewInt(int64(width)), nil)
+	if p.Cmp(max) < 0 {
+		return width + 1
+	}
+	if width > 0 && p.Div(p, big85).Cmp(max) > 0 {
+		return width - 1
+	}
+	return width
+}
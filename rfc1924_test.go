@@ -0,0 +1,45 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRFC1924IPv6(t *testing.T) {
+
+	// 1080:0:0:0:8:800:200C:417A, example from the RFC
+	ip, _ := hex.DecodeString("108000000000000000080800200c417a")
+
+	res, err := encodeRFC1924(ip)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "4)+k&C#VzJ4br>0wv%Yp", string(res))
+
+	res, err = decodeRFC1924([]byte("4)+k&C#VzJ4br>0wv%Yp"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, ip, res)
+}
+
+func TestRFC1924LeadingZeroes(t *testing.T) {
+
+	src := []byte{0, 0, 0, 1}
+	res, err := encodeRFC1924(src)
+	assert.Equal(t, nil, err)
+
+	back, err := decodeRFC1924(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, back)
+}
+
+func TestDecodeRFC1924Invalid(t *testing.T) {
+
+	for _, s := range []string{
+		"012345", // no byte length encodes to 6 digits
+		"4)+k&C#VzJ4br>0wv%Y\"",
+		"~~~~~", // overflows 4 bytes
+	} {
+		_, err := decodeRFC1924([]byte(s))
+		assert.NotEqual(t, nil, err, s)
+	}
+}
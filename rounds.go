@@ -0,0 +1,35 @@
+package gohash
+
+import (
+	"encoding/hex"
+)
+
+// Iterations sets the number of times the hash is applied, each round
+// hashing the digest of the previous one, such as sha256 applied 1000
+// times. With a template, the first round is the template. Default 1
+func (h *Hasher) Iterations(n int) {
+	h.iterations = n
+}
+
+// IterateHex sets wether each round hashes the previous digest as lower
+// case hex instead of raw bytes, as in md5(md5($pass))
+func (h *Hasher) IterateHex(b bool) {
+	h.iterateHex = b
+}
+
+// iterate returns first followed by n-1 rounds of sum
+func iterate(first, sum func(*[]byte) *[]byte, n int, hexRounds bool) func(*[]byte) *[]byte {
+
+	return func(buf *[]byte) *[]byte {
+		res := first(buf)
+		for i := 1; i < n; i++ {
+			if hexRounds {
+				tmp := []byte(hex.EncodeToString(*res))
+				res = sum(&tmp)
+			} else {
+				res = sum(res)
+			}
+		}
+		return res
+	}
+}
@@ -0,0 +1,69 @@
+package gohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherIterations(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("sha256")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.Iterations(1000)
+	hasher.ExpectedHash("fb882108e3147a1ddddb494ffaa023c1b9fdd5c1e68c80314f1373e3ed800534")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+
+	hasher.Iterations(-1)
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "iterations must be at least 1", err.Error())
+}
+
+func TestHasherIterationsRaw(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.Iterations(3)
+	hasher.ExpectedHash("4abe741b1126ee9dd53474044f4d3fff")
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("foo\nhej"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestHasherIterateHex(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.Iterations(2)
+	hasher.IterateHex(true)
+	hasher.ExpectedHash("1b04b5ade586a7c92ee6627acfac4ce7")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestHasherIterationsTemplate(t *testing.T) {
+
+	// md5(md5($pass)) is md5($pass) followed by a hex round
+	hasher := NewHasher()
+	hasher.Template("md5($pass)")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.Iterations(2)
+	hasher.IterateHex(true)
+	hasher.ExpectedHash("1b04b5ade586a7c92ee6627acfac4ce7")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
@@ -0,0 +1,285 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Rule is a word mangling rule, in a subset of the hashcat and john rule
+// syntax, such as "c $1 $2" to capitalize a word and append "12"
+type Rule struct {
+	text string
+	ops  []func([]byte) []byte
+}
+
+// ParseRule parses a rule. Supported functions are : l u c C t TN r d f
+// { } [ ] $X ^X DN iNX oNX 'N sXY @X zN ZN q k K, where positions N are
+// 0-9 and A-Z for 10-35. Spaces between functions are ignored
+func ParseRule(s string) (*Rule, error) {
+
+	rule := &Rule{text: s}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == ' ' || c == '\t' {
+			continue
+		}
+
+		// number of argument bytes, after the function name
+		args := ruleArgs[c]
+		if i+args >= len(s) {
+			return nil, fmt.Errorf("rule: missing argument to %q in %q", c, s)
+		}
+		arg := s[i+1 : i+1+args]
+		i += args
+
+		op, err := ruleOp(c, arg)
+		if err != nil {
+			return nil, fmt.Errorf("rule: %v in %q", err, s)
+		}
+		if op != nil {
+			rule.ops = append(rule.ops, op)
+		}
+	}
+	return rule, nil
+}
+
+// ParseRules parses one rule per line, skipping empty lines and comments
+// starting with '#'
+func ParseRules(r io.Reader) ([]*Rule, error) {
+
+	res := []*Rule{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := ParseRule(line)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rule)
+	}
+	return res, scanner.Err()
+}
+
+// Apply returns word mangled by the rule. word is not modified
+func (r *Rule) Apply(word []byte) []byte {
+
+	res := append([]byte{}, word...)
+	for _, op := range r.ops {
+		res = op(res)
+	}
+	return res
+}
+
+func (r *Rule) String() string {
+	return r.text
+}
+
+var (
+	ruleArgs = map[byte]int{
+		'T': 1, '$': 1, '^': 1, 'D': 1, '\'': 1, '@': 1, 'z': 1, 'Z': 1,
+		'i': 2, 'o': 2, 's': 2,
+	}
+)
+
+// ruleOp returns the function for rule c with argument arg, or nil for
+// the no-op ':'
+func ruleOp(c byte, arg string) (func([]byte) []byte, error) {
+
+	switch c {
+	case ':':
+		return nil, nil
+	case 'l':
+		return func(b []byte) []byte { return setCase(b, false) }, nil
+	case 'u':
+		return func(b []byte) []byte { return setCase(b, true) }, nil
+	case 'c':
+		return func(b []byte) []byte {
+			b = setCase(b, false)
+			if len(b) > 0 {
+				setCase(b[:1], true)
+			}
+			return b
+		}, nil
+	case 'C':
+		return func(b []byte) []byte {
+			b = setCase(b, true)
+			if len(b) > 0 {
+				setCase(b[:1], false)
+			}
+			return b
+		}, nil
+	case 't':
+		return func(b []byte) []byte {
+			for i := range b {
+				b[i] = toggleCase(b[i])
+			}
+			return b
+		}, nil
+	case 'r':
+		return reverse, nil
+	case 'd':
+		return func(b []byte) []byte { return append(b, b...) }, nil
+	case 'f':
+		return func(b []byte) []byte { return append(b, reverse(b)...) }, nil
+	case 'q':
+		return func(b []byte) []byte {
+			res := make([]byte, 0, len(b)*2)
+			for _, x := range b {
+				res = append(res, x, x)
+			}
+			return res
+		}, nil
+	case '{':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = append(b[1:], b[0])
+			}
+			return b
+		}, nil
+	case '}':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = append([]byte{b[len(b)-1]}, b[:len(b)-1]...)
+			}
+			return b
+		}, nil
+	case '[':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = b[1:]
+			}
+			return b
+		}, nil
+	case ']':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = b[:len(b)-1]
+			}
+			return b
+		}, nil
+	case 'k':
+		return func(b []byte) []byte {
+			if len(b) > 1 {
+				b[0], b[1] = b[1], b[0]
+			}
+			return b
+		}, nil
+	case 'K':
+		return func(b []byte) []byte {
+			if n := len(b); n > 1 {
+				b[n-1], b[n-2] = b[n-2], b[n-1]
+			}
+			return b
+		}, nil
+	case '$':
+		return func(b []byte) []byte { return append(b, arg[0]) }, nil
+	case '^':
+		return func(b []byte) []byte { return append([]byte{arg[0]}, b...) }, nil
+	case '@':
+		return func(b []byte) []byte { return bytes.Replace(b, []byte{arg[0]}, nil, -1) }, nil
+	case 's':
+		return func(b []byte) []byte { return bytes.Replace(b, []byte{arg[0]}, []byte{arg[1]}, -1) }, nil
+	}
+
+	if len(arg) == 0 {
+		return nil, fmt.Errorf("unknown function %q", c)
+	}
+	n, err := rulePosition(arg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch c {
+	case 'T':
+		return func(b []byte) []byte {
+			if n < len(b) {
+				b[n] = toggleCase(b[n])
+			}
+			return b
+		}, nil
+	case 'D':
+		return func(b []byte) []byte {
+			if n < len(b) {
+				b = append(b[:n], b[n+1:]...)
+			}
+			return b
+		}, nil
+	case '\'':
+		return func(b []byte) []byte {
+			if n < len(b) {
+				b = b[:n]
+			}
+			return b
+		}, nil
+	case 'z':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = append(bytes.Repeat(b[:1], n), b...)
+			}
+			return b
+		}, nil
+	case 'Z':
+		return func(b []byte) []byte {
+			if len(b) > 0 {
+				b = append(b, bytes.Repeat(b[len(b)-1:], n)...)
+			}
+			return b
+		}, nil
+	case 'i':
+		return func(b []byte) []byte {
+			if n <= len(b) {
+				b = append(b[:n], append([]byte{arg[1]}, b[n:]...)...)
+			}
+			return b
+		}, nil
+	case 'o':
+		return func(b []byte) []byte {
+			if n < len(b) {
+				b[n] = arg[1]
+			}
+			return b
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown function %q", c)
+}
+
+// rulePosition decodes a position, 0-9 and A-Z for 10-35
+func rulePosition(c byte) (int, error) {
+
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), nil
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10, nil
+	}
+	return 0, fmt.Errorf("invalid position %q", c)
+}
+
+// setCase changes the case of the ASCII letters in b, leaving other bytes
+// as is
+func setCase(b []byte, upper bool) []byte {
+
+	for i, c := range b {
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			if upper {
+				b[i] = c &^ 0x20
+			} else {
+				b[i] = c | 0x20
+			}
+		}
+	}
+	return b
+}
+
+func toggleCase(c byte) byte {
+
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return c ^ 0x20
+	}
+	return c
+}
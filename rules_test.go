@@ -0,0 +1,120 @@
+package gohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleApply(t *testing.T) {
+
+	for rule, expected := range map[string]string{
+		":":           "pAssword",
+		"l":           "password",
+		"u":           "PASSWORD",
+		"c":           "Password",
+		"C":           "pASSWORD",
+		"t":           "PaSSWORD",
+		"T0 T1":       "Password",
+		"r":           "drowssAp",
+		"d":           "pAsswordpAssword",
+		"f":           "pAssworddrowssAp",
+		"{":           "Asswordp",
+		"}":           "dpAsswor",
+		"[":           "Assword",
+		"]":           "pAsswor",
+		"$1$2":        "pAssword12",
+		"^2^1":        "12pAssword",
+		"D1":          "pssword",
+		"i1!":         "p!Assword",
+		"o0P":         "PAssword",
+		"'4":          "pAss",
+		"ss$ so0":     "pA$$w0rd",
+		"@s":          "pAword",
+		"z2":          "pppAssword",
+		"Z2":          "pAssworddd",
+		"q":           "ppAAsssswwoorrdd",
+		"k":           "Apssword",
+		"K":           "pAsswodr",
+		"TZ":          "pAssword",
+		"c $1 sa4 $!": "P4ssword1!",
+	} {
+		r, err := ParseRule(rule)
+		assert.Equal(t, nil, err, rule)
+		assert.Equal(t, expected, string(r.Apply([]byte("pAssword"))), rule)
+	}
+}
+
+func TestRuleApplyKeepsWord(t *testing.T) {
+
+	word := []byte("abc")
+	r, err := ParseRule("u $d r")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "dCBA", string(r.Apply(word)))
+	assert.Equal(t, "abc", string(word))
+}
+
+func TestParseRuleErrors(t *testing.T) {
+
+	for _, rule := range []string{"$", "s1", "T", "Tx", "D!", "X", "i1"} {
+		_, err := ParseRule(rule)
+		assert.NotEqual(t, nil, err, rule)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+
+	rules, err := ParseRules(strings.NewReader("# comment\n:\n\nc $1\r\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, "c $1", rules[1].String())
+}
+
+func TestFindFromWordlistRules(t *testing.T) {
+
+	rules, err := ParseRules(strings.NewReader(":\nc\nc se3 $1 $2"))
+	assert.Equal(t, nil, err)
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("a835ac7fa99d0c72da68b678e2fc9cdc")
+	hasher.Rules(rules)
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("foo\nhej\nbar"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "H3j12", res)
+}
+
+func TestFindFromWordlistEmptyRules(t *testing.T) {
+
+	rules, err := ParseRules(strings.NewReader("# no rules\n"))
+	assert.Equal(t, nil, err)
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("541c57960bb997942655d14e3b9607f9")
+	hasher.Rules(rules)
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("foo\nhej\nbar"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+}
+
+func TestFindFromWordlistRulesMaxAttempts(t *testing.T) {
+
+	rules, err := ParseRules(strings.NewReader(":\nc\nu\nr"))
+	assert.Equal(t, nil, err)
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("00000000000000000000000000000000")
+	hasher.Rules(rules)
+	hasher.MaxAttempts(2048)
+
+	// 1000 words of 4 rules each are 4000 candidates
+	words := strings.Repeat("word\n", 1000)
+	_, err = hasher.FindFromWordlist(strings.NewReader(words))
+	_, ok := err.(*LimitError)
+	assert.Equal(t, true, ok)
+}
@@ -0,0 +1,73 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	schemeMutex sync.Mutex
+
+	// openers of the url schemes added by RegisterScheme
+	schemes = map[string]func(url string) (io.ReadCloser, error){}
+)
+
+// RegisterScheme makes ReadInputs open urls of scheme, such as "s3" for
+// s3://bucket/object, with open. The s3 and gs schemes are registered
+// when built with the s3 and gcs build tags
+func RegisterScheme(scheme string, open func(url string) (io.ReadCloser, error)) {
+
+	schemeMutex.Lock()
+	schemes[strings.ToLower(scheme)] = open
+	schemeMutex.Unlock()
+}
+
+// Schemes returns the url schemes added by RegisterScheme
+func Schemes() []string {
+
+	schemeMutex.Lock()
+	defer schemeMutex.Unlock()
+	res := []string{}
+	for scheme := range schemes {
+		res = append(res, scheme)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SchemeInput returns the Input of url, if its scheme was added by
+// RegisterScheme
+func SchemeInput(url string) (Input, bool) {
+
+	i := strings.Index(url, "://")
+	if i < 1 {
+		return Input{}, false
+	}
+	schemeMutex.Lock()
+	open, ok := schemes[strings.ToLower(url[:i])]
+	schemeMutex.Unlock()
+	if !ok {
+		return Input{}, false
+	}
+	return Input{Name: url, Open: func() (io.ReadCloser, error) {
+		return open(url)
+	}}, true
+}
+
+// splitObjectURL returns the bucket and object of a scheme://bucket/object
+// url
+func splitObjectURL(url string, scheme string) (string, string, error) {
+
+	prefix := scheme + "://"
+	if !strings.HasPrefix(strings.ToLower(url), prefix) {
+		return "", "", fmt.Errorf("%s should be %sbucket/object", url, prefix)
+	}
+	parts := strings.SplitN(url[len(prefix):], "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%s should be %sbucket/object", url, prefix)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,51 @@
+//go:build gcs
+// +build gcs
+
+package gohash
+
+import (
+	"context"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+func init() {
+	RegisterScheme("gs", openGCS)
+}
+
+// openGCS streams a gs://bucket/object url, with the application default
+// credentials
+func openGCS(url string) (io.ReadCloser, error) {
+
+	bucket, object, err := splitObjectURL(url, "gs")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := client.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return gcsReader{r, client}, nil
+}
+
+// gcsReader closes the client along with the object reader
+type gcsReader struct {
+	*storage.Reader
+	client *storage.Client
+}
+
+func (r gcsReader) Close() error {
+
+	err := r.Reader.Close()
+	if cerr := r.client.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
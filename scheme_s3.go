@@ -0,0 +1,40 @@
+//go:build s3
+// +build s3
+
+package gohash
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func init() {
+	RegisterScheme("s3", openS3)
+}
+
+// openS3 streams a s3://bucket/object url, with the credentials and region
+// of the default aws config chain
+func openS3(url string) (io.ReadCloser, error) {
+
+	bucket, key, err := splitObjectURL(url, "s3")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s3.NewFromConfig(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
@@ -0,0 +1,50 @@
+package gohash
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterScheme(t *testing.T) {
+
+	RegisterScheme("Mem", func(url string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(url)), nil
+	})
+	defer func() {
+		schemeMutex.Lock()
+		delete(schemes, "mem")
+		schemeMutex.Unlock()
+	}()
+	assert.Contains(t, Schemes(), "mem")
+
+	_, ok := SchemeInput("nope://bucket/fox")
+	assert.Equal(t, false, ok)
+	_, ok = SchemeInput("fox.txt")
+	assert.Equal(t, false, ok)
+
+	inputs, err := ReadInputs([]string{"mem://bucket/fox"})
+	assert.Equal(t, nil, err)
+	res := HashInputs(inputs, "md5", "hex", 1)
+	assert.Equal(t, nil, res[0].Err)
+	assert.Equal(t, "mem://bucket/fox", res[0].Result.Name)
+	assert.Equal(t, *NewCalculator([]byte("mem://bucket/fox")).Sum("md5"), res[0].Result.Digest)
+}
+
+func TestSplitObjectURL(t *testing.T) {
+
+	bucket, object, err := splitObjectURL("s3://backups/2020/disk.img", "s3")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "backups", bucket)
+	assert.Equal(t, "2020/disk.img", object)
+
+	_, _, err = splitObjectURL("s3://backups", "s3")
+	assert.Equal(t, "s3://backups should be s3://bucket/object", err.Error())
+	_, _, err = splitObjectURL("gs://backups/", "gs")
+	assert.Equal(t, "gs://backups/ should be gs://bucket/object", err.Error())
+	_, _, err = splitObjectURL("gs://backups/disk.img", "s3")
+	assert.Equal(t, "gs://backups/disk.img should be s3://bucket/object", err.Error())
+}
@@ -0,0 +1,65 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SearchResult describes the match found by a search, see LastResult
+type SearchResult struct {
+	Key  string
+	Hash string
+	Algo string
+
+	// tries of the whole search, and the time until the match was found
+	Tries   uint64
+	Elapsed time.Duration
+
+	// length of the key, without prefix and suffix
+	Length int
+
+	// the worker that found the match, counting from 0
+	Worker int
+}
+
+// Rate returns the average number of tries per second
+func (r SearchResult) Rate() float64 {
+
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Tries) / r.Elapsed.Seconds()
+}
+
+func (r SearchResult) String() string {
+	return fmt.Sprintf("%s %s of length %d, %d tries in %s (~%.0f/s) by worker %d",
+		r.Algo, r.Key, r.Length, r.Tries, r.Elapsed.Round(time.Millisecond), r.Rate(), r.Worker)
+}
+
+// LastResult returns the match completing the last search, or nil if it
+// found none. The Find methods return only the key, for compatibility
+func (h *Hasher) LastResult() *SearchResult {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if h.result == nil {
+		return nil
+	}
+	res := *h.result
+	return &res
+}
+
+// newSearchResult returns the result of a match. The mutex must be held
+func (h *Hasher) newSearchResult(buf []byte, digest []byte, worker int) *SearchResult {
+
+	return &SearchResult{
+		Key:     string(buf),
+		Hash:    hex.EncodeToString(digest),
+		Algo:    h.digestAlgo(digest),
+		Tries:   h.try,
+		Elapsed: time.Since(h.started),
+		Length:  len(buf) - len(h.prefix) - len(h.suffix),
+		Worker:  worker,
+	}
+}
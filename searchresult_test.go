@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherLastResult(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Hej)
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Prefix("h")
+	hasher.Length(2)
+	hasher.Workers(4)
+	assert.Equal(t, (*SearchResult)(nil), hasher.LastResult())
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+
+	result := hasher.LastResult()
+	if assert.NotEqual(t, (*SearchResult)(nil), result) {
+		assert.Equal(t, "hej", result.Key)
+		assert.Equal(t, md5Hej, result.Hash)
+		assert.Equal(t, "md5", result.Algo)
+		assert.Equal(t, 2, result.Length)
+		assert.Equal(t, true, result.Worker >= 0 && result.Worker < 4)
+		assert.Equal(t, true, result.Tries > 0 && result.Tries <= 26*26)
+		assert.Equal(t, true, result.Elapsed > 0)
+	}
+
+	// none after a search without a match
+	hasher.ExpectedHash(md5Zero)
+	_, err = hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+	assert.Equal(t, (*SearchResult)(nil), hasher.LastResult())
+}
+
+func TestHasherLastResultWorkerLengths(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash("25ed1bcb423b0b7200f485fc5ff71c8e") // zz
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+	hasher.Workers(2)
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "zz", res)
+
+	// z is the 26th prefix, searched by the second worker
+	result := hasher.LastResult()
+	if assert.NotEqual(t, (*SearchResult)(nil), result) {
+		assert.Equal(t, 1, result.Worker)
+	}
+}
+
+func TestSearchResultString(t *testing.T) {
+
+	result := SearchResult{
+		Key:     "hej",
+		Algo:    "md5",
+		Tries:   3000,
+		Elapsed: 2 * time.Second,
+		Length:  3,
+		Worker:  1,
+	}
+	assert.Equal(t, 1500.0, result.Rate())
+	assert.Equal(t, "md5 hej of length 3, 3000 tries in 2s (~1500/s) by worker 1", result.String())
+	assert.Equal(t, 0.0, SearchResult{}.Rate())
+}
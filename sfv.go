@@ -0,0 +1,91 @@
+package gohash
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// FormatSFV renders a crc32 digest as a line of a sfv file, "<file> <CRC>"
+func FormatSFV(fileName string, digest []byte) string {
+	return fileName + " " + strings.ToUpper(hex.EncodeToString(digest))
+}
+
+// ParseSFV parses a line of a sfv file, returning file name and crc32
+// digest. Dos path separators are replaced with the ones of the os
+func ParseSFV(line string) (string, []byte, error) {
+
+	line = strings.TrimRight(line, " \t\r\n")
+	sep := strings.LastIndexAny(line, " \t")
+	if sep < 1 {
+		return "", nil, fmt.Errorf("sfv: malformed line %q", line)
+	}
+	digest, err := hex.DecodeString(line[sep+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("sfv: bad digest: %v", err)
+	}
+	if len(digest) != 4 {
+		return "", nil, fmt.Errorf("sfv: crc should be 32 bit, is %d", len(digest)*8)
+	}
+	name := strings.TrimRight(line[:sep], " \t")
+	if name == "" {
+		return "", nil, fmt.Errorf("sfv: malformed line %q", line)
+	}
+	return filepath.FromSlash(strings.Replace(name, "\\", "/", -1)), digest, nil
+}
+
+// WriteSFV writes a sfv file of the crc32 results, after a comment line
+func WriteSFV(w io.Writer, results []Result) error {
+
+	if _, err := io.WriteString(w, "; Generated by gohash\n"); err != nil {
+		return err
+	}
+	for _, res := range results {
+		if resolveAlgoAliases(res.Algo) != "crc32-ieee" {
+			return fmt.Errorf("sfv: %s is %s, not crc32", res.Name, res.Algo)
+		}
+		if _, err := io.WriteString(w, FormatSFV(res.Name, res.Digest)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSFV reads the files and crc32 digests of a sfv file. Returns the
+// entries and the number of malformed lines, empty lines and ; comments
+// are skipped
+func ReadSFV(r io.Reader) ([]ManifestEntry, int, error) {
+
+	res := []ManifestEntry{}
+	malformed := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		name, digest, err := ParseSFV(line)
+		if err != nil {
+			malformed++
+			continue
+		}
+		res = append(res, ManifestEntry{Name: name, Algo: "crc32-ieee", Digest: digest, Binary: true})
+	}
+	return res, malformed, scanner.Err()
+}
+
+// CheckSFV hashes each file of a sfv file, and reports which match their
+// crc32. Relative paths are from the current directory
+func CheckSFV(r io.Reader) (*CheckReport, error) {
+
+	entries, malformed, err := ReadSFV(r)
+	if err != nil {
+		return nil, err
+	}
+	report := CheckEntries(entries)
+	report.Malformed = malformed
+	return report, nil
+}
@@ -0,0 +1,77 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSFV(t *testing.T) {
+
+	digest := *NewCalculator([]byte(fox)).Sum("crc32")
+	assert.Equal(t, "fox.txt 414FA339", FormatSFV("fox.txt", digest))
+}
+
+func TestParseSFV(t *testing.T) {
+
+	crc, _ := hex.DecodeString("414fa339")
+	tests := []struct {
+		line, name string
+	}{
+		{"fox.txt 414FA339", "fox.txt"},
+		{"with space.txt\t414fa339\r\n", "with space.txt"},
+		{"cd1\\fox.txt   414FA339", filepath.Join("cd1", "fox.txt")},
+	}
+	for _, test := range tests {
+		name, digest, err := ParseSFV(test.line)
+		assert.Equal(t, nil, err, test.line)
+		assert.Equal(t, test.name, name)
+		assert.Equal(t, crc, digest)
+	}
+
+	_, _, err := ParseSFV("414FA339")
+	assert.Equal(t, `sfv: malformed line "414FA339"`, err.Error())
+	_, _, err = ParseSFV("fox.txt 414FA3")
+	assert.Equal(t, "sfv: crc should be 32 bit, is 24", err.Error())
+	_, _, err = ParseSFV("fox.txt 414FA3XX")
+	assert.Equal(t, "sfv: bad digest: encoding/hex: invalid byte: U+0058 'X'", err.Error())
+}
+
+func TestWriteSFV(t *testing.T) {
+
+	results := []Result{
+		{Algo: "crc32-ieee", Name: "a.txt", Digest: *NewCalculator([]byte("a")).Sum("crc32")},
+	}
+	var buf bytes.Buffer
+	assert.Equal(t, nil, WriteSFV(&buf, results))
+	assert.Equal(t, "; Generated by gohash\na.txt E8B7BE43\n", buf.String())
+
+	err := WriteSFV(&buf, []Result{{Algo: "md5", Name: "a.txt"}})
+	assert.Equal(t, "sfv: a.txt is md5, not crc32", err.Error())
+}
+
+func TestCheckSFV(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.txt": "a", "sub/b.txt": "changed"})
+
+	sfv := "; a comment\r\n\r\n" +
+		FormatSFV(filepath.Join(dir, "a.txt"), *NewCalculator([]byte("a")).Sum("crc32")) + "\r\n" +
+		FormatSFV(strings.Replace(filepath.Join(dir, "sub", "b.txt"), string(os.PathSeparator), "\\", -1), *NewCalculator([]byte("b")).Sum("crc32")) + "\r\n" +
+		FormatSFV(filepath.Join(dir, "c.txt"), *NewCalculator([]byte("c")).Sum("crc32")) + "\r\n" +
+		"garbage\r\n"
+
+	report, err := CheckSFV(strings.NewReader(sfv))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, report.OK)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Missing)
+	assert.Equal(t, 1, report.Malformed)
+	assert.Equal(t, filepath.Join(dir, "sub", "b.txt"), report.Results[1].Name)
+	assert.Equal(t, CheckFailed, report.Results[1].Status)
+}
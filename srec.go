@@ -0,0 +1,98 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// encodeSRecord encodes src as Motorola S-records starting at address 0.
+// The address width, S1, S2 or S3, is picked from the size of src
+func encodeSRecord(src []byte) ([]byte, error) {
+
+	dataType, endType, addrLen := byte('1'), byte('9'), 2
+	if len(src) > 0x10000 {
+		dataType, endType, addrLen = '2', '8', 3
+	}
+	if len(src) > 0x1000000 {
+		dataType, endType, addrLen = '3', '7', 4
+	}
+
+	lines := []string{srecRecord('0', 0, 2, nil)}
+	for pos := 0; pos < len(src); pos += recordDataLen {
+		end := pos + recordDataLen
+		if end > len(src) {
+			end = len(src)
+		}
+		lines = append(lines, srecRecord(dataType, uint32(pos), addrLen, src[pos:end]))
+	}
+	lines = append(lines, srecRecord(endType, 0, addrLen, nil))
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// decodeSRecord decodes Motorola S-records, verifying their checksums.
+// The result starts at the lowest address, with gaps filled with 0xff
+func decodeSRecord(src []byte) ([]byte, error) {
+
+	img := newMemoryImage()
+	for n, line := range strings.Fields(string(src)) {
+		if len(line) < 2 || (line[0] != 'S' && line[0] != 's') {
+			return nil, fmt.Errorf("srec: record %d does not start with 'S'", n+1)
+		}
+		b, err := hex.DecodeString(line[2:])
+		if err != nil {
+			return nil, fmt.Errorf("srec: record %d: %v", n+1, err)
+		}
+		if len(b) < 3 || len(b) != int(b[0])+1 {
+			return nil, fmt.Errorf("srec: record %d has invalid length", n+1)
+		}
+		sum := byte(0)
+		for _, c := range b {
+			sum += c
+		}
+		if sum != 0xff {
+			return nil, fmt.Errorf("srec: record %d has invalid checksum", n+1)
+		}
+
+		addrLen := 0
+		switch line[1] {
+		case '1':
+			addrLen = 2
+		case '2':
+			addrLen = 3
+		case '3':
+			addrLen = 4
+		case '0', '5', '6', '7', '8', '9':
+			// header, count and start address records
+			continue
+		default:
+			return nil, fmt.Errorf("srec: record %d has unknown type S%c", n+1, line[1])
+		}
+		if len(b) < addrLen+2 {
+			return nil, fmt.Errorf("srec: record %d has invalid length", n+1)
+		}
+		addr := 0
+		for _, c := range b[1 : 1+addrLen] {
+			addr = addr<<8 | int(c)
+		}
+		if err := img.write(addr, b[1+addrLen:len(b)-1]); err != nil {
+			return nil, fmt.Errorf("srec: record %d: %v", n+1, err)
+		}
+	}
+	return img.bytes(), nil
+}
+
+func srecRecord(typ byte, addr uint32, addrLen int, data []byte) string {
+
+	b := []byte{byte(addrLen + len(data) + 1)}
+	for i := addrLen - 1; i >= 0; i-- {
+		b = append(b, byte(addr>>(uint(i)*8)))
+	}
+	b = append(b, data...)
+	sum := byte(0)
+	for _, c := range b {
+		sum += c
+	}
+	b = append(b, ^sum)
+	return "S" + string(typ) + strings.ToUpper(hex.EncodeToString(b))
+}
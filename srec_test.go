@@ -0,0 +1,44 @@
+package gohash
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSRecord(t *testing.T) {
+
+	res, err := encodeSRecord([]byte("hi"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "S0030000FC\nS1050000686929\nS9030000FC", string(res))
+
+	// S3 record with 32-bit address
+	dec, err := decodeSRecord([]byte("S30700001000686917\nS70500000000FA"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("hi"), dec)
+}
+
+func TestSRecordLarge(t *testing.T) {
+
+	src := bytes.Repeat([]byte{0xa5}, 0x10001)
+	res, err := encodeSRecord(src)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "S2", string(res[11:13]))
+
+	dec, err := decodeSRecord(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, dec)
+}
+
+func TestSRecordInvalid(t *testing.T) {
+
+	_, err := decodeSRecord([]byte("S1050000686928"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeSRecord([]byte("S10500006869"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeSRecord([]byte("X1050000686929"))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,105 @@
+package gohash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+var (
+	// sriAlgos maps SRI hash names to algo id's, in order of strength
+	sriAlgos = []struct {
+		name string
+		algo string
+	}{
+		{"sha256", "sha256"},
+		{"sha384", "sha384"},
+		{"sha512", "sha512"},
+	}
+)
+
+// SRI returns a Subresource Integrity value for the given algos,
+// such as "sha384-<base64>". Defaults to sha384
+func (c *Calculator) SRI(algos ...string) (string, error) {
+
+	if len(algos) == 0 {
+		algos = []string{"sha384"}
+	}
+
+	res := []string{}
+	for _, algo := range algos {
+		algo = resolveAlgoAliases(strings.ToLower(algo))
+		if sriStrength(algo) < 0 {
+			return "", fmt.Errorf("sri: unsupported algo %s", algo)
+		}
+		sum := c.Sum(algo)
+		res = append(res, algo+"-"+base64.StdEncoding.EncodeToString(*sum))
+	}
+	return strings.Join(res, " "), nil
+}
+
+// VerifySRI checks data against a Subresource Integrity value. As in the
+// spec, only the strongest algorithm present is considered, and any of
+// its digests may match
+func VerifySRI(data []byte, integrity string) (bool, error) {
+
+	type sriToken struct {
+		algo   string
+		digest []byte
+	}
+
+	tokens := []sriToken{}
+	strongest := -1
+
+	for _, field := range strings.Fields(integrity) {
+		// drop any options
+		if pos := strings.Index(field, "?"); pos != -1 {
+			field = field[0:pos]
+		}
+		parts := strings.SplitN(field, "-", 2)
+		if len(parts) != 2 {
+			return false, fmt.Errorf("sri: malformed value %s", field)
+		}
+		algo := strings.ToLower(parts[0])
+		strength := sriStrength(algo)
+		if strength < 0 {
+			// unknown algorithms are ignored per spec
+			continue
+		}
+		digest, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return false, fmt.Errorf("sri: bad base64 in %s: %v", field, err)
+		}
+		tokens = append(tokens, sriToken{algo, digest})
+		if strength > strongest {
+			strongest = strength
+		}
+	}
+
+	if len(tokens) == 0 {
+		return false, fmt.Errorf("sri: no supported hashes in %q", integrity)
+	}
+
+	calc := NewCalculator(data)
+	for _, token := range tokens {
+		if sriStrength(token.algo) != strongest {
+			continue
+		}
+		if subtle.ConstantTimeCompare(*calc.Sum(token.algo), token.digest) == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// returns -1 for algos not allowed in SRI
+func sriStrength(algo string) int {
+
+	for i, a := range sriAlgos {
+		if a.name == algo {
+			return i
+		}
+	}
+	return -1
+}
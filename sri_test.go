@@ -0,0 +1,48 @@
+package gohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	sriScript = []byte("alert('Hello, world.');")
+)
+
+func TestCalculatorSRI(t *testing.T) {
+
+	calc := NewCalculator(sriScript)
+
+	res, err := calc.SRI()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "sha384-H8BRh8j48O9oYatfu5AZzq6A9RINhZO5H16dQZngK7T62em8MUt1FLm52t+eX6xO", res)
+
+	res, err = calc.SRI("sha256", "sha512")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(strings.Fields(res)))
+
+	_, err = calc.SRI("md5")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestVerifySRI(t *testing.T) {
+
+	ok, err := VerifySRI(sriScript, "sha384-H8BRh8j48O9oYatfu5AZzq6A9RINhZO5H16dQZngK7T62em8MUt1FLm52t+eX6xO")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	// only the strongest algo counts, so the bogus sha512 makes it fail
+	ok, err = VerifySRI(sriScript, "sha384-H8BRh8j48O9oYatfu5AZzq6A9RINhZO5H16dQZngK7T62em8MUt1FLm52t+eX6xO sha512-AAAA")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, ok)
+
+	// options and unknown algos are ignored
+	ok, err = VerifySRI(sriScript, "md5-xxxx sha384-H8BRh8j48O9oYatfu5AZzq6A9RINhZO5H16dQZngK7T62em8MUt1FLm52t+eX6xO?foo")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+
+	_, err = VerifySRI(sriScript, "md5-xxxx")
+	assert.NotEqual(t, nil, err)
+}
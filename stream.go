@@ -0,0 +1,158 @@
+package gohash
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"sort"
+	"unicode"
+)
+
+var (
+	streamEncoders = map[string]func(io.Writer) io.WriteCloser{
+		"ascii85": ascii85.NewEncoder,
+		"base32": func(w io.Writer) io.WriteCloser {
+			return base32.NewEncoder(base32.StdEncoding, w)
+		},
+		"base32hex": func(w io.Writer) io.WriteCloser {
+			return base32.NewEncoder(base32.HexEncoding, w)
+		},
+		"base64": func(w io.Writer) io.WriteCloser {
+			return base64.NewEncoder(base64.StdEncoding, w)
+		},
+		"hex": func(w io.Writer) io.WriteCloser {
+			return nopWriteCloser{hex.NewEncoder(w)}
+		},
+		"hexup": func(w io.Writer) io.WriteCloser {
+			return nopWriteCloser{hex.NewEncoder(upperWriter{w})}
+		},
+		"quotedprintable": func(w io.Writer) io.WriteCloser {
+			qp := quotedprintable.NewWriter(w)
+			qp.Binary = true
+			return qp
+		},
+		"zbase32": func(w io.Writer) io.WriteCloser {
+			return base32.NewEncoder(zbase32Encoding, w)
+		},
+	}
+
+	streamDecoders = map[string]func(io.Reader) io.Reader{
+		"ascii85": ascii85.NewDecoder,
+		"base32": func(r io.Reader) io.Reader {
+			return base32.NewDecoder(base32.StdEncoding, r)
+		},
+		"base32hex": func(r io.Reader) io.Reader {
+			return base32.NewDecoder(base32.HexEncoding, r)
+		},
+		"base64": func(r io.Reader) io.Reader {
+			return base64.NewDecoder(base64.StdEncoding, r)
+		},
+		"hex": func(r io.Reader) io.Reader {
+			return hex.NewDecoder(spaceSkipper{r})
+		},
+		"hexup": func(r io.Reader) io.Reader {
+			return hex.NewDecoder(spaceSkipper{r})
+		},
+		"quotedprintable": func(r io.Reader) io.Reader {
+			return quotedprintable.NewReader(r)
+		},
+		"zbase32": func(r io.Reader) io.Reader {
+			return base32.NewDecoder(zbase32Encoding, r)
+		},
+	}
+)
+
+// NewEncoder returns a stream encoder writing to w. Close must be called
+// to flush any partially written blocks
+func (c *Coder) NewEncoder(w io.Writer) (io.WriteCloser, error) {
+
+	if err := c.checkStreaming(); err != nil {
+		return nil, err
+	}
+	return streamEncoders[c.encoding](w), nil
+}
+
+// NewDecoder returns a stream decoder reading from r
+func (c *Coder) NewDecoder(r io.Reader) (io.Reader, error) {
+
+	if err := c.checkStreaming(); err != nil {
+		return nil, err
+	}
+	return streamDecoders[c.encoding](r), nil
+}
+
+// StreamingEncodings returns the encoding id's supporting NewEncoder and
+// NewDecoder
+func StreamingEncodings() []string {
+
+	res := []string{}
+	for key := range streamEncoders {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// CanStream returns wether NewEncoder and NewDecoder are supported with
+// the current settings
+func (c *Coder) CanStream() bool {
+	return c.checkStreaming() == nil
+}
+
+func (c *Coder) checkStreaming() error {
+
+	if _, ok := streamEncoders[c.encoding]; !ok || c.alphabet != nil {
+		return fmt.Errorf("encoding %s does not support streaming", c.encoding)
+	}
+	if c.wrap > 0 {
+		return fmt.Errorf("streaming does not support line wrapping")
+	}
+	if c.encoding == "ascii85" && (c.adobe || c.btoa) {
+		return fmt.Errorf("ascii85 streaming does not support adobe framing or btoa")
+	}
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// upperWriter upper cases ascii written to it
+type upperWriter struct {
+	w io.Writer
+}
+
+func (u upperWriter) Write(p []byte) (int, error) {
+	return u.w.Write(bytes.ToUpper(p))
+}
+
+// spaceSkipper drops white space from the underlying reader
+type spaceSkipper struct {
+	r io.Reader
+}
+
+func (s spaceSkipper) Read(p []byte) (int, error) {
+
+	for {
+		n, err := s.r.Read(p)
+		j := 0
+		for _, b := range p[:n] {
+			if !unicode.IsSpace(rune(b)) {
+				p[j] = b
+				j++
+			}
+		}
+		if j > 0 || err != nil {
+			return j, err
+		}
+	}
+}
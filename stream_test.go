@@ -0,0 +1,65 @@
+package gohash
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamMatchesEncode(t *testing.T) {
+
+	src := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog\x00\xff"), 1000)
+
+	for _, encoding := range StreamingEncodings() {
+		coder := NewCoder(encoding)
+		expected, err := coder.Encode(src)
+		assert.Equal(t, nil, err, encoding)
+
+		var buf bytes.Buffer
+		w, err := coder.NewEncoder(&buf)
+		assert.Equal(t, nil, err, encoding)
+
+		// odd sized writes, to cross block boundaries
+		for pos := 0; pos < len(src); pos += 7 {
+			end := pos + 7
+			if end > len(src) {
+				end = len(src)
+			}
+			_, err = w.Write(src[pos:end])
+			assert.Equal(t, nil, err, encoding)
+		}
+		assert.Equal(t, nil, w.Close(), encoding)
+		assert.Equal(t, string(expected), buf.String(), encoding)
+
+		r, err := coder.NewDecoder(&buf)
+		assert.Equal(t, nil, err, encoding)
+		res, err := ioutil.ReadAll(r)
+		assert.Equal(t, nil, err, encoding)
+		assert.Equal(t, src, res, encoding)
+	}
+}
+
+func TestStreamHexSkipsSpaces(t *testing.T) {
+
+	r, err := NewCoder("hex").NewDecoder(strings.NewReader("de ad\nbe ef\n"))
+	assert.Equal(t, nil, err)
+	res, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, res)
+}
+
+func TestStreamUnsupported(t *testing.T) {
+
+	_, err := NewCoder("base58").NewEncoder(&bytes.Buffer{})
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, false, NewCoder("base58").CanStream())
+	assert.Equal(t, true, NewCoder("base64").CanStream())
+
+	coder := NewCoder("ascii85")
+	coder.AdobeFraming(true)
+	_, err = coder.NewDecoder(strings.NewReader(""))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,186 @@
+package gohash
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ExpectedHashes sets several expected hashes, in hex or base64 as with
+// ExpectedHash. The search goes on until all of them are found, reporting
+// each match to OnMatch
+func (h *Hasher) ExpectedHashes(hashes []string) error {
+
+	targets := make(map[string]bool, len(hashes))
+	for _, s := range hashes {
+		b, err := decodeDigest(s)
+		if err != nil {
+			return err
+		}
+		targets[string(b)] = true
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no hashes")
+	}
+
+	h.expected, h.expectedErr = nil, nil
+	h.expectedFormat = ""
+	h.targets = nil
+	if len(targets) == 1 {
+		for digest := range targets {
+			h.expected = []byte(digest)
+		}
+	} else {
+		h.targets = targets
+	}
+	return nil
+}
+
+// LoadExpectedHashes reads expected hashes from r, one hex hash per line
+func (h *Hasher) LoadExpectedHashes(r io.Reader) error {
+
+	hashes, err := readHashes(r)
+	if err != nil {
+		return err
+	}
+	return h.ExpectedHashes(hashes)
+}
+
+// readHashes returns the non-empty lines of r
+func readHashes(r io.Reader) ([]string, error) {
+
+	hashes := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			hashes = append(hashes, line)
+		}
+	}
+	return hashes, scanner.Err()
+}
+
+// OnMatch sets a func called with each key found and its hash, in hex.
+// It may be called by several workers at once
+func (h *Hasher) OnMatch(fn func(key, hash string)) {
+	h.onMatch = fn
+}
+
+// Found returns the keys found by the last search, by their hash in hex
+func (h *Hasher) Found() map[string]string {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	res := make(map[string]string, len(h.found))
+	for digest, key := range h.found {
+		res[hex.EncodeToString([]byte(digest))] = key
+	}
+	return res
+}
+
+// FindAll searches the whole keyspace like FindSequential, returning every
+// key matching the expected hashes or digest pattern, in sorted order.
+// Useful for short checksums such as crc32, where collisions are plenty.
+// When a limit is reached, the matches so far are returned with the error
+func (h *Hasher) FindAll() ([]string, error) {
+	return h.FindAllContext(context.Background())
+}
+
+// FindAllContext is like FindAll, but stops with the error of ctx when it
+// is done
+func (h *Hasher) FindAllContext(ctx context.Context) ([]string, error) {
+
+	if err := h.verify(); err != nil {
+		return nil, err
+	}
+
+	mutex.Lock()
+	h.findAll = true
+	h.all = nil
+	mutex.Unlock()
+
+	// never stops at a match, so the error is only of interest when
+	// ctx is done or a limit is reached
+	_, err := h.FindSequentialContext(ctx)
+
+	mutex.Lock()
+	h.findAll = false
+	res := h.all
+	mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(res)
+	if limitErr, ok := err.(*LimitError); ok {
+		// the matches found before the limit
+		return res, limitErr
+	}
+	return res, nil
+}
+
+// matched records that buf, tried by worker, has the digest of a target,
+// and returns true when the search is complete
+func (h *Hasher) matched(buf []byte, digest []byte, worker int) bool {
+
+	mutex.Lock()
+	if h.findAll {
+		h.all = append(h.all, string(buf))
+		if _, ok := h.found[string(digest)]; !ok {
+			h.found[string(digest)] = string(buf)
+		}
+		mutex.Unlock()
+		h.reportMatch(string(buf), digest)
+		return false
+	}
+	if _, ok := h.found[string(digest)]; ok {
+		// another key for an already found hash
+		mutex.Unlock()
+		return false
+	}
+	h.found[string(digest)] = string(buf)
+	done := len(h.found) >= h.targetCount()
+	if done {
+		h.result = h.newSearchResult(buf, digest, worker)
+	}
+	mutex.Unlock()
+
+	h.reportMatch(string(buf), digest)
+	return done
+}
+
+// reportMatch passes a match to OnMatch and the Results of Start
+func (h *Hasher) reportMatch(key string, digest []byte) {
+
+	hash := hex.EncodeToString(digest)
+	if h.onMatch != nil {
+		h.onMatch(key, hash)
+	}
+	h.sendMatch(Match{Key: key, Hash: hash})
+}
+
+// targetCount returns the number of expected hashes
+func (h *Hasher) targetCount() int {
+
+	if h.targets != nil {
+		return len(h.targets) + h.extraCount()
+	}
+	return 1 + h.extraCount()
+}
+
+// notFound returns the error of a search that did not find every target
+func (h *Hasher) notFound() error {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(h.found) == 0 {
+		return fmt.Errorf("no match found")
+	}
+	return fmt.Errorf("found %d of %d hashes", len(h.found), h.targetCount())
+}
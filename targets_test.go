@@ -0,0 +1,162 @@
+package gohash
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	md5Hej  = "541c57960bb997942655d14e3b9607f9"
+	md5Tex  = "5df398e7946db5ded47290cbb43c5028"
+	md5Lot  = "209f2308333b9973de9b4ea31d526b89"
+	md5Zero = "00000000000000000000000000000000"
+)
+
+func TestExpectedHashes(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hejtxlo")
+	hasher.Length(3)
+	hasher.Workers(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej, md5Tex, md5Lot}))
+
+	var mu sync.Mutex
+	matches := map[string]string{}
+	hasher.OnMatch(func(key, hash string) {
+		mu.Lock()
+		matches[hash] = key
+		mu.Unlock()
+	})
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+
+	expected := map[string]string{md5Hej: "hej", md5Tex: "tex", md5Lot: "lot"}
+	assert.Equal(t, expected, matches)
+	assert.Equal(t, expected, hasher.Found())
+}
+
+func TestExpectedHashesPartial(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej, md5Zero}))
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "found 1 of 2 hashes", err.Error())
+	assert.Equal(t, map[string]string{md5Hej: "hej"}, hasher.Found())
+}
+
+func TestExpectedHashesWordlist(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Tex, md5Hej}))
+
+	res, err := hasher.FindFromWordlist(strings.NewReader("foo\nhej\nbar\ntex\nlot"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "tex", res)
+}
+
+func TestLoadExpectedHashes(t *testing.T) {
+
+	hasher := NewHasher()
+	assert.Equal(t, nil, hasher.LoadExpectedHashes(strings.NewReader(md5Hej+"\n\n "+md5Tex+"\r\n")))
+	assert.Equal(t, 2, len(hasher.targets))
+
+	// a single hash is the same as ExpectedHash
+	assert.Equal(t, nil, hasher.LoadExpectedHashes(strings.NewReader(md5Hej+"\n"+md5Hej)))
+	assert.Equal(t, map[string]bool(nil), hasher.targets)
+	assert.Equal(t, md5Hej, hex.EncodeToString(hasher.expected))
+
+	assert.Equal(t, "no hashes", hasher.LoadExpectedHashes(strings.NewReader("\n")).Error())
+	assert.Equal(t, true, hasher.LoadExpectedHashes(strings.NewReader("xyz")) != nil)
+}
+
+func TestExpectedHashesWrongSize(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej, "0011"}))
+
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "expectedHash is wrong size, should be 128 bit, is 16", err.Error())
+}
+
+func TestExpectedHashesState(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("hej")
+	hasher.Length(3)
+	assert.Equal(t, nil, hasher.ExpectedHashes([]string{md5Hej, md5Zero}))
+	hasher.FindSequential()
+
+	var buf bytes.Buffer
+	assert.Equal(t, nil, hasher.SaveState(&buf))
+
+	resumed := NewHasher()
+	assert.Equal(t, nil, resumed.LoadState(&buf))
+	assert.Equal(t, 2, len(resumed.targets))
+	assert.Equal(t, map[string]string{md5Hej: "hej"}, resumed.Found())
+}
+
+func TestFindAll(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("adler32")
+	hasher.AllowedKeys("abcdefgh")
+	hasher.Length(3)
+	hasher.Workers(4)
+	hasher.ExpectedHash("02510128")
+
+	res, err := hasher.FindAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"aea", "bcb", "cac"}, res)
+
+	hasher.ExpectedHash("00000000")
+	res, err = hasher.FindAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(res))
+}
+
+func TestFindAllPattern(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("crc32")
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(4)
+	hasher.DigestPrefix("1234")
+
+	res, err := hasher.FindAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"beur", "erua", "fahs", "ldmo"}, res)
+}
+
+func TestFindAllContext(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("adler32")
+	hasher.AllowedKeys("abcdefgh")
+	hasher.Length(3)
+
+	_, err := hasher.FindAllContext(context.Background())
+	assert.Equal(t, "expectedHash is wrong size, should be 32 bit, is 0", err.Error())
+
+	hasher.ExpectedHash("02510128")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = hasher.FindAllContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
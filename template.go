@@ -0,0 +1,137 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// templateNode is a parsed part of a template such as "md5($salt.md5($pass))"
+type templateNode struct {
+	// algo is set for hash functions, hashing the concatenated args
+	algo string
+	args []*templateNode
+
+	pass bool
+	salt bool
+	text string
+}
+
+// Template sets how candidates are hashed, such as "sha256($salt.$pass)"
+// or "md5($salt.md5($pass))", instead of the algo. Args of a hash function
+// are concatenated with ".", inner hashes are used as lower case hex, and
+// text can be included in single quotes
+func (h *Hasher) Template(s string) {
+	h.template = s
+}
+
+// Salt sets the value of $salt in the template
+func (h *Hasher) Salt(s string) {
+	h.salt = s
+}
+
+// parseTemplate parses a template, which must be a hash function
+func parseTemplate(s string) (*templateNode, error) {
+
+	p := &templateParser{s: strings.TrimSpace(s)}
+	res, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	if res.algo == "" {
+		return nil, fmt.Errorf("template %q must be a hash function, such as sha1($pass)", s)
+	}
+	return res, nil
+}
+
+type templateParser struct {
+	s   string
+	pos int
+}
+
+func (p *templateParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("template %q at %d: %s", p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *templateParser) parseTerm() (*templateNode, error) {
+
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], "$pass"):
+		p.pos += len("$pass")
+		return &templateNode{pass: true}, nil
+
+	case strings.HasPrefix(p.s[p.pos:], "$salt"):
+		p.pos += len("$salt")
+		return &templateNode{salt: true}, nil
+
+	case strings.HasPrefix(p.s[p.pos:], "'"):
+		end := strings.IndexByte(p.s[p.pos+1:], '\'')
+		if end == -1 {
+			return nil, p.errorf("unterminated text")
+		}
+		res := &templateNode{text: p.s[p.pos+1 : p.pos+1+end]}
+		p.pos += end + 2
+		return res, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && isTemplateNameByte(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected $pass, $salt, text or hash function")
+	}
+	name := strings.Replace(strings.ToLower(p.s[start:p.pos]), "_", "-", -1)
+	name = resolveAlgoAliases(name)
+	if _, ok := hashers[name]; !ok {
+		return nil, fmt.Errorf("unknown algo %s", name)
+	}
+	if p.pos >= len(p.s) || p.s[p.pos] != '(' {
+		return nil, p.errorf("expected (")
+	}
+	p.pos++
+
+	res := &templateNode{algo: name}
+	for {
+		arg, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		res.args = append(res.args, arg)
+		if p.pos < len(p.s) && p.s[p.pos] == '.' {
+			p.pos++
+			continue
+		}
+		if p.pos < len(p.s) && p.s[p.pos] == ')' {
+			p.pos++
+			return res, nil
+		}
+		return nil, p.errorf("expected . or )")
+	}
+}
+
+func isTemplateNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == '_'
+}
+
+// digest returns the hash of the concatenated args of a hash function node
+func (n *templateNode) digest(pass, salt []byte) []byte {
+
+	input := []byte{}
+	for _, arg := range n.args {
+		switch {
+		case arg.algo != "":
+			input = append(input, hex.EncodeToString(arg.digest(pass, salt))...)
+		case arg.pass:
+			input = append(input, pass...)
+		case arg.salt:
+			input = append(input, salt...)
+		default:
+			input = append(input, arg.text...)
+		}
+	}
+	return *hashers[n.algo](&input)
+}
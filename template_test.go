@@ -0,0 +1,57 @@
+package gohash
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateDigest(t *testing.T) {
+
+	tests := map[string]string{
+		"sha256($salt.$pass)":       "2e846b52e7ba436e9043381411228cb52406d5f7bb7babe57a66f83c6dc66d37",
+		"SHA1($pass.$salt)":         "50615c55bd7249958ec57d1a5f037ec74ca9d7a7",
+		"md5($salt.md5($pass))":     "ab44ef56b8239e50d1e37eec136d4978",
+		"md5('x:'.$pass.':'.$salt)": "fd5252d610142bd86e090bc4d3c4fe26",
+		" md5($pass) ":              "541c57960bb997942655d14e3b9607f9",
+	}
+	for template, expected := range tests {
+		tmpl, err := parseTemplate(template)
+		assert.Equal(t, nil, err, template)
+		assert.Equal(t, expected, hex.EncodeToString(tmpl.digest([]byte("hej"), []byte("NaCl"))), template)
+	}
+}
+
+func TestParseTemplateInvalid(t *testing.T) {
+
+	tests := map[string]string{
+		"$pass":            `template "$pass" must be a hash function, such as sha1($pass)`,
+		"md5($pass":        `template "md5($pass" at 9: expected . or )`,
+		"md5($pass))":      `template "md5($pass))" at 10: unexpected ")"`,
+		"md5($pass.'x)":    `template "md5($pass.'x)" at 10: unterminated text`,
+		"md5()":            `template "md5()" at 4: expected $pass, $salt, text or hash function`,
+		"md5 ($pass)":      `template "md5 ($pass)" at 3: expected (`,
+		"nope($pass)":      "unknown algo nope",
+		"md5($pass.$user)": `template "md5($pass.$user)" at 10: expected $pass, $salt, text or hash function`,
+	}
+	for template, expected := range tests {
+		_, err := parseTemplate(template)
+		assert.Equal(t, expected, err.Error(), template)
+	}
+}
+
+func TestHasherTemplate(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Template("md5($salt.md5($pass))")
+	hasher.Salt("NaCl")
+	hasher.AllowedKeys("holej")
+	hasher.Length(3)
+	hasher.ExpectedHash("ab44ef56b8239e50d1e37eec136d4978")
+
+	res, err := hasher.FindSequential()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hej", res)
+	assert.Equal(t, "md5", hasher.algo)
+}
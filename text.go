@@ -0,0 +1,143 @@
+package gohash
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+// NormalizeText returns b with a leading utf-8 or utf-16 byte order mark
+// removed and each CRLF replaced with LF, so text files hash the same on
+// windows and unix. After a utf-16 byte order mark, CRLF is replaced in
+// 16 bit code units of that byte order, utf-16 without one is handled as
+// bytes. Lone CR are kept
+func NormalizeText(b []byte) []byte {
+
+	cr, lf := textLineEnds(b)
+	b = stripBOM(b)
+	if len(cr) == 1 {
+		return bytes.Replace(b, []byte("\r\n"), lf, -1)
+	}
+
+	res := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i += len(cr) {
+		unit := b[i:]
+		if len(unit) > len(cr) {
+			unit = unit[:len(cr)]
+		}
+		if bytes.Equal(unit, cr) && bytes.HasPrefix(b[i+len(cr):], lf) {
+			continue
+		}
+		res = append(res, unit...)
+	}
+	return res
+}
+
+// stripBOM returns b without a leading byte order mark
+func stripBOM(b []byte) []byte {
+
+	for _, bom := range [][]byte{utf8BOM, utf16LEBOM, utf16BEBOM} {
+		if bytes.HasPrefix(b, bom) {
+			return b[len(bom):]
+		}
+	}
+	return b
+}
+
+// textLineEnds returns the code units of CR and LF of the encoding of
+// the byte order mark of b
+func textLineEnds(b []byte) ([]byte, []byte) {
+
+	switch {
+	case bytes.HasPrefix(b, utf16LEBOM):
+		return []byte{'\r', 0}, []byte{'\n', 0}
+	case bytes.HasPrefix(b, utf16BEBOM):
+		return []byte{0, '\r'}, []byte{0, '\n'}
+	}
+	return []byte("\r"), []byte("\n")
+}
+
+// textReader normalizes text as it is read, see NormalizeText
+type textReader struct {
+	r       *bufio.Reader
+	started bool
+	cr, lf  []byte
+
+	// the code unit being read, and what is left of it to return
+	unit    [2]byte
+	pending []byte
+}
+
+// NewTextReader returns a reader of r normalized like NormalizeText
+func NewTextReader(r io.Reader) io.Reader {
+	return &textReader{r: bufio.NewReader(r)}
+}
+
+func (t *textReader) Read(p []byte) (int, error) {
+
+	if !t.started {
+		t.started = true
+		head, _ := t.r.Peek(len(utf8BOM))
+		t.cr, t.lf = textLineEnds(head)
+		if n := len(head) - len(stripBOM(head)); n > 0 {
+			t.r.Discard(n)
+		}
+	}
+
+	n := 0
+	for n < len(p) {
+		if len(t.pending) > 0 {
+			c := copy(p[n:], t.pending)
+			t.pending = t.pending[c:]
+			n += c
+			continue
+		}
+		if n > 0 && t.r.Buffered() == 0 {
+			// return what is read, instead of blocking for more
+			return n, nil
+		}
+		unit, err := t.readUnit()
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		if bytes.Equal(unit, t.cr) {
+			if next, err := t.r.Peek(len(t.lf)); err == nil && bytes.Equal(next, t.lf) {
+				continue
+			}
+		}
+		t.pending = unit
+	}
+	return n, nil
+}
+
+// readUnit reads the next code unit, a trailing odd byte of utf-16 is
+// returned as is
+func (t *textReader) readUnit() ([]byte, error) {
+
+	n, err := io.ReadFull(t.r, t.unit[:len(t.cr)])
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return t.unit[:n], err
+}
+
+// TextInput returns in, normalized like NormalizeText when it is read
+func TextInput(in Input) Input {
+
+	return Input{Name: in.Name, Open: func() (io.ReadCloser, error) {
+		r, err := in.Open()
+		if err != nil {
+			return nil, err
+		}
+		return readCloser{NewTextReader(r), r}, nil
+	}}
+}
@@ -0,0 +1,81 @@
+package gohash
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText(t *testing.T) {
+
+	tests := []struct {
+		in, out string
+	}{
+		{"a\r\nb\r\n", "a\nb\n"},
+		{"a\nb", "a\nb"},
+		{"a\rb\r\r\n", "a\rb\r\n"},
+		{"\xef\xbb\xbfa\r\n", "a\n"},
+		{"\xff\xfea\x00", "a\x00"},
+		{"\xfe\xff\x00a", "\x00a"},
+		{"\xff\xfea\x00\r\x00\n\x00b\x00\r\x00", "a\x00\n\x00b\x00\r\x00"},
+		{"\xfe\xff\x00a\x00\r\x00\n\x00b\x00\r", "\x00a\x00\n\x00b\x00\r"},
+		{"\xff\xfe\r\n\x00\r\x00\n", "\r\n\x00\r\x00\n"}, // U+0A0D, U+0D00, U+0A00
+		{"\xfe\xff\r\n\r\x00\n\x00", "\r\n\r\x00\n\x00"}, // U+0D0A, U+0D00, U+0A00
+		{"\xff\xfea\x00\r", "a\x00\r"},
+		{"a\xef\xbb\xbf", "a\xef\xbb\xbf"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.out, string(NormalizeText([]byte(test.in))), test.in)
+
+		b, err := ioutil.ReadAll(NewTextReader(iotest.OneByteReader(strings.NewReader(test.in))))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.out, string(b), test.in)
+
+		b, err = ioutil.ReadAll(NewTextReader(iotest.HalfReader(strings.NewReader(test.in))))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, test.out, string(b), test.in)
+	}
+}
+
+func TestTextInput(t *testing.T) {
+
+	dir := t.TempDir()
+	unix := filepath.Join(dir, "unix.txt")
+	windows := filepath.Join(dir, "windows.txt")
+	assert.Equal(t, nil, os.WriteFile(unix, []byte("one\ntwo\n"), 0644))
+	assert.Equal(t, nil, os.WriteFile(windows, []byte("\xef\xbb\xbfone\r\ntwo\r\n"), 0644))
+
+	inputs := []Input{TextInput(FileInput(unix)), TextInput(FileInput(windows)), FileInput(windows)}
+	res := HashInputs(inputs, "sha1", "", 1)
+	assert.Equal(t, res[0].Result.Digest, res[1].Result.Digest)
+	assert.NotEqual(t, res[0].Result.Digest, res[2].Result.Digest)
+	assert.Equal(t, windows, res[1].Result.Name)
+
+	for _, bom := range []string{"\xff\xfe", "\xfe\xff"} {
+		utf16 := func(s string) []byte {
+			b := []byte(bom)
+			for _, c := range []byte(s) {
+				if bom == "\xff\xfe" {
+					b = append(b, c, 0)
+				} else {
+					b = append(b, 0, c)
+				}
+			}
+			return b
+		}
+		assert.Equal(t, nil, os.WriteFile(unix, utf16("one\ntwo\n"), 0644))
+		assert.Equal(t, nil, os.WriteFile(windows, utf16("one\r\ntwo\r\n"), 0644))
+		res = HashInputs(inputs, "sha1", "", 1)
+		assert.Equal(t, res[0].Result.Digest, res[1].Result.Digest, bom)
+		assert.NotEqual(t, res[0].Result.Digest, res[2].Result.Digest, bom)
+	}
+
+	_, err := TextInput(FileInput(filepath.Join(dir, "nope"))).Open()
+	assert.Equal(t, true, os.IsNotExist(err))
+}
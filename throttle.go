@@ -0,0 +1,41 @@
+package gohash
+
+import (
+	"time"
+)
+
+// MaxRate caps the search at about n tries per second, over all workers,
+// so long searches can run in the background. The workers are paused
+// between batches of 1024 tries, so low rates are kept on average
+func (h *Hasher) MaxRate(n uint64) {
+	h.maxRate = n
+}
+
+// Nice pauses each worker for d after every 1024 tries, yielding the cpu
+// to other programs
+func (h *Hasher) Nice(d time.Duration) {
+	h.nice = d
+}
+
+// throttle pauses the calling worker for MaxRate and Nice, given the
+// number of tries of the search so far, until done is closed
+func (h *Hasher) throttle(tries uint64, done <-chan struct{}) {
+
+	wait := h.nice
+	if h.maxRate != 0 {
+		due := time.Duration(float64(tries) / float64(h.maxRate) * float64(time.Second))
+		if ahead := due - time.Since(h.started); ahead > wait {
+			wait = ahead
+		}
+	}
+	if wait <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-done:
+	}
+}
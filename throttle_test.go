@@ -0,0 +1,61 @@
+package gohash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasherMaxRate(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(3)
+	hasher.Workers(2)
+	hasher.MaxRate(100000)
+
+	// 17576 keys take about 175ms at the rate
+	started := time.Now()
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+	assert.Equal(t, true, time.Since(started) > 100*time.Millisecond)
+}
+
+func TestHasherNice(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(3)
+	hasher.Workers(1)
+	hasher.Nice(10 * time.Millisecond)
+
+	// 17 pauses of 10ms
+	started := time.Now()
+	_, err := hasher.FindSequential()
+	assert.Equal(t, "no match found", err.Error())
+	assert.Equal(t, true, time.Since(started) > 150*time.Millisecond)
+}
+
+func TestHasherThrottleStops(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.ExpectedHash(md5Zero)
+	hasher.AllowedKeys("abcdefghijklmnopqrstuvwxyz")
+	hasher.Length(3)
+	hasher.MaxRate(10)
+
+	// a pause of about 100s is cut short by the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := hasher.FindSequentialContext(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, true, time.Since(started) < 5*time.Second)
+}
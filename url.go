@@ -0,0 +1,122 @@
+package gohash
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fetcher downloads http and https inputs, so they are hashed as they are
+// read instead of being saved first
+type Fetcher struct {
+	connectTimeout time.Duration
+	timeout        time.Duration
+	maxRedirects   int
+	maxSize        int64
+}
+
+// NewFetcher returns a Fetcher with a 30 second connect timeout, without
+// a limit on the time of the whole download, following up to 10
+// redirects, without a max size
+func NewFetcher() *Fetcher {
+
+	return &Fetcher{
+		connectTimeout: 30 * time.Second,
+		maxRedirects:   10,
+	}
+}
+
+// ConnectTimeout sets how long connecting and waiting for the response
+// headers may take, 0 for no limit
+func (f *Fetcher) ConnectTimeout(d time.Duration) {
+	f.connectTimeout = d
+}
+
+// Timeout sets how long the whole download may take, including reading
+// the body, 0 for no limit
+func (f *Fetcher) Timeout(d time.Duration) {
+	f.timeout = d
+}
+
+// MaxRedirects sets how many redirects are followed, 0 for none
+func (f *Fetcher) MaxRedirects(n int) {
+	f.maxRedirects = n
+}
+
+// MaxSize sets the max size of a download in bytes, 0 for no limit
+func (f *Fetcher) MaxSize(n int64) {
+	f.maxSize = n
+}
+
+// IsURL returns true if s is a http or https url
+func IsURL(s string) bool {
+
+	s = strings.ToLower(s)
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// Open starts downloading url. The body is streamed as it is read, and
+// reading it fails if it is larger than the max size
+func (f *Fetcher) Open(url string) (io.ReadCloser, error) {
+
+	if !IsURL(url) {
+		return nil, fmt.Errorf("%q is not a http or https url", url)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if f.connectTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: f.connectTimeout}).DialContext
+		transport.TLSHandshakeTimeout = f.connectTimeout
+		transport.ResponseHeaderTimeout = f.connectTimeout
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   f.timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > f.maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", f.maxRedirects)
+			}
+			return nil
+		},
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	if f.maxSize == 0 {
+		return resp.Body, nil
+	}
+	if resp.ContentLength > f.maxSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s is %d bytes, more than max size %d", url, resp.ContentLength, f.maxSize)
+	}
+	return &sizeLimiter{ReadCloser: resp.Body, url: url, max: f.maxSize}, nil
+}
+
+// sizeLimiter fails reading more than max bytes, for bodies of unknown
+// or wrong length
+type sizeLimiter struct {
+	io.ReadCloser
+	url  string
+	max  int64
+	read int64
+}
+
+func (l *sizeLimiter) Read(p []byte) (int, error) {
+
+	if left := l.max - l.read + 1; int64(len(p)) > left {
+		p = p[:left]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n, fmt.Errorf("%s is more than max size %d", l.url, l.max)
+	}
+	return n, err
+}
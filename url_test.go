@@ -0,0 +1,146 @@
+package gohash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsURL(t *testing.T) {
+
+	assert.Equal(t, true, IsURL("http://example.com/a.iso"))
+	assert.Equal(t, true, IsURL("HTTPS://example.com/a.iso"))
+	assert.Equal(t, false, IsURL("ftp://example.com/a.iso"))
+	assert.Equal(t, false, IsURL("http.iso"))
+}
+
+func testServer() *httptest.Server {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fox", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, fox)
+	})
+	mux.HandleFunc("/chunked", func(w http.ResponseWriter, r *http.Request) {
+		// no content length
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, fox)
+	})
+	mux.HandleFunc("/redirect/", func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/redirect/"), "%d", &n)
+		if n == 0 {
+			http.Redirect(w, r, "/fox", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, fox)
+	})
+	mux.HandleFunc("/slowbody", func(w http.ResponseWriter, r *http.Request) {
+		for _, word := range strings.SplitAfter(fox, " ") {
+			fmt.Fprint(w, word)
+			w.(http.Flusher).Flush()
+			time.Sleep(20 * time.Millisecond)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetcher(t *testing.T) {
+
+	srv := testServer()
+	defer srv.Close()
+
+	f := NewFetcher()
+	r, err := f.Open(srv.URL + "/redirect/2")
+	assert.Equal(t, nil, err)
+	sum, n, err := SumReader("md5", r)
+	r.Close()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(43), n)
+	assert.Equal(t, *NewCalculator([]byte(fox)).Sum("md5"), *sum)
+
+	_, err = f.Open(srv.URL + "/missing")
+	assert.Equal(t, srv.URL+"/missing: 404 Not Found", err.Error())
+
+	_, err = f.Open("ftp://example.com/fox")
+	assert.Equal(t, `"ftp://example.com/fox" is not a http or https url`, err.Error())
+}
+
+func TestFetcherMaxRedirects(t *testing.T) {
+
+	srv := testServer()
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.MaxRedirects(2)
+	r, err := f.Open(srv.URL + "/redirect/1")
+	assert.Equal(t, nil, err)
+	r.Close()
+
+	_, err = f.Open(srv.URL + "/redirect/2")
+	assert.Equal(t, true, strings.HasSuffix(err.Error(), "stopped after 2 redirects"), err.Error())
+}
+
+func TestFetcherMaxSize(t *testing.T) {
+
+	srv := testServer()
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.MaxSize(43)
+	r, err := f.Open(srv.URL + "/chunked")
+	assert.Equal(t, nil, err)
+	b, err := ioutil.ReadAll(r)
+	r.Close()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+
+	f.MaxSize(10)
+	_, err = f.Open(srv.URL + "/fox")
+	assert.Equal(t, srv.URL+"/fox is 43 bytes, more than max size 10", err.Error())
+
+	r, err = f.Open(srv.URL + "/chunked")
+	assert.Equal(t, nil, err)
+	_, err = ioutil.ReadAll(r)
+	r.Close()
+	assert.Equal(t, srv.URL+"/chunked is more than max size 10", err.Error())
+}
+
+func TestFetcherTimeout(t *testing.T) {
+
+	srv := testServer()
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.Timeout(50 * time.Millisecond)
+	_, err := f.Open(srv.URL + "/slow")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFetcherConnectTimeout(t *testing.T) {
+
+	srv := testServer()
+	defer srv.Close()
+
+	f := NewFetcher()
+	f.ConnectTimeout(50 * time.Millisecond)
+	_, err := f.Open(srv.URL + "/slow")
+	assert.NotEqual(t, nil, err)
+
+	// a body taking longer than the connect timeout is read in full
+	r, err := f.Open(srv.URL + "/slowbody")
+	assert.Equal(t, nil, err)
+	b, err := ioutil.ReadAll(r)
+	r.Close()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+}
@@ -1,10 +1,13 @@
 package gohash
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"sort"
+	"time"
 
 	termutil "github.com/andrew-d/go-termutil"
 )
@@ -58,29 +61,233 @@ func (a byteSlice) Len() int           { return len(a) }
 func (a byteSlice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byteSlice) Less(i, j int) bool { return a[i] < a[j] }
 
+var (
+	// InputProgress, if set, is called with the bytes read so far and the
+	// rate in bytes per second as ReadPipeOrFile and OpenPipeOrFile inputs
+	// are read, see CountingReader
+	InputProgress func(read int64, rate float64)
+
+	// MaxInputMemory, if set, is the most bytes ReadPipeOrFile keeps in
+	// memory. Larger inputs are streamed from their file instead, piped
+	// input from a temp file, see AppInputData.IsStreamed. SumReader fails
+	// on larger inputs of the algos it can not stream
+	MaxInputMemory int64
+)
+
+// CountingReader counts the bytes read from the underlying reader, and
+// reports them with the average rate, at most once a second and at the
+// end of the input
+type CountingReader struct {
+	r        io.Reader
+	fn       func(read int64, rate float64)
+	interval time.Duration
+	started  time.Time
+	reported time.Time
+	read     int64
+}
+
+// NewCountingReader returns a CountingReader of r, calling fn with the
+// bytes read so far and the rate in bytes per second. fn may be nil
+func NewCountingReader(r io.Reader, fn func(read int64, rate float64)) *CountingReader {
+
+	return &CountingReader{
+		r:        r,
+		fn:       fn,
+		interval: defaultProgressInterval,
+	}
+}
+
+// Interval sets the least time between reports
+func (c *CountingReader) Interval(d time.Duration) {
+	c.interval = d
+}
+
+// Count returns the number of bytes read
+func (c *CountingReader) Count() int64 {
+	return c.read
+}
+
+// Rate returns the average number of bytes read per second
+func (c *CountingReader) Rate() float64 {
+
+	elapsed := time.Since(c.started)
+	if c.started.IsZero() || elapsed <= 0 {
+		return 0
+	}
+	return float64(c.read) / elapsed.Seconds()
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+
+	now := time.Now()
+	if c.started.IsZero() {
+		c.started = now
+		c.reported = now
+	}
+	n, err := c.r.Read(p)
+	c.read += int64(n)
+	if c.fn != nil && (err != nil || now.Sub(c.reported) >= c.interval) {
+		c.reported = now
+		c.fn(c.read, c.Rate())
+	}
+	return n, err
+}
+
+// countInput wraps r in a CountingReader reporting to InputProgress, if
+// it is set
+func countInput(r io.Reader) io.Reader {
+
+	if InputProgress == nil {
+		return r
+	}
+	return NewCountingReader(r, InputProgress)
+}
+
 // AppInputData is the captured input to the app, either from a pipe or a file
 type AppInputData struct {
 	Data   []byte
 	IsPipe bool
+
+	// the input when it is larger than MaxInputMemory, and Data is nil
+	file *os.File
+	temp bool
 }
 
-// ReadPipeOrFile reads from stdin if pipe exists, else from provided file
+// ReadPipeOrFile reads from stdin if pipe exists, else from provided file.
+// Inputs larger than MaxInputMemory are not read into Data, and must be
+// read with Reader instead
 func ReadPipeOrFile(fileName string) (*AppInputData, error) {
 
 	res := AppInputData{}
 
 	if !termutil.Isatty(os.Stdin.Fd()) {
-		res.Data, _ = ioutil.ReadAll(os.Stdin)
 		res.IsPipe = true
+		if MaxInputMemory > 0 {
+			return &res, res.readLimited(countInput(os.Stdin))
+		}
+		res.Data, _ = ioutil.ReadAll(countInput(os.Stdin))
 	} else {
 		if fileName == "" {
 			return nil, fmt.Errorf("no piped data and no file provided")
 		}
+		if MaxInputMemory > 0 {
+			if fi, err := os.Stat(fileName); err == nil && fi.Size() > MaxInputMemory {
+				res.file, err = os.Open(fileName)
+				return &res, err
+			}
+		}
 		var err error
-		res.Data, err = ioutil.ReadFile(fileName)
+		if InputProgress == nil {
+			res.Data, err = ioutil.ReadFile(fileName)
+		} else {
+			res.Data, err = readCounted(fileName)
+		}
 		if err != nil {
 			return &res, err
 		}
 	}
 	return &res, nil
 }
+
+// readLimited reads r into Data, or into a temp file if r is larger than
+// MaxInputMemory
+func (a *AppInputData) readLimited(r io.Reader) error {
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxInputMemory+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) <= MaxInputMemory {
+		a.Data = data
+		return nil
+	}
+
+	f, err := ioutil.TempFile("", "gohash")
+	if err != nil {
+		return err
+	}
+	a.file = f
+	a.temp = true
+	if _, err := f.Write(data); err != nil {
+		a.Close()
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		a.Close()
+		return err
+	}
+	return nil
+}
+
+// IsStreamed returns true if the input was larger than MaxInputMemory, so
+// it is read with Reader instead of from Data
+func (a *AppInputData) IsStreamed() bool {
+	return a.file != nil
+}
+
+// Reader returns a reader of the input from the start, from Data or
+// from the file of a streamed input
+func (a *AppInputData) Reader() (io.Reader, error) {
+
+	if a.file == nil {
+		return bytes.NewReader(a.Data), nil
+	}
+	if _, err := a.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if a.temp {
+		return a.file, nil
+	}
+	return countInput(a.file), nil
+}
+
+// Close closes the file of a streamed input, removing the temp file of a
+// piped input
+func (a *AppInputData) Close() error {
+
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	if a.temp {
+		os.Remove(a.file.Name())
+	}
+	a.file = nil
+	return err
+}
+
+// readCounted reads the file, reporting to InputProgress
+func readCounted(fileName string) ([]byte, error) {
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(countInput(f))
+}
+
+// OpenPipeOrFile returns stdin if pipe exists, else opens provided file
+func OpenPipeOrFile(fileName string) (io.ReadCloser, error) {
+
+	if !termutil.Isatty(os.Stdin.Fd()) {
+		return ioutil.NopCloser(countInput(os.Stdin)), nil
+	}
+	if fileName == "" {
+		return nil, fmt.Errorf("no piped data and no file provided")
+	}
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if InputProgress == nil {
+		return f, nil
+	}
+	return readCloser{countInput(f), f}, nil
+}
+
+// readCloser closes the underlying reader of a wrapping reader
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
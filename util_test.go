@@ -0,0 +1,125 @@
+package gohash
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountingReader(t *testing.T) {
+
+	reports := []int64{}
+	r := NewCountingReader(iotest.OneByteReader(strings.NewReader(fox)), func(read int64, rate float64) {
+		reports = append(reports, read)
+		assert.Equal(t, true, rate >= 0)
+	})
+	r.Interval(0)
+
+	b, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+	assert.Equal(t, int64(43), r.Count())
+	assert.Equal(t, int64(43), reports[len(reports)-1])
+	assert.Equal(t, true, len(reports) >= 43)
+}
+
+func TestCountingReaderInterval(t *testing.T) {
+
+	reports := []int64{}
+	r := NewCountingReader(iotest.OneByteReader(strings.NewReader(fox)), func(read int64, rate float64) {
+		reports = append(reports, read)
+	})
+
+	_, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	// only the end of the input, within the first second
+	assert.Equal(t, []int64{43}, reports)
+}
+
+func TestInputProgress(t *testing.T) {
+
+	name := filepath.Join(t.TempDir(), "fox.txt")
+	assert.Equal(t, nil, os.WriteFile(name, []byte(fox), 0644))
+
+	var last int64
+	InputProgress = func(read int64, rate float64) {
+		last = read
+	}
+	defer func() {
+		InputProgress = nil
+	}()
+
+	r, err := os.Open(name)
+	assert.Equal(t, nil, err)
+	defer r.Close()
+	b, err := ioutil.ReadAll(countInput(r))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+	assert.Equal(t, int64(43), last)
+
+	b, err = readCounted(name)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+	assert.Equal(t, int64(43), last)
+}
+
+func TestReadLimited(t *testing.T) {
+
+	MaxInputMemory = 10
+	defer func() {
+		MaxInputMemory = 0
+	}()
+
+	small := &AppInputData{}
+	assert.Equal(t, nil, small.readLimited(strings.NewReader("hello")))
+	assert.Equal(t, false, small.IsStreamed())
+	assert.Equal(t, "hello", string(small.Data))
+	r, err := small.Reader()
+	assert.Equal(t, nil, err)
+	b, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hello", string(b))
+	assert.Equal(t, nil, small.Close())
+
+	large := &AppInputData{}
+	assert.Equal(t, nil, large.readLimited(iotest.OneByteReader(strings.NewReader(fox))))
+	assert.Equal(t, true, large.IsStreamed())
+	assert.Equal(t, []byte(nil), large.Data)
+	temp := large.file.Name()
+	for i := 0; i < 2; i++ {
+		r, err := large.Reader()
+		assert.Equal(t, nil, err)
+		res, err := ReaderResult("md5", "-", "", r)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, *NewCalculator([]byte(fox)).Sum("md5"), res.Digest)
+		assert.Equal(t, int64(43), res.Size)
+	}
+	assert.Equal(t, nil, large.Close())
+	_, err = os.Stat(temp)
+	assert.Equal(t, true, os.IsNotExist(err))
+}
+
+func TestStreamedFile(t *testing.T) {
+
+	name := filepath.Join(t.TempDir(), "fox.txt")
+	assert.Equal(t, nil, os.WriteFile(name, []byte(fox), 0644))
+
+	f, err := os.Open(name)
+	assert.Equal(t, nil, err)
+	in := &AppInputData{file: f}
+	r, err := in.Reader()
+	assert.Equal(t, nil, err)
+	b, err := ioutil.ReadAll(r)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, fox, string(b))
+	assert.Equal(t, nil, in.Close())
+
+	// the input file is kept
+	_, err = os.Stat(name)
+	assert.Equal(t, nil, err)
+}
@@ -0,0 +1,125 @@
+package gohash
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	uuAlphabet = "`!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_"
+	xxAlphabet = "+-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// bytes per encoded line
+	uuLineLength = 45
+)
+
+func encodeUUEncode(src []byte) ([]byte, error) {
+	return encodeUUBlock(src, uuAlphabet), nil
+}
+
+func decodeUUEncode(src []byte) ([]byte, error) {
+	return decodeUUBlock(src, "uuencode", uuAlphabet)
+}
+
+func encodeXXEncode(src []byte) ([]byte, error) {
+	return encodeUUBlock(src, xxAlphabet), nil
+}
+
+func decodeXXEncode(src []byte) ([]byte, error) {
+	return decodeUUBlock(src, "xxencode", xxAlphabet)
+}
+
+// encodeUUBlock renders a complete "begin ... end" block
+func encodeUUBlock(src []byte, alphabet string) []byte {
+
+	var buf bytes.Buffer
+	buf.WriteString("begin 644 data\n")
+
+	for len(src) > 0 {
+		n := len(src)
+		if n > uuLineLength {
+			n = uuLineLength
+		}
+		buf.WriteByte(alphabet[n])
+		for i := 0; i < n; i += 3 {
+			var chunk [3]byte
+			copy(chunk[:], src[i:n])
+			buf.WriteByte(alphabet[chunk[0]>>2])
+			buf.WriteByte(alphabet[(chunk[0]<<4|chunk[1]>>4)&0x3f])
+			buf.WriteByte(alphabet[(chunk[1]<<2|chunk[2]>>6)&0x3f])
+			buf.WriteByte(alphabet[chunk[2]&0x3f])
+		}
+		buf.WriteByte('\n')
+		src = src[n:]
+	}
+
+	buf.WriteByte(alphabet[0])
+	buf.WriteString("\nend\n")
+	return buf.Bytes()
+}
+
+// decodeUUBlock decodes data lines, with or without the begin/end framing
+func decodeUUBlock(src []byte, name string, alphabet string) ([]byte, error) {
+
+	res := []byte{}
+	lines, offsets := splitOffsets(string(src), "\n")
+
+	for num, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" || strings.HasPrefix(line, "begin ") {
+			continue
+		}
+		if line == "end" {
+			break
+		}
+
+		values, i := uuValues(line, alphabet)
+		if values == nil {
+			return nil, newDecodeError(name, src, offsets[num]+i, "invalid character")
+		}
+
+		n := int(values[0])
+		if n == 0 {
+			continue
+		}
+		data := values[1:]
+		if len(data) < (n+2)/3*4 {
+			return nil, fmt.Errorf("line %d: too short for %d bytes", num+1, n)
+		}
+
+		for i := 0; n > 0; i += 4 {
+			decoded := []byte{
+				data[i]<<2 | data[i+1]>>4,
+				data[i+1]<<4 | data[i+2]>>2,
+				data[i+2]<<6 | data[i+3],
+			}
+			if n < 3 {
+				decoded = decoded[0:n]
+			}
+			res = append(res, decoded...)
+			n -= len(decoded)
+		}
+	}
+	return res, nil
+}
+
+// uuValues returns the index in alphabet of each character of line, or
+// nil and the offset of an invalid character
+func uuValues(line string, alphabet string) ([]byte, int) {
+
+	res := make([]byte, len(line))
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if alphabet[0] == '`' && c == ' ' {
+			// space is the traditional zero, before backtick was used
+			c = '`'
+		}
+		idx := strings.IndexByte(alphabet, c)
+		if idx == -1 {
+			return nil, i
+		}
+		res[i] = byte(idx)
+	}
+	return res, 0
+}
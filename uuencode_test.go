@@ -0,0 +1,46 @@
+package gohash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUEncodeMultipleLines(t *testing.T) {
+
+	src := []byte(strings.Repeat(fox, 3))
+	res, err := encodeUUEncode(src)
+	assert.Equal(t, nil, err)
+
+	// 129 bytes is two full lines of 45, and one of 39
+	lines := strings.Split(string(res), "\n")
+	assert.Equal(t, 7, len(lines))
+	assert.Equal(t, byte('M'), lines[1][0])
+	assert.Equal(t, byte('G'), lines[3][0])
+
+	back, err := decodeUUEncode(res)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, src, back)
+}
+
+func TestDecodeUUEncodeWithoutFraming(t *testing.T) {
+
+	// space as zero, as emitted by older encoders
+	res, err := decodeUUEncode([]byte("#86)C\r\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "abc", string(res))
+
+	res, err = decodeUUEncode([]byte("\"86( \n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ab", string(res))
+}
+
+func TestDecodeXXEncodeInvalid(t *testing.T) {
+
+	_, err := decodeXXEncode([]byte("begin 644 data\nfJ4!\n+\nend\n"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = decodeXXEncode([]byte("fJ4V\n"))
+	assert.NotEqual(t, nil, err)
+}
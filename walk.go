@@ -0,0 +1,166 @@
+package gohash
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SymlinksSkip skips all symlinks
+	SymlinksSkip = "skip"
+
+	// SymlinksFiles hashes symlinks to files, without entering symlinks to
+	// directories
+	SymlinksFiles = "files"
+
+	// SymlinksFollow hashes symlinks to files and enters symlinks to
+	// directories, each directory once
+	SymlinksFollow = "follow"
+)
+
+// Walker lists the files of directory trees as inputs
+type Walker struct {
+	include  []string
+	exclude  []string
+	symlinks string
+	hidden   bool
+}
+
+// NewWalker returns a Walker of all files that are not hidden, hashing
+// symlinks to files
+func NewWalker() *Walker {
+
+	return &Walker{
+		symlinks: SymlinksFiles,
+	}
+}
+
+// Include only lists files matching any of the glob patterns. Patterns
+// with a "/" match the slash separated path from the root, others the
+// file name, as in "*.iso" or "docs/*.md"
+func (w *Walker) Include(patterns ...string) {
+	w.include = append(w.include, patterns...)
+}
+
+// Exclude skips files and directories matching any of the glob patterns,
+// matched like Include
+func (w *Walker) Exclude(patterns ...string) {
+	w.exclude = append(w.exclude, patterns...)
+}
+
+// Symlinks sets how symlinks are handled, SymlinksSkip, SymlinksFiles or
+// SymlinksFollow
+func (w *Walker) Symlinks(policy string) {
+	w.symlinks = policy
+}
+
+// Hidden sets wether files and directories starting with "." are listed
+func (w *Walker) Hidden(b bool) {
+	w.hidden = b
+}
+
+// Walk returns the inputs of the files below root, sorted by path
+func (w *Walker) Walk(root string) ([]Input, error) {
+
+	switch w.symlinks {
+	case SymlinksSkip, SymlinksFiles, SymlinksFollow:
+	default:
+		return nil, fmt.Errorf("unknown symlink policy %q", w.symlinks)
+	}
+	for _, pattern := range append(append([]string{}, w.include...), w.exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: %v", pattern, err)
+		}
+	}
+
+	fi, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	res := []Input{}
+	visited := map[string]bool{}
+	err = w.walk(root, "", visited, &res)
+	return res, err
+}
+
+// walk adds the files of dir to res, rel is the slash path of dir from
+// the root
+func (w *Walker) walk(dir string, rel string, visited map[string]bool, res *[]Input) error {
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if visited[resolved] {
+		// a symlink loop, or a directory linked twice
+		return nil
+	}
+	visited[resolved] = true
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range entries {
+		name := fi.Name()
+		p := filepath.Join(dir, name)
+		r := path.Join(rel, name)
+		if !w.hidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		if matchAny(w.exclude, r) {
+			continue
+		}
+
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if w.symlinks == SymlinksSkip {
+				continue
+			}
+			target, err := os.Stat(p)
+			if err != nil {
+				// a dangling symlink
+				continue
+			}
+			if target.IsDir() && w.symlinks != SymlinksFollow {
+				continue
+			}
+			fi = target
+		}
+
+		switch {
+		case fi.IsDir():
+			if err := w.walk(p, r, visited, res); err != nil {
+				return err
+			}
+		case fi.Mode().IsRegular():
+			if len(w.include) == 0 || matchAny(w.include, r) {
+				*res = append(*res, FileInput(p))
+			}
+		}
+	}
+	return nil
+}
+
+// matchAny returns true if rel, or its base name for patterns without a
+// "/", matches any of patterns
+func matchAny(patterns []string, rel string) bool {
+
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package gohash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func walkNames(t *testing.T, w *Walker, root string) []string {
+
+	inputs, err := w.Walk(root)
+	assert.Equal(t, nil, err)
+	res := []string{}
+	for _, in := range inputs {
+		rel, err := filepath.Rel(root, in.Name)
+		assert.Equal(t, nil, err)
+		res = append(res, filepath.ToSlash(rel))
+	}
+	return res
+}
+
+func testTree(t *testing.T) string {
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.iso":           "a",
+		"b.txt":           "b",
+		".hidden":         "h",
+		".git/config":     "g",
+		"docs/c.md":       "c",
+		"docs/d.iso":      "d",
+		"build/e.iso":     "e",
+		"other/f.txt":     "f",
+		"other/deep/g.md": "g",
+	})
+	assert.Equal(t, nil, os.Symlink(filepath.Join(dir, "b.txt"), filepath.Join(dir, "link.txt")))
+	assert.Equal(t, nil, os.Symlink(filepath.Join(dir, "other"), filepath.Join(dir, "linked")))
+	assert.Equal(t, nil, os.Symlink(dir, filepath.Join(dir, "other", "loop")))
+	assert.Equal(t, nil, os.Symlink(filepath.Join(dir, "nope"), filepath.Join(dir, "dangling")))
+	return dir
+}
+
+func TestWalker(t *testing.T) {
+
+	dir := testTree(t)
+
+	assert.Equal(t, []string{
+		"a.iso", "b.txt", "build/e.iso", "docs/c.md", "docs/d.iso",
+		"link.txt", "other/deep/g.md", "other/f.txt",
+	}, walkNames(t, NewWalker(), dir))
+
+	w := NewWalker()
+	w.Hidden(true)
+	w.Symlinks(SymlinksSkip)
+	assert.Equal(t, []string{
+		".git/config", ".hidden", "a.iso", "b.txt", "build/e.iso",
+		"docs/c.md", "docs/d.iso", "other/deep/g.md", "other/f.txt",
+	}, walkNames(t, w, dir))
+}
+
+func TestWalkerFilters(t *testing.T) {
+
+	dir := testTree(t)
+
+	w := NewWalker()
+	w.Include("*.iso", "docs/*.md")
+	w.Exclude("build")
+	assert.Equal(t, []string{"a.iso", "docs/c.md", "docs/d.iso"}, walkNames(t, w, dir))
+
+	w = NewWalker()
+	w.Exclude("*.md", "other/*")
+	assert.Equal(t, []string{"a.iso", "b.txt", "build/e.iso", "docs/d.iso", "link.txt"}, walkNames(t, w, dir))
+}
+
+func TestWalkerFollowSymlinks(t *testing.T) {
+
+	dir := testTree(t)
+
+	w := NewWalker()
+	w.Symlinks(SymlinksFollow)
+	w.Include("*.txt", "*.md")
+	assert.Equal(t, []string{
+		"b.txt", "docs/c.md", "link.txt", "linked/deep/g.md", "linked/f.txt",
+	}, walkNames(t, w, dir))
+}
+
+func TestWalkerErrors(t *testing.T) {
+
+	dir := testTree(t)
+
+	w := NewWalker()
+	w.Symlinks("sometimes")
+	_, err := w.Walk(dir)
+	assert.Equal(t, `unknown symlink policy "sometimes"`, err.Error())
+
+	w = NewWalker()
+	w.Include("[a")
+	_, err = w.Walk(dir)
+	assert.Equal(t, "[a: syntax error in pattern", err.Error())
+
+	_, err = NewWalker().Walk(filepath.Join(dir, "a.iso"))
+	assert.Equal(t, filepath.Join(dir, "a.iso")+" is not a directory", err.Error())
+}
@@ -0,0 +1,205 @@
+package gohash
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// WorkUnit is a part of the keyspace, Count keys starting at key number
+// Offset in the order of FindSequential, counting the shortest keys first.
+// It can be serialized as json, to be searched on another machine
+type WorkUnit struct {
+	Offset *big.Int `json:"offset"`
+	Count  *big.Int `json:"count"`
+}
+
+// WorkUnits splits the keyspace in n work units of about the same size,
+// or fewer if the keyspace is smaller than n
+func (h *Hasher) WorkUnits(n int) ([]WorkUnit, error) {
+
+	if n < 1 {
+		return nil, fmt.Errorf("work units must be at least 1")
+	}
+	total, err := h.Keyspace()
+	if err != nil {
+		return nil, err
+	}
+
+	size := new(big.Int).Add(total, big.NewInt(int64(n-1)))
+	size.Div(size, big.NewInt(int64(n)))
+
+	res := []WorkUnit{}
+	for offset := new(big.Int); offset.Cmp(total) < 0; {
+		count := new(big.Int).Sub(total, offset)
+		if count.Cmp(size) > 0 {
+			count.Set(size)
+		}
+		res = append(res, WorkUnit{Offset: new(big.Int).Set(offset), Count: count})
+		offset.Add(offset, count)
+	}
+	return res, nil
+}
+
+// FindWorkUnit searches the keys of unit, split between the workers
+func (h *Hasher) FindWorkUnit(unit WorkUnit) (string, error) {
+	return h.FindWorkUnitContext(context.Background(), unit)
+}
+
+// FindWorkUnitContext is like FindWorkUnit, but stops with the error of
+// ctx when it is done
+func (h *Hasher) FindWorkUnitContext(ctx context.Context, unit WorkUnit) (string, error) {
+	return h.limited(ctx, func(ctx context.Context) (string, error) {
+		return h.findWorkUnit(ctx, unit)
+	})
+}
+
+func (h *Hasher) findWorkUnit(ctx context.Context, unit WorkUnit) (string, error) {
+
+	if err := h.verify(); err != nil {
+		return "", err
+	}
+	total := h.keyspace()
+	if unit.Offset == nil || unit.Count == nil || unit.Offset.Sign() < 0 || unit.Count.Sign() < 0 ||
+		new(big.Int).Add(unit.Offset, unit.Count).Cmp(total) > 0 {
+		return "", fmt.Errorf("work unit is out of the keyspace of %s keys", total)
+	}
+
+	h.setTotal(unit.Count)
+	h.started = time.Now()
+	defer h.startStatusReport()()
+
+	workers := h.workers
+	if workers < 1 {
+		workers = 1
+	}
+	part := new(big.Int).Add(unit.Count, big.NewInt(int64(workers-1)))
+	part.Div(part, big.NewInt(int64(workers)))
+
+	search, cancel := context.WithCancel(ctx)
+	defer cancel()
+	found := make(chan string, workers)
+	var wg sync.WaitGroup
+	offset := new(big.Int).Set(unit.Offset)
+	end := new(big.Int).Add(unit.Offset, unit.Count)
+	for w := 0; w < workers && offset.Cmp(end) < 0; w++ {
+		count := new(big.Int).Sub(end, offset)
+		if count.Cmp(part) > 0 {
+			count.Set(part)
+		}
+		wg.Add(1)
+		go func(w int, offset *big.Int, count uint64) {
+			defer wg.Done()
+			if res, ok := h.searchRange(w, offset, count, search.Done()); ok {
+				found <- res
+			}
+		}(w, new(big.Int).Set(offset), clampUint64(count))
+		offset.Add(offset, count)
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	res, ok := <-found
+	cancel()
+	for range found {
+		// wait for the other workers to stop
+	}
+	if ok {
+		return res, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return "", h.notFound()
+}
+
+// searchRange tries count keys as worker w, starting at key number offset
+func (h *Hasher) searchRange(w int, offset *big.Int, count uint64, stop <-chan struct{}) (string, bool) {
+
+	// find the length of the offset, and the index of each key
+	index := new(big.Int).Set(offset)
+	length := h.minLength
+	for ; length <= h.maxLength; length++ {
+		size := h.lengthKeyspace(length)
+		if index.Cmp(size) < 0 {
+			break
+		}
+		index.Sub(index, size)
+	}
+	if length > h.maxLength {
+		return "", false
+	}
+	digits := make([]int, length)
+	for pos := length - 1; pos >= 0; pos-- {
+		var d big.Int
+		index.DivMod(index, big.NewInt(int64(len(h.positions[pos]))), &d)
+		digits[pos] = int(d.Int64())
+	}
+
+	buf := h.newCandidate(length)
+	key := buf[len(h.prefix) : len(h.prefix)+length]
+	for i, d := range digits {
+		key[i] = h.keyAt(key, i, d)
+	}
+
+	batch := h.newBatch(w)
+	tries := uint64(0)
+	for ; count > 0; count-- {
+		if batch.equals(buf) {
+			return string(buf), true
+		}
+
+		tries++
+		if tries%statusInterval == 0 {
+			select {
+			case <-stop:
+				return "", false
+			default:
+			}
+			h.reportProgress(buf, statusInterval)
+		}
+
+		roller := length - 1
+		for ; roller >= 0; roller-- {
+			if digits[roller] < len(h.positions[roller])-1 {
+				digits[roller]++
+				key[roller] = h.keyAt(key, roller, digits[roller])
+				break
+			}
+			digits[roller] = 0
+			key[roller] = h.keyAt(key, roller, 0)
+		}
+		if roller < 0 {
+			// continue with the first key of the next length
+			if length++; length > h.maxLength {
+				break
+			}
+			digits = make([]int, length)
+			buf = h.newCandidate(length)
+			key = buf[len(h.prefix) : len(h.prefix)+length]
+			roller = -1
+		}
+		if h.markov != nil || roller < 0 {
+			// the order of the following keys depends on the changed key
+			for i := roller + 1; i < length; i++ {
+				key[i] = h.keyAt(key, i, digits[i])
+			}
+		}
+	}
+	h.reportProgress(buf, tries%statusInterval)
+	return "", false
+}
+
+// clampUint64 returns n, or the largest uint64 if n is larger
+func clampUint64(n *big.Int) uint64 {
+
+	if !n.IsUint64() {
+		return math.MaxUint64
+	}
+	return n.Uint64()
+}
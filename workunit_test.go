@@ -0,0 +1,100 @@
+package gohash
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkUnits(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.AllowedKeys("abc")
+	hasher.MinLength(1)
+	hasher.MaxLength(3)
+
+	units, err := hasher.WorkUnits(4)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []WorkUnit{
+		{big.NewInt(0), big.NewInt(10)},
+		{big.NewInt(10), big.NewInt(10)},
+		{big.NewInt(20), big.NewInt(10)},
+		{big.NewInt(30), big.NewInt(9)},
+	}, units)
+
+	units, err = hasher.WorkUnits(100)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 39, len(units))
+
+	_, err = hasher.WorkUnits(0)
+	assert.Equal(t, "work units must be at least 1", err.Error())
+}
+
+func TestWorkUnitJSON(t *testing.T) {
+
+	unit := WorkUnit{Offset: new(big.Int).Lsh(big.NewInt(1), 80), Count: big.NewInt(1000)}
+	b, err := json.Marshal(unit)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"offset":1208925819614629174706176,"count":1000}`, string(b))
+
+	var res WorkUnit
+	assert.Equal(t, nil, json.Unmarshal(b, &res))
+	assert.Equal(t, unit, res)
+}
+
+func TestFindWorkUnitOrder(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ab")
+	hasher.MinLength(1)
+	hasher.MaxLength(2)
+	hasher.Prefix("x")
+	hasher.DigestRegexp(".")
+	hasher.Workers(1)
+
+	for i, expected := range []string{"xa", "xb", "xaa", "xab", "xba", "xbb"} {
+		res, err := hasher.FindWorkUnit(WorkUnit{big.NewInt(int64(i)), big.NewInt(1)})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, expected, res)
+	}
+}
+
+func TestFindWorkUnit(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ehj")
+	hasher.MinLength(1)
+	hasher.MaxLength(4)
+	hasher.ExpectedHash(md5Hej)
+	hasher.Workers(3)
+
+	units, err := hasher.WorkUnits(5)
+	assert.Equal(t, nil, err)
+
+	found := []string{}
+	for _, unit := range units {
+		res, err := hasher.FindWorkUnit(unit)
+		if err != nil {
+			assert.Equal(t, "no match found", err.Error())
+			continue
+		}
+		found = append(found, res)
+	}
+	assert.Equal(t, []string{"hej"}, found)
+}
+
+func TestFindWorkUnitOutOfRange(t *testing.T) {
+
+	hasher := NewHasher()
+	hasher.Algo("md5")
+	hasher.AllowedKeys("ab")
+	hasher.Length(2)
+	hasher.ExpectedHash(md5Hej)
+
+	_, err := hasher.FindWorkUnit(WorkUnit{big.NewInt(2), big.NewInt(3)})
+	assert.Equal(t, "work unit is out of the keyspace of 4 keys", err.Error())
+}